@@ -0,0 +1,166 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package accesslog produces standards-compliant HTTP access logs through a log scope.
+//
+// Access log lines are emitted at info level through a dedicated scope, which lets
+// operators control them like any other scope and send them to their own file:
+//
+//	scope := log.RegisterScope("access", "HTTP access log", 0)
+//	scope.SetOutput(accessFile)
+//
+//	handler = accesslog.New(scope).Handler(handler)
+package accesslog
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/tetratelabs/log"
+)
+
+// Logger writes access log records through a scope.
+type Logger struct {
+	scope   *log.Scope
+	encoder *Encoder
+
+	mu        sync.RWMutex
+	forwarded []func(*Record)
+}
+
+// New returns a logger producing Combined Log Format lines through the given scope.
+//
+// The scope is switched to log.MessageFormat so that its output consists of the bare
+// access log lines.
+func New(s *log.Scope) *Logger {
+	l, _ := NewWithFormat(s, CombinedFormat)
+	return l
+}
+
+// NewWithFormat is like New, but uses the given Apache mod_log_config style format.
+func NewWithFormat(s *log.Scope, format string) (*Logger, error) {
+	e, err := NewEncoder(format)
+	if err != nil {
+		return nil, err
+	}
+
+	s.SetFormat(log.MessageFormat)
+
+	return &Logger{
+		scope:   s,
+		encoder: e,
+	}, nil
+}
+
+// Log writes a record to the access log, and hands it to the functions given to Forward.
+func (l *Logger) Log(r *Record) {
+	if l.scope.InfoEnabled() {
+		l.scope.Info(l.encoder.Encode(r))
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for _, fn := range l.forwarded {
+		fn(r)
+	}
+}
+
+// Forward makes the logger hand every record to the given function, regardless of the
+// level of its scope, for example to ship them to an Envoy Access Log Service with the
+// als package. The function must not retain the record.
+func (l *Logger) Forward(fn func(*Record)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.forwarded = append(l.forwarded, fn)
+}
+
+// Handler returns a middleware logging every request served by the given handler.
+func (l *Logger) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+		rw := &responseWriter{ResponseWriter: w}
+
+		next.ServeHTTP(rw, req)
+
+		user := ""
+		if req.URL.User != nil {
+			user = req.URL.User.Username()
+		} else if u, _, ok := req.BasicAuth(); ok {
+			user = u
+		}
+
+		status := rw.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		l.Log(&Record{
+			RemoteAddr: req.RemoteAddr,
+			User:       user,
+			Time:       start,
+			Host:       req.Host,
+			Method:     req.Method,
+			URI:        req.RequestURI,
+			Proto:      req.Proto,
+			Header:     req.Header,
+			Status:     status,
+			Size:       rw.size,
+			Duration:   time.Since(start),
+		})
+	})
+}
+
+// responseWriter captures the status and size of a response.
+type responseWriter struct {
+	http.ResponseWriter
+	status int
+	size   int64
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	n, err := w.ResponseWriter.Write(b)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+
+	return nil, nil, errors.New("the response writer does not support hijacking")
+}
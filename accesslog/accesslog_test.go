@@ -0,0 +1,98 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/tetratelabs/log"
+)
+
+func TestHandler(t *testing.T) {
+	scope := log.RegisterScope("TestHandler", "", 0)
+	defer scope.SetOutput(nil)
+	defer scope.SetFormat(log.DefaultFormat)
+
+	var buf bytes.Buffer
+	scope.SetOutput(zapcore.AddSync(&buf))
+
+	handler := New(scope).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		_, _ = w.Write([]byte("short and stout"))
+	}))
+
+	req := httptest.NewRequest("POST", "/pot?brew=1", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.SetBasicAuth("alice", "secret")
+	req.Header.Set("User-Agent", "test-agent")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	pat := `^10\.0\.0\.1 - alice \[[^\]]+\] "POST /pot\?brew=1 HTTP/1\.1" 418 15 "-" "test-agent"\n$`
+	if match, _ := regexp.MatchString(pat, buf.String()); !match {
+		t.Errorf("Got '%v', expected a match with '%v'", buf.String(), pat)
+	}
+}
+
+func TestHandlerDefaultStatus(t *testing.T) {
+	scope := log.RegisterScope("TestHandlerDefaultStatus", "", 0)
+	defer scope.SetOutput(nil)
+	defer scope.SetFormat(log.DefaultFormat)
+
+	var buf bytes.Buffer
+	scope.SetOutput(zapcore.AddSync(&buf))
+
+	l, err := NewWithFormat(scope, "%s %b")
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	handler := l.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+
+	if got := buf.String(); got != "200 -\n" {
+		t.Errorf("Got '%v', expected '200 -\\n'", got)
+	}
+}
+
+func TestNewWithFormatError(t *testing.T) {
+	if _, err := NewWithFormat(log.RegisterScope("TestNewWithFormatError", "", 0), "%z"); err == nil {
+		t.Error("Got success, expected an error")
+	}
+}
+
+func TestForward(t *testing.T) {
+	scope := log.RegisterScope("TestForward", "", 0)
+	defer scope.SetOutputLevel(log.InfoLevel)
+	defer scope.SetFormat(log.DefaultFormat)
+
+	scope.SetOutputLevel(log.NoneLevel)
+
+	var statuses []int
+	l := New(scope)
+	l.Forward(func(r *Record) { statuses = append(statuses, r.Status) })
+
+	l.Log(&Record{Status: 200})
+	l.Log(&Record{Status: 404})
+
+	if len(statuses) != 2 || statuses[0] != 200 || statuses[1] != 404 {
+		t.Errorf("Got %v, expected [200 404]", statuses)
+	}
+}
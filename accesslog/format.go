@@ -0,0 +1,207 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// CommonFormat is the NCSA Common Log Format.
+	CommonFormat = `%h %l %u %t "%r" %>s %b`
+
+	// CombinedFormat is the NCSA Combined Log Format, which adds the referer and user
+	// agent to the Common Log Format.
+	CombinedFormat = CommonFormat + ` "%{Referer}i" "%{User-Agent}i"`
+
+	clfTimeLayout = "02/Jan/2006:15:04:05 -0700"
+)
+
+// Record describes a request that was served.
+type Record struct {
+	// RemoteAddr is the address of the client, with or without a port.
+	RemoteAddr string
+	// User is the authenticated user, if any.
+	User string
+	// Time is when the request was received.
+	Time time.Time
+	// Host is the host the request was sent to, from the URL or the Host header.
+	Host string
+	// Method, URI and Proto make up the request line.
+	Method string
+	URI    string
+	Proto  string
+	// Header holds the request headers.
+	Header http.Header
+	// Status is the status code of the response.
+	Status int
+	// Size is the number of bytes in the response body.
+	Size int64
+	// Duration is the time it took to serve the request.
+	Duration time.Duration
+}
+
+// directive appends a piece of a record to an access log line.
+type directive func(b []byte, r *Record) []byte
+
+// Encoder renders records using an Apache mod_log_config style format, compiled once.
+//
+// The supported directives are %h (remote host), %l (always -), %u (user), %t (time),
+// %r (request line), %m (method), %U (URL path), %q (query string), %H (protocol),
+// %s and %>s (status), %b (size, - when 0), %B (size), %D (duration in microseconds),
+// %T (duration in seconds), %{Name}i (request header) and %% (a percent sign).
+type Encoder struct {
+	directives []directive
+}
+
+// NewEncoder compiles the given format.
+func NewEncoder(format string) (*Encoder, error) {
+	e := &Encoder{}
+
+	literal := strings.Builder{}
+	flush := func() {
+		if literal.Len() > 0 {
+			s := literal.String()
+			e.directives = append(e.directives, func(b []byte, _ *Record) []byte { return append(b, s...) })
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			literal.WriteByte(format[i])
+			continue
+		}
+
+		i++
+		if i == len(format) {
+			return nil, fmt.Errorf("dangling %% at the end of access log format '%s'", format)
+		}
+
+		if format[i] == '%' {
+			literal.WriteByte('%')
+			continue
+		}
+
+		if format[i] == '>' && i+1 < len(format) && format[i+1] == 's' {
+			i++
+		}
+
+		var d directive
+		if format[i] == '{' {
+			end := strings.IndexByte(format[i:], '}')
+			if end < 0 || i+end+1 >= len(format) || format[i+end+1] != 'i' {
+				return nil, fmt.Errorf("invalid header directive in access log format '%s'", format)
+			}
+
+			d = headerDirective(format[i+1 : i+end])
+			i += end + 1
+		} else {
+			var ok bool
+			if d, ok = directives[format[i]]; !ok {
+				return nil, fmt.Errorf("unknown directive %%%c in access log format '%s'", format[i], format)
+			}
+		}
+
+		flush()
+		e.directives = append(e.directives, d)
+	}
+	flush()
+
+	return e, nil
+}
+
+// Encode renders a record as a single access log line, without a line ending.
+func (e *Encoder) Encode(r *Record) string {
+	return string(e.AppendRecord(make([]byte, 0, 256), r))
+}
+
+// AppendRecord renders a record and appends it to the given buffer.
+func (e *Encoder) AppendRecord(b []byte, r *Record) []byte {
+	for _, d := range e.directives {
+		b = d(b, r)
+	}
+
+	return b
+}
+
+var directives = map[byte]directive{
+	'h': func(b []byte, r *Record) []byte { return appendOrDash(b, remoteHost(r.RemoteAddr)) },
+	'v': func(b []byte, r *Record) []byte { return appendOrDash(b, r.Host) },
+	'l': func(b []byte, _ *Record) []byte { return append(b, '-') },
+	'u': func(b []byte, r *Record) []byte { return appendOrDash(b, r.User) },
+	't': func(b []byte, r *Record) []byte {
+		b = append(b, '[')
+		b = r.Time.AppendFormat(b, clfTimeLayout)
+		return append(b, ']')
+	},
+	'r': func(b []byte, r *Record) []byte {
+		b = append(b, r.Method...)
+		b = append(b, ' ')
+		b = append(b, r.URI...)
+		b = append(b, ' ')
+		return append(b, r.Proto...)
+	},
+	'm': func(b []byte, r *Record) []byte { return append(b, r.Method...) },
+	'U': func(b []byte, r *Record) []byte {
+		if i := strings.IndexByte(r.URI, '?'); i >= 0 {
+			return append(b, r.URI[:i]...)
+		}
+		return append(b, r.URI...)
+	},
+	'q': func(b []byte, r *Record) []byte {
+		if i := strings.IndexByte(r.URI, '?'); i >= 0 {
+			return append(b, r.URI[i:]...)
+		}
+		return b
+	},
+	'H': func(b []byte, r *Record) []byte { return append(b, r.Proto...) },
+	's': func(b []byte, r *Record) []byte { return strconv.AppendInt(b, int64(r.Status), 10) },
+	'b': func(b []byte, r *Record) []byte {
+		if r.Size == 0 {
+			return append(b, '-')
+		}
+		return strconv.AppendInt(b, r.Size, 10)
+	},
+	'B': func(b []byte, r *Record) []byte { return strconv.AppendInt(b, r.Size, 10) },
+	'D': func(b []byte, r *Record) []byte { return strconv.AppendInt(b, r.Duration.Microseconds(), 10) },
+	'T': func(b []byte, r *Record) []byte { return strconv.AppendInt(b, int64(r.Duration/time.Second), 10) },
+}
+
+func headerDirective(name string) directive {
+	return func(b []byte, r *Record) []byte {
+		return appendOrDash(b, r.Header.Get(name))
+	}
+}
+
+func appendOrDash(b []byte, s string) []byte {
+	if s == "" {
+		return append(b, '-')
+	}
+
+	return append(b, s...)
+}
+
+func remoteHost(addr string) string {
+	if i := strings.LastIndexByte(addr, ':'); i >= 0 && !strings.HasSuffix(addr, "]") {
+		addr = addr[:i]
+	}
+
+	return strings.TrimSuffix(strings.TrimPrefix(addr, "["), "]")
+}
@@ -0,0 +1,100 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestEncoder(t *testing.T) {
+	r := &Record{
+		RemoteAddr: "127.0.0.1:54321",
+		User:       "frank",
+		Time:       time.Date(2000, time.October, 10, 13, 55, 36, 0, time.FixedZone("", -7*60*60)),
+		Host:       "www.example.com",
+		Method:     "GET",
+		URI:        "/apache_pb.gif?size=large",
+		Proto:      "HTTP/1.0",
+		Header: http.Header{
+			"Referer":    []string{"http://www.example.com/start.html"},
+			"User-Agent": []string{"Mozilla/4.08"},
+		},
+		Status:   200,
+		Size:     2326,
+		Duration: 1500 * time.Millisecond,
+	}
+
+	cases := []struct {
+		format string
+		result string
+	}{
+		{CommonFormat, `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif?size=large HTTP/1.0" 200 2326`},
+		{CombinedFormat, `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif?size=large HTTP/1.0" 200 2326 "http://www.example.com/start.html" "Mozilla/4.08"`},
+		{"%v %m %U %q %H", "www.example.com GET /apache_pb.gif ?size=large HTTP/1.0"},
+		{"%s %B %D %T", "200 2326 1500000 1"},
+		{"%{X-Missing}i 100%%", "- 100%"},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			e, err := NewEncoder(c.format)
+			if err != nil {
+				t.Fatalf("Got error '%v', expected success", err)
+			}
+
+			if got := e.Encode(r); got != c.result {
+				t.Errorf("Got '%v', expected '%v'", got, c.result)
+			}
+		})
+	}
+}
+
+func TestEncoderEmptyValues(t *testing.T) {
+	e, _ := NewEncoder(CommonFormat)
+
+	got := e.Encode(&Record{
+		RemoteAddr: "[::1]:8080",
+		Time:       time.Date(2000, time.January, 2, 3, 4, 5, 0, time.UTC),
+		Method:     "HEAD",
+		URI:        "/",
+		Proto:      "HTTP/1.1",
+		Status:     204,
+	})
+
+	expected := `::1 - - [02/Jan/2000:03:04:05 +0000] "HEAD / HTTP/1.1" 204 -`
+	if got != expected {
+		t.Errorf("Got '%v', expected '%v'", got, expected)
+	}
+}
+
+func TestEncoderErrors(t *testing.T) {
+	cases := []string{
+		"%",
+		"%z",
+		"%{Referer",
+		"%{Referer}o",
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if _, err := NewEncoder(c); err == nil {
+				t.Errorf("Got success, expected an error for format '%v'", c)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"bytes"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// affixSink writes a prefix at the start and a suffix at the end of every line written to
+// the wrapped output.
+type affixSink struct {
+	zapcore.WriteSyncer
+	prefix []byte
+	suffix []byte
+}
+
+// newAffixSink wraps an output with the line prefix and suffix of the options, if any.
+func newAffixSink(ws zapcore.WriteSyncer, options *Options) zapcore.WriteSyncer {
+	if ws == nil || (options.LinePrefix == "" && options.LineSuffix == "") {
+		return ws
+	}
+
+	a := newAffix(options)
+	a.WriteSyncer = ws
+	return a
+}
+
+// newAffix returns the line prefix and suffix of the options, without a destination.
+func newAffix(options *Options) affixSink {
+	return affixSink{prefix: []byte(options.LinePrefix), suffix: []byte(options.LineSuffix)}
+}
+
+func (a affixSink) Write(p []byte) (int, error) {
+	lines := bytes.Count(p, []byte{'\n'}) + 1
+	buf := make([]byte, 0, len(p)+lines*(len(a.prefix)+len(a.suffix)))
+
+	for rest := p; len(rest) > 0; {
+		i := bytes.IndexByte(rest, '\n')
+		if i < 0 {
+			i = len(rest)
+		}
+
+		buf = append(buf, a.prefix...)
+		buf = append(buf, rest[:i]...)
+		buf = append(buf, a.suffix...)
+		if i < len(rest) {
+			buf = append(buf, '\n')
+			i++
+		}
+		rest = rest[i:]
+	}
+
+	if _, err := a.WriteSyncer.Write(buf); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
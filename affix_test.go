@@ -0,0 +1,110 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestAffixSink(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{"", ""},
+		{"one\n", "> one <\n"},
+		{"one\ntwo\n", "> one <\n> two <\n"},
+		{"partial", "> partial <"},
+		{"\n", ">  <\n"},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var buf bytes.Buffer
+			ws := newAffixSink(zapcore.AddSync(&buf), &Options{LinePrefix: "> ", LineSuffix: " <"})
+
+			n, err := ws.Write([]byte(c.input))
+			if err != nil || n != len(c.input) {
+				t.Errorf("Got %d, %v, expected %d, nil", n, err, len(c.input))
+			}
+
+			if buf.String() != c.expected {
+				t.Errorf("Got %q, expected %q", buf.String(), c.expected)
+			}
+		})
+	}
+}
+
+func TestLinePrefix(t *testing.T) {
+	lines, err := captureStdout(func() {
+		o := DefaultOptions()
+		o.LinePrefix = "[app] "
+		o.LineSuffix = " ;"
+		if err := Configure(o); err != nil {
+			t.Errorf("Got error '%v', expected success", err)
+		}
+
+		Info("Hello")
+		_ = Sync()
+	})
+	_ = Configure(DefaultOptions())
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	if !strings.HasPrefix(lines[0], "[app] ") || !strings.HasSuffix(lines[0], "Hello ;") {
+		t.Errorf("Got '%v', expected the prefix and the suffix", lines[0])
+	}
+}
+
+func TestLinePrefixScopeOutputs(t *testing.T) {
+	s := RegisterScope("TestLinePrefixScopeOutputs", "", 0)
+	own, routed := &bufferSyncer{}, &bufferSyncer{}
+	s.SetOutput(own)
+	defer s.SetOutput(nil)
+
+	o := DefaultOptions()
+	o.LinePrefix = "[app] "
+	if err := Configure(o); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = Configure(DefaultOptions()) }()
+
+	s.Info("own")
+	s.Freeze().Info("frozen")
+
+	SetRouter(func(context.Context, zapcore.Entry, []zapcore.Field) zapcore.WriteSyncer { return routed }, routed)
+	defer SetRouter(nil)
+	s.Info("routed")
+
+	for _, out := range []string{own.String(), routed.String()} {
+		lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+		for _, l := range lines {
+			if !strings.HasPrefix(l, "[app] ") {
+				t.Errorf("Got '%v', expected the prefix", l)
+			}
+		}
+	}
+
+	if !strings.Contains(own.String(), "frozen") || !strings.Contains(routed.String(), "routed") {
+		t.Errorf("Got '%v' and '%v', expected the entries in their outputs", own.String(), routed.String())
+	}
+}
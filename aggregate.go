@@ -0,0 +1,250 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// errorBuckets is the number of buckets the sliding window of the error counts is divided in.
+const errorBuckets = 10
+
+// ErrorFingerprint identifies a family of error entries: those logged by the same scope,
+// from the same message template, about the same type of error.
+type ErrorFingerprint struct {
+	// Scope is the name of the scope which logged the entries.
+	Scope string
+	// Template is the format string given to Errorf, or the message given to the other methods.
+	Template string
+	// ErrorType is the Go type of the first error logged with the entries, if any.
+	ErrorType string
+}
+
+// ErrorCount is the number of error entries with a fingerprint logged during the window.
+type ErrorCount struct {
+	ErrorFingerprint
+	Count uint64
+}
+
+// errorAggregator counts the error entries per fingerprint over a sliding window, divided
+// in buckets which are reset as the window moves on.
+type errorAggregator struct {
+	window time.Duration
+	width  time.Duration
+
+	mu     sync.Mutex
+	counts map[ErrorFingerprint]*errorWindow
+}
+
+type errorWindow struct {
+	counts [errorBuckets]uint64
+	slots  [errorBuckets]int64
+}
+
+// set by the Configure method, holds a *errorAggregator, nil when aggregation is disabled
+var errorAggregation atomic.Value
+
+// reset by the Configure method
+var errorReporter struct {
+	sync.Mutex
+	stop chan struct{}
+}
+
+func newErrorAggregator(window time.Duration) *errorAggregator {
+	width := window / errorBuckets
+	if width <= 0 {
+		width = 1
+	}
+
+	return &errorAggregator{
+		window: window,
+		width:  width,
+		counts: make(map[ErrorFingerprint]*errorWindow),
+	}
+}
+
+func (a *errorAggregator) record(fp ErrorFingerprint, now time.Time) {
+	slot := now.UnixNano() / int64(a.width)
+	i := slot % errorBuckets
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	w, ok := a.counts[fp]
+	if !ok {
+		w = &errorWindow{}
+		a.counts[fp] = w
+	}
+
+	if w.slots[i] != slot {
+		w.slots[i] = slot
+		w.counts[i] = 0
+	}
+	w.counts[i]++
+}
+
+// snapshot returns the counts of the window ending now, and forgets the fingerprints which
+// weren't seen during the window.
+func (a *errorAggregator) snapshot(now time.Time) []ErrorCount {
+	oldest := now.UnixNano()/int64(a.width) - errorBuckets + 1
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	counts := make([]ErrorCount, 0, len(a.counts))
+	for fp, w := range a.counts {
+		var n uint64
+		for i := range w.slots {
+			if w.slots[i] >= oldest {
+				n += w.counts[i]
+			}
+		}
+
+		if n == 0 {
+			delete(a.counts, fp)
+			continue
+		}
+
+		counts = append(counts, ErrorCount{ErrorFingerprint: fp, Count: n})
+	}
+
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		if counts[i].Scope != counts[j].Scope {
+			return counts[i].Scope < counts[j].Scope
+		}
+		return counts[i].Template < counts[j].Template
+	})
+
+	return counts
+}
+
+// ErrorCounts returns the number of error entries logged per fingerprint during the last
+// Options.ErrorSummaryInterval, most frequent first. It returns nil when error aggregation
+// is disabled.
+func ErrorCounts() []ErrorCount {
+	a, _ := errorAggregation.Load().(*errorAggregator)
+	if a == nil {
+		return nil
+	}
+
+	return a.snapshot(now())
+}
+
+// countError accounts for an error entry, if error aggregation is enabled, and applies the
+// escalation policies.
+func (s *Scope) countError(template string, errType string) {
+	if s.GetOutputLevel() < ErrorLevel {
+		// only recorded for crash reports
+		return
+	}
+
+	fp := ErrorFingerprint{Scope: s.name, Template: template, ErrorType: errType}
+	if a, _ := errorAggregation.Load().(*errorAggregator); a != nil {
+		a.record(fp, now())
+	}
+	s.escalate(fp)
+}
+
+// fieldsErrorType returns the type of the first error held by the fields.
+func fieldsErrorType(fields []zapcore.Field) string {
+	for _, f := range fields {
+		if f.Type == zapcore.ErrorType {
+			return fmt.Sprintf("%T", f.Interface)
+		}
+	}
+
+	return ""
+}
+
+// argsErrorType returns the type of the first error amongst the arguments, which can
+// also be fields.
+func argsErrorType(args []interface{}) string {
+	for _, a := range args {
+		switch v := a.(type) {
+		case error:
+			return fmt.Sprintf("%T", v)
+		case zapcore.Field:
+			if v.Type == zapcore.ErrorType {
+				return fmt.Sprintf("%T", v.Interface)
+			}
+		}
+	}
+
+	return ""
+}
+
+// startErrorReporter replaces any running aggregation with one that counts error entries
+// over the given window, and logs a summary of the repeated errors at the end of each
+// window. A window of 0 disables the aggregation.
+func startErrorReporter(window time.Duration) {
+	errorReporter.Lock()
+	defer errorReporter.Unlock()
+
+	if errorReporter.stop != nil {
+		close(errorReporter.stop)
+		errorReporter.stop = nil
+	}
+
+	if window <= 0 {
+		errorAggregation.Store((*errorAggregator)(nil))
+		return
+	}
+
+	a := newErrorAggregator(window)
+	errorAggregation.Store(a)
+
+	stop := make(chan struct{})
+	errorReporter.stop = stop
+
+	go func() {
+		t := time.NewTicker(window)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-t.C:
+				reportErrors(a.snapshot(now()), window)
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// reportErrors logs an entry for each fingerprint seen more than once.
+func reportErrors(counts []ErrorCount, window time.Duration) {
+	for _, c := range counts {
+		if c.Count < 2 {
+			continue
+		}
+
+		Warn("error repeated",
+			zap.String("error_scope", c.Scope),
+			zap.String("template", c.Template),
+			zap.String("error_type", c.ErrorType),
+			zap.Uint64("count", c.Count),
+			zap.Duration("window", window))
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestErrorAggregatorWindow(t *testing.T) {
+	a := newErrorAggregator(10 * time.Second)
+	fp := ErrorFingerprint{Scope: "s", Template: "t"}
+	start := time.Unix(1000, 0)
+
+	a.record(fp, start)
+	a.record(fp, start.Add(time.Second))
+	a.record(fp, start.Add(5*time.Second))
+
+	cases := []struct {
+		at       time.Duration
+		expected uint64
+	}{
+		{5 * time.Second, 3},
+		{9 * time.Second, 3},
+		{10 * time.Second, 2},
+		{14 * time.Second, 1},
+		{15 * time.Second, 0},
+	}
+
+	for _, c := range cases {
+		counts := a.snapshot(start.Add(c.at))
+
+		var got uint64
+		if len(counts) > 0 {
+			got = counts[0].Count
+		}
+
+		if got != c.expected {
+			t.Errorf("Got %d at %v, expected %d", got, c.at, c.expected)
+		}
+	}
+
+	if len(a.counts) != 0 {
+		t.Errorf("Got %v, expected fingerprints out of the window to be forgotten", a.counts)
+	}
+}
+
+func TestErrorCounts(t *testing.T) {
+	s := RegisterScope("TestErrorCounts", "", 0)
+
+	lines, err := captureStdout(func() {
+		o := DefaultOptions()
+		o.ErrorSummaryInterval = time.Hour
+		if err := Configure(o); err != nil {
+			t.Fatalf("Got error '%v', expected success", err)
+		}
+		defer func() { _ = Configure(DefaultOptions()) }()
+
+		for i := 0; i < 3; i++ {
+			s.Errorf("read %d failed: %v", i, io.EOF)
+		}
+		s.Error("boom", zap.Error(&os.PathError{Op: "open", Path: "/x", Err: errors.New("denied")}))
+		Errorw("bang", "attempt", 1)
+		s.Warn("not an error")
+
+		expected := []ErrorCount{
+			{ErrorFingerprint{Scope: "TestErrorCounts", Template: "read %d failed: %v", ErrorType: "*errors.errorString"}, 3},
+			{ErrorFingerprint{Scope: "TestErrorCounts", Template: "boom", ErrorType: "*fs.PathError"}, 1},
+			{ErrorFingerprint{Scope: DefaultScopeName, Template: "bang"}, 1},
+		}
+
+		got := ErrorCounts()
+		if len(got) == 3 && strings.HasPrefix(got[1].ErrorType, "*os.") {
+			// os.PathError isn't an alias of fs.PathError before Go 1.16
+			expected[1].ErrorType = got[1].ErrorType
+		}
+
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("Got %v, expected %v", got, expected)
+		}
+
+		reportErrors(got, time.Hour)
+		_ = Sync()
+	})
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	var summaries []string
+	for _, l := range lines {
+		if strings.Contains(l, "error repeated") {
+			summaries = append(summaries, l)
+		}
+	}
+
+	if len(summaries) != 1 || !strings.Contains(summaries[0], `"count": 3`) {
+		t.Errorf("Got %v, expected a summary of the repeated error only", summaries)
+	}
+
+	if ErrorCounts() != nil {
+		t.Error("Got counts, expected nil once aggregation is disabled")
+	}
+}
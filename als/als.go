@@ -0,0 +1,166 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package als ships access log records to an Envoy gRPC Access Log Service (ALS)
+// collector, alongside the access logs of the Envoy proxies of a mesh.
+//
+// The package does not depend on the Envoy API definitions. Applications adapt the
+// StreamAccessLogs client of go-control-plane to the Stream interface, turning each
+// HTTPEntry into an HTTPAccessLogEntry, and forward the records of an access logger:
+//
+//	sink := als.NewSink(myStream, "node-1", "my-service")
+//	accessLogger.Forward(sink.Log)
+package als
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tetratelabs/log"
+	"github.com/tetratelabs/log/accesslog"
+)
+
+var scope = log.RegisterScope("als", "Envoy Access Log Service sink.", 0)
+
+// Identifier identifies the source of the entries of a stream. As mandated by the ALS
+// protocol, it is only sent with the first message of a stream.
+type Identifier struct {
+	// Node is the id of the node producing the entries.
+	Node string
+	// LogName is the name of the access log, as configured in Envoy access log configurations.
+	LogName string
+}
+
+// HTTPEntry holds the subset of the fields of an Envoy HTTPAccessLogEntry that can be
+// filled from an access log record. Field names follow the Envoy API.
+type HTTPEntry struct {
+	// StartTime is when the request was received.
+	StartTime time.Time
+	// TimeToLastDownstreamTxByte is how long it took to serve the request.
+	TimeToLastDownstreamTxByte time.Duration
+	// DownstreamRemoteAddress is the address of the client.
+	DownstreamRemoteAddress string
+	// ProtocolVersion is one of HTTP10, HTTP11, HTTP2 and HTTP3, or empty if unknown.
+	ProtocolVersion string
+
+	RequestMethod string
+	Authority     string
+	Path          string
+	UserAgent     string
+	Referer       string
+	ForwardedFor  string
+	RequestID     string
+
+	ResponseCode      uint32
+	ResponseBodyBytes uint64
+}
+
+// Stream sends access log entries on an Envoy StreamAccessLogs gRPC stream.
+type Stream interface {
+	// Send sends a StreamAccessLogsMessage holding the given HTTP entries. The identifier
+	// is only given for the first message of the stream, it is nil afterwards.
+	Send(identifier *Identifier, entries []*HTTPEntry) error
+}
+
+// Sink converts access log records to ALS entries and sends them on a stream.
+type Sink struct {
+	stream     Stream
+	identifier Identifier
+
+	mu     sync.Mutex
+	sent   bool
+	failed uint64
+}
+
+// NewSink returns a sink sending entries on the given stream, for the given node and log name.
+func NewSink(s Stream, node, logName string) *Sink {
+	return &Sink{
+		stream:     s,
+		identifier: Identifier{Node: node, LogName: logName},
+	}
+}
+
+// Log converts a record and sends it on the stream. Failures are logged, and the
+// identifier is sent again with the next message, as a new stream is then expected.
+func (s *Sink) Log(r *accesslog.Record) {
+	entry := Convert(r)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var id *Identifier
+	if !s.sent {
+		id = &s.identifier
+	}
+
+	if err := s.stream.Send(id, []*HTTPEntry{entry}); err != nil {
+		s.sent = false
+		s.failed++
+		scope.Warnf("unable to send access log entry to the access log service: %v", err)
+		return
+	}
+
+	s.sent = true
+}
+
+// Failed returns the number of entries which could not be sent.
+func (s *Sink) Failed() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.failed
+}
+
+// Convert turns an access log record into an ALS HTTP entry.
+func Convert(r *accesslog.Record) *HTTPEntry {
+	e := &HTTPEntry{
+		StartTime:                  r.Time,
+		TimeToLastDownstreamTxByte: r.Duration,
+		DownstreamRemoteAddress:    r.RemoteAddr,
+		ProtocolVersion:            protocolVersion(r.Proto),
+		RequestMethod:              r.Method,
+		Authority:                  r.Host,
+		Path:                       r.URI,
+		ResponseCode:               uint32(r.Status),
+	}
+
+	if r.Size > 0 {
+		e.ResponseBodyBytes = uint64(r.Size)
+	}
+
+	if r.Header != nil {
+		e.UserAgent = r.Header.Get("User-Agent")
+		e.Referer = r.Header.Get("Referer")
+		e.ForwardedFor = r.Header.Get("X-Forwarded-For")
+		e.RequestID = r.Header.Get("X-Request-Id")
+	}
+
+	return e
+}
+
+func protocolVersion(proto string) string {
+	switch strings.ToUpper(proto) {
+	case "HTTP/1.0":
+		return "HTTP10"
+	case "HTTP/1.1":
+		return "HTTP11"
+	case "HTTP/2", "HTTP/2.0":
+		return "HTTP2"
+	case "HTTP/3", "HTTP/3.0":
+		return "HTTP3"
+	}
+
+	return ""
+}
@@ -0,0 +1,114 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package als
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/tetratelabs/log/accesslog"
+)
+
+type message struct {
+	identifier *Identifier
+	entries    []*HTTPEntry
+}
+
+type fakeStream struct {
+	messages []message
+	fail     bool
+}
+
+func (s *fakeStream) Send(identifier *Identifier, entries []*HTTPEntry) error {
+	if s.fail {
+		return errors.New("stream broken")
+	}
+
+	s.messages = append(s.messages, message{identifier, entries})
+	return nil
+}
+
+func TestConvert(t *testing.T) {
+	start := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	e := Convert(&accesslog.Record{
+		RemoteAddr: "10.0.0.1:1234",
+		Time:       start,
+		Host:       "example.com",
+		Method:     "GET",
+		URI:        "/path?q=1",
+		Proto:      "HTTP/1.1",
+		Header: http.Header{
+			"User-Agent":   []string{"agent"},
+			"X-Request-Id": []string{"42"},
+		},
+		Status:   200,
+		Size:     512,
+		Duration: time.Second,
+	})
+
+	expected := HTTPEntry{
+		StartTime:                  start,
+		TimeToLastDownstreamTxByte: time.Second,
+		DownstreamRemoteAddress:    "10.0.0.1:1234",
+		ProtocolVersion:            "HTTP11",
+		RequestMethod:              "GET",
+		Authority:                  "example.com",
+		Path:                       "/path?q=1",
+		UserAgent:                  "agent",
+		RequestID:                  "42",
+		ResponseCode:               200,
+		ResponseBodyBytes:          512,
+	}
+
+	if *e != expected {
+		t.Errorf("Got %+v, expected %+v", *e, expected)
+	}
+}
+
+func TestSink(t *testing.T) {
+	stream := &fakeStream{}
+	s := NewSink(stream, "node-1", "service")
+
+	s.Log(&accesslog.Record{Status: 200})
+	s.Log(&accesslog.Record{Status: 201})
+
+	stream.fail = true
+	s.Log(&accesslog.Record{Status: 500})
+	stream.fail = false
+
+	s.Log(&accesslog.Record{Status: 202})
+
+	if len(stream.messages) != 3 {
+		t.Fatalf("Got %d messages, expected 3", len(stream.messages))
+	}
+
+	for i, withID := range []bool{true, false, true} {
+		m := stream.messages[i]
+		if (m.identifier != nil) != withID {
+			t.Errorf("Got identifier %v for message %d, expected one: %v", m.identifier, i, withID)
+		}
+
+		if m.identifier != nil && (m.identifier.Node != "node-1" || m.identifier.LogName != "service") {
+			t.Errorf("Got %+v, expected the node and log name", m.identifier)
+		}
+	}
+
+	if s.Failed() != 1 {
+		t.Errorf("Got %d failures, expected 1", s.Failed())
+	}
+}
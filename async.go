@@ -0,0 +1,260 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const defaultAsyncQueueSize = 10000
+
+// BackpressurePolicy determines what an AsyncWriter does when its queue is full.
+type BackpressurePolicy int
+
+const (
+	// DropNewest discards the entry being written, keeping the queue intact.
+	DropNewest BackpressurePolicy = iota
+	// DropOldest discards the oldest queued entry to make room for the new one.
+	DropOldest
+	// Block makes the writer wait until there is room in the queue, slowing down the application.
+	Block
+)
+
+var policyToString = map[BackpressurePolicy]string{
+	DropNewest: "drop-newest",
+	DropOldest: "drop-oldest",
+	Block:      "block",
+}
+
+// String returns the name of the policy
+func (p BackpressurePolicy) String() string {
+	return policyToString[p]
+}
+
+// AsyncOptions controls the behavior of an AsyncWriter.
+type AsyncOptions struct {
+	// QueueSize is the maximum number of entries waiting to be written.
+	QueueSize int
+
+	// Policy determines what happens to new entries once the queue is full.
+	Policy BackpressurePolicy
+
+	// HighWaterMark is the number of queued entries at which OnHighWaterMark is
+	// invoked. The callback fires once each time the mark is crossed upwards.
+	// A value of 0 disables the callback.
+	HighWaterMark int
+
+	// OnHighWaterMark is invoked with the current queue length when the queue reaches
+	// the HighWaterMark. It must not log through the writer that invokes it.
+	OnHighWaterMark func(queued int)
+}
+
+// DefaultAsyncOptions returns a new set of async options, initialized to the defaults
+func DefaultAsyncOptions() *AsyncOptions {
+	return &AsyncOptions{
+		QueueSize: defaultAsyncQueueSize,
+		Policy:    DropNewest,
+	}
+}
+
+// AsyncWriter decouples the application from a slow writer by queuing entries and
+// writing them from a background goroutine. What happens when the queue fills up
+// is determined by its BackpressurePolicy.
+//
+// AsyncWriter is typically layered on top of a BatchWriter wrapping a network sink.
+type AsyncWriter struct {
+	out     io.Writer
+	options AsyncOptions
+
+	mu            sync.Mutex
+	cond          *sync.Cond
+	queue         [][]byte
+	writing       bool
+	closed        bool
+	aboveHighMark bool
+	dropped       uint64
+
+	done chan struct{}
+}
+
+// NewAsyncWriter returns a writer that asynchronously forwards entries to the given writer.
+// Close must be called to release the background goroutine.
+func NewAsyncWriter(w io.Writer, options *AsyncOptions) *AsyncWriter {
+	if options == nil {
+		options = DefaultAsyncOptions()
+	}
+
+	aw := &AsyncWriter{
+		out:     w,
+		options: *options,
+		done:    make(chan struct{}),
+	}
+	aw.cond = sync.NewCond(&aw.mu)
+
+	if aw.options.QueueSize <= 0 {
+		aw.options.QueueSize = 1
+	}
+
+	go aw.run()
+
+	return aw
+}
+
+// Write queues an entry. It only blocks when the queue is full and the Block policy is in effect.
+func (aw *AsyncWriter) Write(p []byte) (int, error) {
+	// the caller owns p and is free to reuse it once we return
+	b := make([]byte, len(p))
+	copy(b, p)
+
+	aw.mu.Lock()
+
+	for len(aw.queue) >= aw.options.QueueSize && !aw.closed {
+		if aw.options.Policy == DropNewest {
+			aw.drop()
+			aw.mu.Unlock()
+			return len(p), nil
+		}
+
+		if aw.options.Policy == DropOldest {
+			aw.queue[0] = nil
+			aw.queue = aw.queue[1:]
+			aw.drop()
+			break
+		}
+
+		aw.cond.Wait()
+	}
+
+	if aw.closed {
+		aw.drop()
+		aw.mu.Unlock()
+		return len(p), nil
+	}
+
+	aw.queue = append(aw.queue, b)
+	queued := len(aw.queue)
+
+	notify := false
+	if aw.options.HighWaterMark > 0 && queued >= aw.options.HighWaterMark && !aw.aboveHighMark {
+		aw.aboveHighMark = true
+		notify = aw.options.OnHighWaterMark != nil
+	}
+
+	aw.cond.Broadcast()
+	aw.mu.Unlock()
+
+	if notify {
+		aw.options.OnHighWaterMark(queued)
+	}
+
+	return len(p), nil
+}
+
+// Sync waits for all queued entries to be written and then syncs the underlying
+// writer, if it supports it.
+func (aw *AsyncWriter) Sync() error {
+	aw.mu.Lock()
+	for (len(aw.queue) > 0 || aw.writing) && !aw.closed {
+		aw.cond.Wait()
+	}
+	aw.mu.Unlock()
+
+	if s, ok := aw.out.(zapcore.WriteSyncer); ok {
+		return s.Sync()
+	}
+
+	return nil
+}
+
+// Close writes out all queued entries, stops the background goroutine and closes
+// the underlying writer, if it supports it. Entries written after Close are dropped.
+func (aw *AsyncWriter) Close() error {
+	aw.mu.Lock()
+	aw.closed = true
+	aw.cond.Broadcast()
+	aw.mu.Unlock()
+
+	<-aw.done
+
+	if c, ok := aw.out.(io.Closer); ok {
+		return c.Close()
+	}
+
+	return nil
+}
+
+// Dropped returns the number of entries discarded because of backpressure.
+func (aw *AsyncWriter) Dropped() uint64 {
+	aw.mu.Lock()
+	defer aw.mu.Unlock()
+
+	return aw.dropped
+}
+
+func (aw *AsyncWriter) run() {
+	defer close(aw.done)
+
+	for {
+		aw.mu.Lock()
+		for len(aw.queue) == 0 && !aw.closed {
+			aw.cond.Wait()
+		}
+
+		if len(aw.queue) == 0 {
+			aw.mu.Unlock()
+			return
+		}
+
+		batch := aw.queue
+		aw.queue = nil
+		aw.writing = true
+		aw.aboveHighMark = false
+		aw.cond.Broadcast()
+		aw.mu.Unlock()
+
+		for _, b := range batch {
+			_, _ = aw.out.Write(b)
+		}
+
+		aw.mu.Lock()
+		aw.writing = false
+		aw.cond.Broadcast()
+		aw.mu.Unlock()
+	}
+}
+
+func (aw *AsyncWriter) drop() {
+	aw.dropped++
+	recordDropped(DroppedByAsyncSink, 1)
+}
+
+// Run blocks until the context is done, then closes the writer, which writes out all the
+// queued entries before returning, and returns the error of Close. It returns nil right
+// away if the writer is closed first. It ties the writer to the lifecycle of a service,
+// for example managed by an errgroup:
+//
+//	g.Go(func() error { return w.Run(ctx) })
+func (aw *AsyncWriter) Run(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return aw.Close()
+	case <-aw.done:
+		return nil
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// gatedWriter blocks every write until the gate is opened.
+type gatedWriter struct {
+	recordingWriter
+	gate chan struct{}
+}
+
+func (gw *gatedWriter) Write(p []byte) (int, error) {
+	<-gw.gate
+	return gw.recordingWriter.Write(p)
+}
+
+// waitForWriting waits until the background goroutine took the queued entries.
+func waitForWriting(aw *AsyncWriter) {
+	for {
+		aw.mu.Lock()
+		w := aw.writing
+		aw.mu.Unlock()
+
+		if w {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestAsyncWriterPolicies(t *testing.T) {
+	cases := []struct {
+		policy  BackpressurePolicy
+		dropped uint64
+		written string
+	}{
+		{DropNewest, 2, "0123"},
+		{DropOldest, 2, "0345"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.policy.String(), func(t *testing.T) {
+			gw := &gatedWriter{gate: make(chan struct{})}
+			aw := NewAsyncWriter(gw, &AsyncOptions{QueueSize: 3, Policy: c.policy})
+
+			// the first entry is picked up by the background goroutine and held by the gate
+			_, _ = aw.Write([]byte("0"))
+			waitForWriting(aw)
+
+			for _, s := range []string{"1", "2", "3", "4", "5"} {
+				_, _ = aw.Write([]byte(s))
+			}
+
+			if d := aw.Dropped(); d != c.dropped {
+				t.Errorf("Got %d dropped entries, expected %d", d, c.dropped)
+			}
+
+			close(gw.gate)
+			_ = aw.Close()
+
+			if got := strings.Join(gw.get(), ""); got != c.written {
+				t.Errorf("Got %q, expected %q", got, c.written)
+			}
+		})
+	}
+}
+
+func TestAsyncWriterBlock(t *testing.T) {
+	gw := &gatedWriter{gate: make(chan struct{})}
+	aw := NewAsyncWriter(gw, &AsyncOptions{QueueSize: 1, Policy: Block})
+
+	_, _ = aw.Write([]byte("0"))
+	waitForWriting(aw)
+	_, _ = aw.Write([]byte("1"))
+
+	written := make(chan struct{})
+	go func() {
+		_, _ = aw.Write([]byte("2"))
+		close(written)
+	}()
+
+	select {
+	case <-written:
+		t.Fatal("Write did not block on a full queue")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(gw.gate)
+	<-written
+
+	if err := aw.Sync(); err != nil {
+		t.Errorf("Got err '%v', expecting success", err)
+	}
+
+	if got := strings.Join(gw.get(), ""); got != "012" {
+		t.Errorf("Got %q, expected %q", got, "012")
+	}
+
+	_ = aw.Close()
+
+	if d := aw.Dropped(); d != 0 {
+		t.Errorf("Got %d dropped entries, expected 0", d)
+	}
+}
+
+func TestAsyncWriterHighWaterMark(t *testing.T) {
+	var marks []int
+
+	gw := &gatedWriter{gate: make(chan struct{})}
+	aw := NewAsyncWriter(gw, &AsyncOptions{
+		QueueSize:       10,
+		HighWaterMark:   2,
+		OnHighWaterMark: func(queued int) { marks = append(marks, queued) },
+	})
+
+	_, _ = aw.Write([]byte("0"))
+	waitForWriting(aw)
+
+	for _, s := range []string{"1", "2", "3"} {
+		_, _ = aw.Write([]byte(s))
+	}
+
+	close(gw.gate)
+	_ = aw.Close()
+
+	if len(marks) != 1 || marks[0] != 2 {
+		t.Errorf("Got %v, expected a single notification at 2", marks)
+	}
+}
+
+func TestAsyncWriterRun(t *testing.T) {
+	rw := &recordingWriter{}
+	aw := NewAsyncWriter(rw, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() { errs <- aw.Run(ctx) }()
+
+	for i := 0; i < 100; i++ {
+		_, _ = aw.Write([]byte("entry\n"))
+	}
+	cancel()
+
+	if err := <-errs; err != nil {
+		t.Errorf("Got err '%v', expecting success", err)
+	}
+
+	// the queue was drained before Run returned
+	if got := len(rw.get()); got != 100 {
+		t.Errorf("Got %d entries, expected 100", got)
+	}
+
+	// Run returns once the writer is closed
+	aw = NewAsyncWriter(rw, nil)
+	_ = aw.Close()
+	if err := aw.Run(context.Background()); err != nil {
+		t.Errorf("Got err '%v', expecting success", err)
+	}
+}
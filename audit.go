@@ -0,0 +1,55 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// set by the Configure method, non-zero when level changes are audited
+var auditLevelChanges int32
+
+// auditLevelChange logs an entry describing a change of output level of a scope through the
+// default scope, whatever its level, so that operators can reconstruct why verbosity changed.
+// The changes of the scopes of isolated registries are logged through the scopes themselves,
+// to the outputs of their registry, outside of the chain of the process.
+func auditLevelChange(changed *Scope, c LevelChange) {
+	if atomic.LoadInt32(&auditLevelChanges) == 0 {
+		return
+	}
+
+	fields := []zapcore.Field{
+		zap.String("level_scope", c.Scope),
+		zap.String("old_level", levelToString[c.Old]),
+		zap.String("new_level", levelToString[c.New]),
+		zap.String("source", c.Source),
+	}
+	if c.Identity != "" {
+		fields = append(fields, zap.String("identity", c.Identity))
+	}
+
+	var s *Scope
+	if changed.registry.isolated {
+		s = changed.copy()
+	} else {
+		s = defaultScope.copy()
+		s.chain, _ = auditChain.Load().(*hashChain)
+	}
+	s.unleveled = true
+	s.emit(zapcore.InfoLevel, false, "output level changed", fields)
+}
@@ -0,0 +1,93 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAuditLevelChanges(t *testing.T) {
+	s := RegisterScope("TestAuditLevelChanges", "", 0)
+
+	lines, _ := captureStdout(func() {
+		o := DefaultOptions()
+		o.AuditLevelChanges = true
+		o.JSONEncoding = true
+		o.SetOutputLevel(DefaultScopeName, ErrorLevel)
+		if err := Configure(o); err != nil {
+			t.Fatalf("Got error '%v', expected success", err)
+		}
+
+		s.SetOutputLevel(DebugLevel)
+		s.SetOutputLevelFrom(WarnLevel, "http", "alice")
+		s.SetOutputLevelFrom(WarnLevel, "http", "alice")
+		_ = Sync()
+	})
+	_ = Configure(DefaultOptions())
+	s.SetOutputLevel(InfoLevel)
+
+	var audits []string
+	for _, l := range lines {
+		if strings.Contains(l, "TestAuditLevelChanges") {
+			audits = append(audits, l)
+		}
+	}
+
+	expected := []string{
+		`"level_scope":"TestAuditLevelChanges","old_level":"info","new_level":"debug","source":"api"}`,
+		`"level_scope":"TestAuditLevelChanges","old_level":"debug","new_level":"warn","source":"http","identity":"alice"}`,
+	}
+
+	if len(audits) != len(expected) {
+		t.Fatalf("Got %v, expected %v", audits, expected)
+	}
+
+	for i := range expected {
+		if !strings.HasSuffix(audits[i], expected[i]) {
+			t.Errorf("Got %v, expected %v", audits[i], expected[i])
+		}
+	}
+}
+
+func TestAuditLevelChangesOfConfigure(t *testing.T) {
+	s := RegisterScope("TestAuditLevelChangesOfConfigure", "", 0)
+
+	lines, _ := captureStdout(func() {
+		// auditing is turned on by the same call changing the level
+		o := DefaultOptions()
+		o.AuditLevelChanges = true
+		o.JSONEncoding = true
+		o.SetOutputLevel("TestAuditLevelChangesOfConfigure", DebugLevel)
+		if err := Configure(o); err != nil {
+			t.Fatalf("Got error '%v', expected success", err)
+		}
+		_ = Sync()
+	})
+	_ = Configure(DefaultOptions())
+	s.SetOutputLevel(InfoLevel)
+
+	var audits []string
+	for _, l := range lines {
+		if strings.Contains(l, "TestAuditLevelChangesOfConfigure") {
+			audits = append(audits, l)
+		}
+	}
+
+	expected := `"level_scope":"TestAuditLevelChangesOfConfigure","old_level":"info","new_level":"debug","source":"configure"}`
+	if len(audits) != 1 || !strings.HasSuffix(audits[0], expected) {
+		t.Errorf("Got %v, expected %v", audits, expected)
+	}
+}
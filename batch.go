@@ -0,0 +1,202 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	defaultBatchMaxBytes      = 256 * 1024
+	defaultBatchMaxEntries    = 1000
+	defaultBatchFlushInterval = time.Second
+)
+
+// BatchOptions controls when a BatchWriter flushes its buffered entries.
+// A batch is flushed as soon as any of the thresholds is reached.
+type BatchOptions struct {
+	// MaxBytes is the size in bytes a batch can reach before being flushed.
+	// A value of 0 disables the size threshold.
+	MaxBytes int
+
+	// MaxEntries is the number of entries a batch can hold before being flushed.
+	// A value of 0 disables the entry count threshold.
+	MaxEntries int
+
+	// FlushInterval is the maximum amount of time an entry can stay buffered.
+	// A value of 0 disables time-based flushing.
+	FlushInterval time.Duration
+}
+
+// DefaultBatchOptions returns a new set of batch options, initialized to the defaults
+func DefaultBatchOptions() *BatchOptions {
+	return &BatchOptions{
+		MaxBytes:      defaultBatchMaxBytes,
+		MaxEntries:    defaultBatchMaxEntries,
+		FlushInterval: defaultBatchFlushInterval,
+	}
+}
+
+// BatchWriter coalesces log entries and writes them to an underlying writer
+// in batches, which is far more efficient for network and object-store sinks.
+type BatchWriter struct {
+	out     io.Writer
+	options BatchOptions
+
+	mu      sync.Mutex
+	buf     []byte
+	entries int
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewBatchWriter returns a writer that batches entries on their way to the given writer.
+// Close must be called to release the background flusher when a FlushInterval is set.
+func NewBatchWriter(w io.Writer, options *BatchOptions) *BatchWriter {
+	if options == nil {
+		options = DefaultBatchOptions()
+	}
+
+	bw := &BatchWriter{
+		out:     w,
+		options: *options,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	if bw.options.FlushInterval > 0 {
+		go bw.flusher()
+	} else {
+		close(bw.done)
+	}
+
+	return bw
+}
+
+// Write adds an entry to the current batch, flushing the batch if it reached one of
+// its thresholds.
+func (bw *BatchWriter) Write(p []byte) (int, error) {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+
+	bw.buf = append(bw.buf, p...)
+	bw.entries++
+
+	if (bw.options.MaxBytes > 0 && len(bw.buf) >= bw.options.MaxBytes) ||
+		(bw.options.MaxEntries > 0 && bw.entries >= bw.options.MaxEntries) {
+		if err := bw.flush(); err != nil {
+			return len(p), err
+		}
+	}
+
+	return len(p), nil
+}
+
+// Flush writes the current batch to the underlying writer.
+func (bw *BatchWriter) Flush() error {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+
+	return bw.flush()
+}
+
+// Sync flushes the current batch and syncs the underlying writer, if it supports it.
+func (bw *BatchWriter) Sync() error {
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+
+	if s, ok := bw.out.(zapcore.WriteSyncer); ok {
+		return s.Sync()
+	}
+
+	return nil
+}
+
+// Close stops the background flusher, flushes the current batch and closes the
+// underlying writer, if it supports it.
+func (bw *BatchWriter) Close() error {
+	bw.mu.Lock()
+	select {
+	case <-bw.stop:
+	default:
+		close(bw.stop)
+	}
+	bw.mu.Unlock()
+
+	<-bw.done
+
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+
+	if c, ok := bw.out.(io.Closer); ok {
+		return c.Close()
+	}
+
+	return nil
+}
+
+// Run blocks until the context is done, then closes the writer, which flushes the current
+// batch before returning, and returns the error of Close. It returns nil right away if the
+// writer is closed first. It ties the writer to the lifecycle of a service, for example
+// managed by an errgroup:
+//
+//	g.Go(func() error { return w.Run(ctx) })
+func (bw *BatchWriter) Run(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return bw.Close()
+	case <-bw.stop:
+		return nil
+	}
+}
+
+// flush must be called with the lock held.
+func (bw *BatchWriter) flush() error {
+	if len(bw.buf) == 0 {
+		return nil
+	}
+
+	_, err := bw.out.Write(bw.buf)
+
+	// the batch is discarded even on failure, it's up to the underlying writer to retry
+	bw.buf = bw.buf[:0]
+	bw.entries = 0
+
+	return err
+}
+
+func (bw *BatchWriter) flusher() {
+	defer close(bw.done)
+
+	t := time.NewTicker(bw.options.FlushInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+			_ = bw.Flush()
+		case <-bw.stop:
+			return
+		}
+	}
+}
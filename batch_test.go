@@ -0,0 +1,153 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingWriter keeps track of every individual write it receives.
+type recordingWriter struct {
+	sync.Mutex
+	writes []string
+}
+
+func (rw *recordingWriter) Write(p []byte) (int, error) {
+	rw.Lock()
+	defer rw.Unlock()
+
+	rw.writes = append(rw.writes, string(p))
+	return len(p), nil
+}
+
+func (rw *recordingWriter) get() []string {
+	rw.Lock()
+	defer rw.Unlock()
+
+	return append([]string(nil), rw.writes...)
+}
+
+func TestBatchWriterThresholds(t *testing.T) {
+	cases := []struct {
+		options BatchOptions
+		writes  int
+		batches []string
+	}{
+		{BatchOptions{MaxEntries: 2}, 5, []string{"a\na\n", "a\na\n"}},
+		{BatchOptions{MaxBytes: 6}, 5, []string{"a\na\na\n"}},
+		{BatchOptions{}, 5, nil},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			rw := &recordingWriter{}
+			bw := NewBatchWriter(rw, &c.options)
+
+			for j := 0; j < c.writes; j++ {
+				_, _ = bw.Write([]byte("a\n"))
+			}
+
+			got := rw.get()
+			if len(got) != len(c.batches) {
+				t.Fatalf("Got %q, expected %q", got, c.batches)
+			}
+
+			for j := range got {
+				if got[j] != c.batches[j] {
+					t.Errorf("Got %q, expected %q", got[j], c.batches[j])
+				}
+			}
+
+			// whatever is left must come out on close
+			_ = bw.Close()
+			total := 0
+			for _, b := range rw.get() {
+				total += len(b)
+			}
+
+			if total != 2*c.writes {
+				t.Errorf("Got %d bytes after close, expected %d", total, 2*c.writes)
+			}
+		})
+	}
+}
+
+func TestBatchWriterInterval(t *testing.T) {
+	rw := &recordingWriter{}
+	bw := NewBatchWriter(rw, &BatchOptions{FlushInterval: time.Millisecond})
+	defer bw.Close()
+
+	_, _ = bw.Write([]byte("a\n"))
+
+	deadline := time.Now().Add(time.Second)
+	for len(rw.get()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Batch was not flushed by the background flusher")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestBatchWriterExplicitFlush(t *testing.T) {
+	rw := &recordingWriter{}
+	bw := NewBatchWriter(rw, &BatchOptions{})
+
+	_, _ = bw.Write([]byte("a\n"))
+	_, _ = bw.Write([]byte("b\n"))
+
+	if err := bw.Sync(); err != nil {
+		t.Errorf("Got err '%v', expecting success", err)
+	}
+
+	if got := rw.get(); len(got) != 1 || got[0] != "a\nb\n" {
+		t.Errorf("Got %q, expected a single batch", got)
+	}
+
+	// closing twice must be harmless
+	_ = bw.Close()
+	_ = bw.Close()
+}
+
+func TestBatchWriterRun(t *testing.T) {
+	rw := &recordingWriter{}
+	bw := NewBatchWriter(rw, &BatchOptions{FlushInterval: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() { errs <- bw.Run(ctx) }()
+
+	_, _ = bw.Write([]byte("a\n"))
+	cancel()
+
+	if err := <-errs; err != nil {
+		t.Errorf("Got err '%v', expecting success", err)
+	}
+
+	// the final flush happened before Run returned
+	if got := rw.get(); len(got) != 1 || got[0] != "a\n" {
+		t.Errorf("Got %q, expected the pending batch to be flushed", got)
+	}
+
+	// Run returns once the writer is closed
+	bw = NewBatchWriter(rw, nil)
+	_ = bw.Close()
+	if err := bw.Run(context.Background()); err != nil {
+		t.Errorf("Got err '%v', expecting success", err)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package benchmarks compares the output formats of the log package by driving each of
+// them through the same workloads, so that a performance regression of one format, or a
+// new format slower than the others, shows up side by side:
+//
+//	go test -bench . -benchmem ./benchmarks
+//
+// Every format returned by log.Formats is covered, formats added later included. The
+// results are reported per format and workload, as BenchmarkFormats/<format>/<workload>.
+package benchmarks
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/tetratelabs/log"
+)
+
+// Workload is a logging pattern the formats are compared on.
+type Workload struct {
+	// Name identifies the workload in the benchmark results.
+	Name string
+
+	// Log emits one entry through the given scope.
+	Log func(s *log.Scope)
+}
+
+// Workloads are the logging patterns run against every format.
+var Workloads = []Workload{
+	{"message", func(s *log.Scope) {
+		s.Info("request served")
+	}},
+	{"fields", func(s *log.Scope) {
+		s.Info("request served",
+			zap.String("method", "GET"),
+			zap.String("path", "/api/v1/users"),
+			zap.Int("status", 200),
+			zap.Duration("latency", 1500*time.Microsecond),
+			zap.Bool("cached", false))
+	}},
+	{"keyvalues", func(s *log.Scope) {
+		s.Infow("request served", "method", "GET", "path", "/api/v1/users", "status", 200)
+	}},
+	{"printf", func(s *log.Scope) {
+		s.Infof("served %s %s with status %d", "GET", "/api/v1/users", 200)
+	}},
+	{"error", func(s *log.Scope) {
+		s.Error("request failed", zap.Error(errors.New("connection reset by peer")))
+	}},
+	{"context", func(s *log.Scope) {
+		s.WithContext(requestContext).Info("request served", zap.Int("status", 200))
+	}},
+}
+
+// requestContext carries the fields of a typical request, for the context workload.
+var requestContext = log.ContextWithFields(context.Background(),
+	zap.String("request_id", "4bf92f3577b34da6"),
+	zap.String("user", "alice"))
+
+// NewScope returns a scope writing in the given format to the given destination, at the
+// debug level and without callers, so that the workloads only measure the encoding.
+func NewScope(name string, f log.Format, out zapcore.WriteSyncer) *log.Scope {
+	s := log.RegisterScope(name, "benchmarked format "+f.String(), 0)
+	s.SetFormat(f)
+	s.SetOutput(out)
+	s.SetOutputLevel(log.DebugLevel)
+	s.SetStackTraceLevel(log.NoneLevel)
+	s.SetLogCallers(false)
+	return s
+}
+
+// Run benchmarks every workload in every format, writing the entries to ioutil.Discard.
+// The logging must be configured, for instance with log.Configure(log.DefaultOptions()).
+func Run(b *testing.B) {
+	out := zapcore.AddSync(ioutil.Discard)
+
+	for _, f := range log.Formats() {
+		s := NewScope("benchmarks-"+f.String(), f, out)
+
+		b.Run(f.String(), func(b *testing.B) {
+			for _, w := range Workloads {
+				b.Run(w.Name, func(b *testing.B) {
+					b.ReportAllocs()
+					for i := 0; i < b.N; i++ {
+						w.Log(s)
+					}
+				})
+			}
+		})
+	}
+}
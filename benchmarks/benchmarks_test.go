@@ -0,0 +1,55 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package benchmarks
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/tetratelabs/log"
+)
+
+func BenchmarkFormats(b *testing.B) {
+	if err := log.Configure(log.DefaultOptions()); err != nil {
+		b.Fatal(err)
+	}
+
+	Run(b)
+}
+
+// TestWorkloads checks every workload produces an entry in every format, so that the
+// benchmarks measure actual encoding work.
+func TestWorkloads(t *testing.T) {
+	if err := log.Configure(log.DefaultOptions()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, f := range log.Formats() {
+		var buf bytes.Buffer
+		s := NewScope("TestWorkloads-"+strconv.Itoa(i), f, zapcore.AddSync(&buf))
+
+		for _, w := range Workloads {
+			buf.Reset()
+			w.Log(s)
+
+			if buf.Len() == 0 {
+				t.Errorf("Got no output for workload %s in format %v, expected an entry", w.Name, f)
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// BinaryEncoding is an enumeration of the ways binary values can be output.
+type BinaryEncoding int
+
+const (
+	// Base64Binary outputs binary values as base64 strings.
+	Base64Binary BinaryEncoding = iota
+	// HexBinary outputs binary values as hexadecimal strings.
+	HexBinary
+	// LengthBinary only outputs the length of binary values, never their content.
+	LengthBinary
+)
+
+var binaryEncodingToString = map[BinaryEncoding]string{
+	Base64Binary: "base64",
+	HexBinary:    "hex",
+	LengthBinary: "length",
+}
+
+var stringToBinaryEncoding = map[string]BinaryEncoding{
+	"base64": Base64Binary,
+	"hex":    HexBinary,
+	"length": LengthBinary,
+}
+
+// String returns the name of the binary encoding
+func (b BinaryEncoding) String() string {
+	return binaryEncodingToString[b]
+}
+
+// BinaryEncodingFrom returns the binary encoding for the given name
+func BinaryEncodingFrom(name string) (BinaryEncoding, bool) {
+	b, ok := stringToBinaryEncoding[name]
+	return b, ok
+}
+
+// binaryProcessor renders binary fields, as produced by zap.Binary or zap.Any with a
+// []byte, with the given encoding, truncating them to maxBytes if it's not 0.
+func binaryProcessor(encoding BinaryEncoding, maxBytes int) fieldProcessor {
+	match := func(f *zapcore.Field) bool {
+		return f.Type == zapcore.BinaryType
+	}
+
+	return func(fields []zapcore.Field) []zapcore.Field {
+		return rewriteFields(fields, match, func(f zapcore.Field) zapcore.Field {
+			b := f.Interface.([]byte)
+			if encoding == LengthBinary {
+				return zap.String(f.Key, fmt.Sprintf("[%d bytes]", len(b)))
+			}
+
+			truncated := 0
+			if maxBytes > 0 && len(b) > maxBytes {
+				truncated = len(b) - maxBytes
+				b = b[:maxBytes]
+			}
+
+			var s string
+			if encoding == HexBinary {
+				s = hex.EncodeToString(b)
+			} else {
+				s = base64.StdEncoding.EncodeToString(b)
+			}
+
+			if truncated > 0 {
+				s = fmt.Sprintf("%s...[%d more bytes]", s, truncated)
+			}
+
+			return zap.String(f.Key, s)
+		})
+	}
+}
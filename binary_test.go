@@ -0,0 +1,66 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"strconv"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestBinaryProcessor(t *testing.T) {
+	payload := []byte{0xde, 0xad, 0xbe, 0xef, 0x01}
+
+	cases := []struct {
+		encoding BinaryEncoding
+		maxBytes int
+		want     string
+	}{
+		{Base64Binary, 0, "3q2+7wE="},
+		{Base64Binary, 3, "3q2+...[2 more bytes]"},
+		{HexBinary, 0, "deadbeef01"},
+		{HexBinary, 2, "dead...[3 more bytes]"},
+		{LengthBinary, 2, "[5 bytes]"},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			p := binaryProcessor(c.encoding, c.maxBytes)
+			out := p([]zapcore.Field{zap.String("k", "v"), zap.Binary("payload", payload)})
+
+			if out[0] != zap.String("k", "v") {
+				t.Errorf("Got %v, expected the string field to be left alone", out[0])
+			}
+
+			if out[1] != zap.String("payload", c.want) {
+				t.Errorf("Got %v, expected %q", out[1], c.want)
+			}
+		})
+	}
+}
+
+func TestBinaryEncodingFrom(t *testing.T) {
+	for b, name := range binaryEncodingToString {
+		if got, ok := BinaryEncodingFrom(name); !ok || got != b || b.String() != name {
+			t.Errorf("Got (%v, %v), expected (%v, true)", got, ok, b)
+		}
+	}
+
+	if _, ok := BinaryEncodingFrom("decimal"); ok {
+		t.Error("Got true, expected false")
+	}
+}
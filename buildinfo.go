@@ -0,0 +1,58 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"runtime/debug"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Keys of the fields added by WithBuildInfo.
+const (
+	BuildPathKey     = "build_path"
+	BuildVersionKey  = "build_version"
+	BuildRevisionKey = "build_revision"
+	BuildDirtyKey    = "build_dirty"
+)
+
+// BuildInfoFields returns fields identifying the running binary: the path and version of
+// its main module and, when the binary was built from a VCS checkout with Go 1.18 or
+// later, the revision it was built from and whether the checkout had local changes.
+// It returns nil when the binary holds no build information.
+func BuildInfoFields() []zapcore.Field {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+
+	fields := []zapcore.Field{
+		zap.String(BuildPathKey, info.Main.Path),
+		zap.String(BuildVersionKey, info.Main.Version),
+	}
+
+	if revision, dirty, ok := vcsInfo(info); ok {
+		fields = append(fields, zap.String(BuildRevisionKey, revision), zap.Bool(BuildDirtyKey, dirty))
+	}
+
+	return fields
+}
+
+// WithBuildInfo adds the fields returned by BuildInfoFields to every entry logged by the
+// process, so that each line identifies the exact binary which produced it.
+func WithBuildInfo() {
+	AddStaticFields(BuildInfoFields()...)
+}
@@ -0,0 +1,42 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"runtime/debug"
+	"testing"
+)
+
+func TestWithBuildInfo(t *testing.T) {
+	defer ResetStaticFields()
+
+	fields := BuildInfoFields()
+	if _, ok := debug.ReadBuildInfo(); !ok {
+		if fields != nil {
+			t.Errorf("Got %v, expected nil without build information", fields)
+		}
+		return
+	}
+
+	if len(fields) < 2 || fields[0].Key != BuildPathKey || fields[1].Key != BuildVersionKey {
+		t.Errorf("Got %v, expected the path and version of the main module", fields)
+	}
+
+	WithBuildInfo()
+
+	if got := withStaticFields(nil); len(got) != len(fields) {
+		t.Errorf("Got %v, expected %v", got, fields)
+	}
+}
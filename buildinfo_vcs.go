@@ -0,0 +1,37 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+// +build go1.18
+
+package log // nolint: golint
+
+import (
+	"runtime/debug"
+)
+
+// vcsInfo returns the VCS revision the binary was built from, and whether the checkout
+// had local changes.
+func vcsInfo(info *debug.BuildInfo) (revision string, dirty bool, ok bool) {
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			revision, ok = s.Value, true
+		case "vcs.modified":
+			dirty = s.Value == "true"
+		}
+	}
+
+	return revision, dirty, ok
+}
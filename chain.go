@@ -0,0 +1,167 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ChainKey is the key of the field linking each audit entry to the previous one when
+// Options.AuditChain is set, see VerifyChain.
+const ChainKey = "chain"
+
+// hashChain links the entries written through it: each one carries the digest of the
+// link of the previous entry followed by its own encoding, where its link is replaced by
+// chainPlaceholder.
+type hashChain struct {
+	mu   sync.Mutex
+	hash hash.Hash
+
+	// the link of the previous entry
+	prev string
+}
+
+// chainPlaceholder stands for the link of an entry while its digest is computed.
+var chainPlaceholder = strings.Repeat("0", 2*sha256.Size)
+
+// set by the Configure method, the *hashChain of the audit entries if they're chained
+var auditChain atomic.Value
+
+func newHashChain(key []byte) *hashChain {
+	c := &hashChain{hash: sha256.New()}
+	if len(key) > 0 {
+		c.hash = hmac.New(sha256.New, key)
+	}
+
+	return c
+}
+
+// chainLink returns the digest of prev followed by line.
+func chainLink(h hash.Hash, prev string, line []byte) string {
+	h.Reset()
+	_, _ = io.WriteString(h, prev)
+	_, _ = h.Write(line)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// write encodes an entry along with its link, and writes it.
+func (c *hashChain) write(enc zapcore.Encoder, ws zapcore.WriteSyncer, e zapcore.Entry, fields []zapcore.Field) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fields = append(fields[:len(fields):len(fields)], zap.String(ChainKey, chainPlaceholder))
+
+	buf, err := enc.EncodeEntry(e, fields)
+	if err != nil {
+		return err
+	}
+	defer buf.Free()
+
+	b := buf.Bytes()
+	i := bytes.LastIndex(b, []byte(chainPlaceholder))
+	if i < 0 {
+		return fmt.Errorf("no %s field in the encoded entry", ChainKey)
+	}
+
+	link := chainLink(c.hash, c.prev, b)
+	copy(b[i:], link)
+
+	if _, err = ws.Write(b); err != nil {
+		// the entry may have been written in part, the chain will tell
+		return err
+	}
+	c.prev = link
+
+	return nil
+}
+
+// chainPattern finds the link of an entry in the JSON and console formats.
+var chainPattern = regexp.MustCompile(`"` + ChainKey + `": ?"([0-9a-f]{64})"`)
+
+// VerifyChain checks the links of the audit entries output when Options.AuditChain is set,
+// given the key in Options.AuditChainKey, if any. The lines without a link are skipped, so
+// the entries of the other scopes can be interleaved with the audit entries. It returns the
+// number of audit entries verified, and an error locating the first one altered, inserted,
+// or following removed entries. The removal of the last entries can't be detected from the
+// log alone. The files of a rotated log must be verified together, in order, as the chain
+// starts with the first entry logged after Configure.
+//
+// The links cover the entries as encoded, before Options.LinePrefix and Options.LineSuffix
+// are added: those given here are removed from the lines which carry them.
+//
+// Without a key, the chain detects accidental corruption, but not tampering by someone
+// able to recompute it.
+func VerifyChain(r io.Reader, key []byte, linePrefix, lineSuffix string) (int, error) {
+	h := newHashChain(key).hash
+	br := bufio.NewReader(r)
+
+	var prev string
+	entries := 0
+	for lineNumber := 1; ; lineNumber++ {
+		line, err := br.ReadBytes('\n')
+		line = trimAffixes(line, []byte(linePrefix), []byte(lineSuffix))
+		if m := chainPattern.FindAllSubmatchIndex(line, -1); len(m) > 0 {
+			start, end := m[len(m)-1][2], m[len(m)-1][3]
+			link := string(line[start:end])
+			copy(line[start:end], chainPlaceholder)
+
+			if chainLink(h, prev, line) != link {
+				return entries, fmt.Errorf("broken chain at line %d: the entry or the ones before were altered or removed", lineNumber)
+			}
+
+			prev = link
+			entries++
+		}
+
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return entries, err
+		}
+	}
+}
+
+// trimAffixes removes the prefix and the suffix, before the newline, of a line.
+func trimAffixes(line []byte, prefix, suffix []byte) []byte {
+	line = bytes.TrimPrefix(line, prefix)
+	if len(suffix) == 0 {
+		return line
+	}
+
+	if n := len(line); n > 0 && line[n-1] == '\n' {
+		if body := line[:n-1]; bytes.HasSuffix(body, suffix) {
+			return append(body[:len(body)-len(suffix)], '\n')
+		}
+		return line
+	}
+
+	return bytes.TrimSuffix(line, suffix)
+}
@@ -0,0 +1,115 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestAuditChain(t *testing.T) {
+	s := RegisterScope("TestAuditChain", "", 0)
+	key := []byte("secret")
+
+	for i, json := range []bool{false, true} {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "audit.log")
+
+			o := DefaultOptions()
+			o.OutputPaths = []string{path}
+			o.JSONEncoding = json
+			o.AuditLevelChanges = true
+			o.AuditChain = true
+			o.AuditChainKey = key
+			if err := Configure(o); err != nil {
+				t.Fatalf("Got error '%v', expected success", err)
+			}
+
+			s.SetOutputLevel(DebugLevel)
+			s.Info("not audited")
+			s.SetOutputLevel(WarnLevel)
+			s.SetOutputLevelFrom(ErrorLevel, "http", "alice")
+			_ = Sync()
+			_ = Configure(DefaultOptions())
+			s.SetOutputLevel(InfoLevel)
+
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Got error '%v', expected success", err)
+			}
+
+			if n, err := VerifyChain(bytes.NewReader(content), key, "", ""); n != 3 || err != nil {
+				t.Errorf("Got %d, '%v', expected 3 entries verified", n, err)
+			}
+
+			if n, err := VerifyChain(bytes.NewReader(content), []byte("other"), "", ""); n != 0 || err == nil {
+				t.Errorf("Got %d, '%v', expected the other key to fail", n, err)
+			}
+
+			lines := strings.SplitAfter(string(content), "\n")
+			removed := strings.Join(append(lines[:2:2], lines[3:]...), "")
+			if n, err := VerifyChain(strings.NewReader(removed), key, "", ""); n != 1 || err == nil {
+				t.Errorf("Got %d, '%v', expected the removal to be detected", n, err)
+			}
+
+			altered := strings.Replace(string(content), "alice", "bob", 1)
+			if n, err := VerifyChain(strings.NewReader(altered), key, "", ""); n != 2 || err == nil {
+				t.Errorf("Got %d, '%v', expected the alteration to be detected", n, err)
+			}
+		})
+	}
+}
+
+func TestAuditChainLineAffixes(t *testing.T) {
+	s := RegisterScope("TestAuditChainLineAffixes", "", 0)
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	o := DefaultOptions()
+	o.OutputPaths = []string{path}
+	o.AuditLevelChanges = true
+	o.AuditChain = true
+	o.LinePrefix = "K8S "
+	o.LineSuffix = " END"
+	if err := Configure(o); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	s.SetOutputLevel(DebugLevel)
+	s.SetOutputLevel(WarnLevel)
+	_ = Sync()
+	_ = Configure(DefaultOptions())
+	s.SetOutputLevel(InfoLevel)
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	if !strings.HasPrefix(string(content), "K8S ") {
+		t.Fatalf("Got %q, expected the lines to carry the prefix", content)
+	}
+
+	if n, err := VerifyChain(bytes.NewReader(content), nil, "K8S ", " END"); n != 2 || err != nil {
+		t.Errorf("Got %d, '%v', expected 2 entries verified", n, err)
+	}
+
+	if n, err := VerifyChain(bytes.NewReader(content), nil, "", ""); n != 0 || err == nil {
+		t.Errorf("Got %d, '%v', expected the affixes to break the chain when not given", n, err)
+	}
+}
@@ -0,0 +1,342 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cloudwatch provides a log sink that ships entries to AWS CloudWatch Logs.
+//
+// The package does not depend on the AWS SDK. Applications adapt the client they already
+// use to the Client interface and register it:
+//
+//	if err := cloudwatch.Register(myClient); err != nil {
+//		// handle the error
+//	}
+//
+//	options := log.DefaultOptions()
+//	options.JSONEncoding = true
+//	options.OutputPaths = append(options.OutputPaths, "cloudwatch://my-group/my-app-")
+//	_ = log.Configure(options)
+//
+// Entries are batched per log stream, each scope being mapped to its own stream named
+// after the scope, with an optional prefix. JSONEncoding must be enabled for the scope
+// and time of the entries to be found; entries that aren't JSON go to the stream of the
+// default scope, timestamped when written.
+package cloudwatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+)
+
+// Scheme is the URL scheme used to target a log group, as in cloudwatch://<group>/<stream prefix>.
+const Scheme = "cloudwatch"
+
+// The limits of PutLogEvents.
+const (
+	// MaxBatchEvents is the maximum number of events in a batch.
+	MaxBatchEvents = 10000
+	// MaxBatchBytes is the maximum size of a batch, counting EventOverhead per event.
+	MaxBatchBytes = 1048576
+	// EventOverhead is the number of bytes added to the size of each message in a batch.
+	EventOverhead = 26
+	// MaxEventBytes is the maximum size of a message, longer messages are truncated.
+	MaxEventBytes = 262144 - EventOverhead
+	// MaxBatchSpan is the maximum time between the first and last event of a batch.
+	MaxBatchSpan = 24 * time.Hour
+)
+
+const (
+	defaultFlushInterval = 5 * time.Second
+	defaultStreamName    = "default"
+)
+
+// Event is a log event, as sent to PutLogEvents.
+type Event struct {
+	// Message is the encoded log entry.
+	Message string
+	// Timestamp is the time of the entry, in milliseconds since the epoch.
+	Timestamp int64
+}
+
+// Client is the subset of the CloudWatch Logs API used by the sink.
+type Client interface {
+	// CreateLogStream creates a log stream in the given group. It must return nil if the
+	// stream already exists.
+	CreateLogStream(group, stream string) error
+
+	// PutLogEvents uploads a batch of events, in chronological order, to a log stream.
+	// It returns the sequence token to use for the next batch. When the given token is
+	// rejected, it must return a *SequenceTokenError holding the expected token.
+	PutLogEvents(group, stream string, events []Event, sequenceToken *string) (next *string, err error)
+}
+
+// SequenceTokenError reports that the sequence token given to PutLogEvents was rejected.
+type SequenceTokenError struct {
+	// Expected is the token to use instead.
+	Expected *string
+}
+
+func (e *SequenceTokenError) Error() string {
+	return "invalid sequence token"
+}
+
+// Options controls the behavior of a Sink.
+type Options struct {
+	// Group is the log group the streams belong to.
+	Group string
+
+	// StreamPrefix is prepended to the scope names to form the names of the log streams.
+	StreamPrefix string
+
+	// FlushInterval is the maximum amount of time an entry can stay buffered. A value of
+	// 0 disables time-based flushing, entries being sent when a batch is full or on Sync.
+	FlushInterval time.Duration
+}
+
+// Sink writes log entries to CloudWatch Logs.
+type Sink struct {
+	client  Client
+	options Options
+
+	mu      sync.Mutex
+	streams map[string]*stream
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// stream holds the state of a log stream.
+type stream struct {
+	created bool
+	token   *string
+	pending []Event
+	size    int
+}
+
+// NewSink returns a sink that uploads entries to the log group of the given options.
+// Close must be called to deliver the pending entries and release the background flusher.
+func NewSink(c Client, options Options) *Sink {
+	s := &Sink{
+		client:  c,
+		options: options,
+		streams: make(map[string]*stream),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	if options.FlushInterval > 0 {
+		go s.flusher()
+	} else {
+		close(s.done)
+	}
+
+	return s
+}
+
+// Register makes cloudwatch://<group>/<stream prefix> URLs usable as log output paths,
+// uploading through the given client. It can only be called once per process.
+func Register(c Client) error {
+	return zap.RegisterSink(Scheme, func(u *url.URL) (zap.Sink, error) {
+		if u.Host == "" {
+			return nil, errors.New("missing log group in cloudwatch URL, expecting cloudwatch://<group>/<stream prefix>")
+		}
+
+		return NewSink(c, Options{
+			Group:         u.Host,
+			StreamPrefix:  strings.TrimPrefix(u.Path, "/"),
+			FlushInterval: defaultFlushInterval,
+		}), nil
+	})
+}
+
+// Write buffers an encoded log entry, uploading the batch of its stream when full.
+func (s *Sink) Write(p []byte) (int, error) {
+	name, e := s.event(p)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, ok := s.streams[name]
+	if !ok {
+		st = &stream{}
+		s.streams[name] = st
+	}
+
+	size := len(e.Message) + EventOverhead
+	if len(st.pending) > 0 && (len(st.pending)+1 > MaxBatchEvents || st.size+size > MaxBatchBytes) {
+		if err := s.flush(name, st); err != nil {
+			return 0, err
+		}
+	}
+
+	st.pending = append(st.pending, e)
+	st.size += size
+
+	return len(p), nil
+}
+
+// Sync uploads the buffered entries.
+func (s *Sink) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.flushAll()
+}
+
+// Close stops the background flusher and uploads the buffered entries. The client itself
+// is owned by the caller and is left open.
+func (s *Sink) Close() error {
+	s.mu.Lock()
+	select {
+	case <-s.stop:
+	default:
+		close(s.stop)
+	}
+	s.mu.Unlock()
+
+	<-s.done
+
+	return s.Sync()
+}
+
+// event returns the stream of an entry and the event it's uploaded as.
+func (s *Sink) event(p []byte) (string, Event) {
+	msg := string(bytes.TrimRight(p, "\n"))
+	if len(msg) > MaxEventBytes {
+		// don't split a character, CloudWatch requires valid UTF-8
+		n := MaxEventBytes
+		for n > 0 && !utf8.RuneStart(msg[n]) {
+			n--
+		}
+		msg = msg[:n]
+	}
+
+	var entry struct {
+		Scope string `json:"scope"`
+		Time  string `json:"time"`
+	}
+	_ = json.Unmarshal(p, &entry)
+
+	t, err := time.Parse(time.RFC3339Nano, entry.Time)
+	if err != nil {
+		t = time.Now()
+	}
+
+	scope := entry.Scope
+	if scope == "" {
+		scope = defaultStreamName
+	}
+
+	return s.options.StreamPrefix + scope, Event{Message: msg, Timestamp: t.UnixNano() / int64(time.Millisecond)}
+}
+
+// flushAll must be called with the lock held.
+func (s *Sink) flushAll() error {
+	names := make([]string, 0, len(s.streams))
+	for name := range s.streams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []string
+	for _, name := range names {
+		if err := s.flush(name, s.streams[name]); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// flush uploads the pending events of a stream, in as many batches as their span requires.
+// The events are discarded even on failure. Must be called with the lock held.
+func (s *Sink) flush(name string, st *stream) error {
+	events := st.pending
+	st.pending = nil
+	st.size = 0
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	if !st.created {
+		if err := s.client.CreateLogStream(s.options.Group, name); err != nil {
+			return fmt.Errorf("unable to create log stream %s/%s: %v", s.options.Group, name, err)
+		}
+		st.created = true
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Timestamp < events[j].Timestamp })
+
+	span := MaxBatchSpan.Milliseconds()
+	for len(events) > 0 {
+		n := 1
+		for n < len(events) && events[n].Timestamp-events[0].Timestamp < span {
+			n++
+		}
+
+		if err := s.put(name, st, events[:n]); err != nil {
+			return fmt.Errorf("unable to upload to log stream %s/%s: %v", s.options.Group, name, err)
+		}
+		events = events[n:]
+	}
+
+	return nil
+}
+
+// put uploads a batch, retrying once with the expected sequence token if it was rejected.
+func (s *Sink) put(name string, st *stream, events []Event) error {
+	next, err := s.client.PutLogEvents(s.options.Group, name, events, st.token)
+
+	var tokenErr *SequenceTokenError
+	if errors.As(err, &tokenErr) {
+		st.token = tokenErr.Expected
+		next, err = s.client.PutLogEvents(s.options.Group, name, events, st.token)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	st.token = next
+	return nil
+}
+
+func (s *Sink) flusher() {
+	defer close(s.done)
+
+	t := time.NewTicker(s.options.FlushInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+			_ = s.Sync()
+		case <-s.stop:
+			return
+		}
+	}
+}
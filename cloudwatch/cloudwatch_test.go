@@ -0,0 +1,156 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudwatch
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type put struct {
+	stream string
+	events []Event
+	token  string
+}
+
+type testClient struct {
+	created []string
+	puts    []put
+	token   int
+	reject  bool
+}
+
+func (c *testClient) CreateLogStream(group, stream string) error {
+	c.created = append(c.created, group+"/"+stream)
+	return nil
+}
+
+func (c *testClient) PutLogEvents(group, stream string, events []Event, token *string) (*string, error) {
+	given := ""
+	if token != nil {
+		given = *token
+	}
+
+	expected := ""
+	if c.token > 0 {
+		expected = string(rune('0' + c.token))
+	}
+
+	if c.reject && given != expected {
+		return nil, &SequenceTokenError{Expected: &expected}
+	}
+
+	c.puts = append(c.puts, put{stream: stream, events: append([]Event(nil), events...), token: given})
+	c.token++
+	next := string(rune('0' + c.token))
+	return &next, nil
+}
+
+func TestSink(t *testing.T) {
+	c := &testClient{}
+	s := NewSink(c, Options{Group: "group", StreamPrefix: "app-"})
+
+	_, _ = s.Write([]byte(`{"time":"2026-01-01T00:00:02.000000Z","scope":"http","msg":"b"}` + "\n"))
+	_, _ = s.Write([]byte(`{"time":"2026-01-01T00:00:01.000000Z","scope":"http","msg":"a"}` + "\n"))
+	_, _ = s.Write([]byte(`{"time":"2026-01-01T00:00:03.000000Z","msg":"c"}` + "\n"))
+	_, _ = s.Write([]byte("not json\n"))
+
+	if len(c.puts) != 0 {
+		t.Errorf("Got %v, expected the entries to be buffered", c.puts)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	if strings.Join(c.created, ",") != "group/app-default,group/app-http" {
+		t.Errorf("Got %v, expected the default and http streams", c.created)
+	}
+
+	// the entry that isn't JSON is timestamped now, more than a day after the others
+	if len(c.puts) != 3 {
+		t.Fatalf("Got %v, expected two batches for the default stream and one for http", c.puts)
+	}
+
+	if c.puts[1].stream != "app-default" || c.puts[1].token != "1" {
+		t.Errorf("Got %v, expected the sequence token of the previous batch", c.puts[1])
+	}
+
+	http := c.puts[2]
+	if http.stream != "app-http" || len(http.events) != 2 || !strings.Contains(http.events[0].Message, `"a"`) {
+		t.Errorf("Got %v, expected the http entries in chronological order", http)
+	}
+
+	expected := time.Date(2026, 1, 1, 0, 0, 1, 0, time.UTC).UnixNano() / int64(time.Millisecond)
+	if http.events[0].Timestamp != expected {
+		t.Errorf("Got %v, expected %v", http.events[0].Timestamp, expected)
+	}
+}
+
+func TestSinkSequenceToken(t *testing.T) {
+	c := &testClient{reject: true, token: 3}
+	s := NewSink(c, Options{Group: "group"})
+
+	_, _ = s.Write([]byte("{}\n"))
+	if err := s.Sync(); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	if len(c.puts) != 1 || c.puts[0].token != "3" {
+		t.Errorf("Got %v, expected a retry with the expected token", c.puts)
+	}
+}
+
+func TestSinkLimits(t *testing.T) {
+	c := &testClient{}
+	s := NewSink(c, Options{Group: "group"})
+
+	big := strings.Repeat("x", MaxEventBytes+10)
+	for i := 0; i < 5; i++ {
+		_, _ = s.Write([]byte(big))
+	}
+	_ = s.Sync()
+
+	total := 0
+	for _, p := range c.puts {
+		size := 0
+		for _, e := range p.events {
+			if len(e.Message) > MaxEventBytes {
+				t.Errorf("Got a %d bytes message, expected at most %d", len(e.Message), MaxEventBytes)
+			}
+			size += len(e.Message) + EventOverhead
+		}
+		if size > MaxBatchBytes {
+			t.Errorf("Got a %d bytes batch, expected at most %d", size, MaxBatchBytes)
+		}
+		total += len(p.events)
+	}
+
+	if total != 5 || len(c.puts) != 2 {
+		t.Errorf("Got %d events in %d batches, expected 5 in 2", total, len(c.puts))
+	}
+}
+
+func TestRegister(t *testing.T) {
+	if err := Register(&testClient{}); err != nil {
+		t.Errorf("Got error '%v', expected success", err)
+	}
+
+	if err := Register(&testClient{}); err == nil {
+		t.Errorf("Got success, expected an error on second registration")
+	}
+
+}
@@ -63,9 +63,6 @@ import (
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
-	"go.uber.org/zap/zapgrpc"
-	"google.golang.org/grpc/grpclog"
-	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 // none is used to disable logging output as well as to disable stack tracing.
@@ -85,11 +82,11 @@ func init() {
 }
 
 // prepZap is a utility function used by the Configure function.
-func prepZap(options *Options) (zapcore.Core, zapcore.Core, zapcore.WriteSyncer, error) {
+func prepZap(options *Options) (zapcore.Core, zapcore.Core, *outputs, zapcore.WriteSyncer, error) {
 	encCfg := zapcore.EncoderConfig{
 		TimeKey:        "time",
 		LevelKey:       "level",
-		NameKey:        "scope",
+		NameKey:        defaultScopeKey,
 		CallerKey:      "caller",
 		MessageKey:     "msg",
 		StacktraceKey:  "stack",
@@ -100,35 +97,116 @@ func prepZap(options *Options) (zapcore.Core, zapcore.Core, zapcore.WriteSyncer,
 		EncodeTime:     formatDate,
 	}
 
-	var enc zapcore.Encoder
-	if options.JSONEncoding {
-		enc = zapcore.NewJSONEncoder(encCfg)
-	} else {
-		enc = zapcore.NewConsoleEncoder(encCfg)
+	if options.ScopeKey != "" {
+		encCfg.NameKey = options.ScopeKey
+	}
+
+	// scopes can override the output format, so have an encoder ready for each of them
+	out := &outputs{
+		format: ConsoleFormat,
+		affix:  newAffix(options),
+		encoders: map[Format]zapcore.Encoder{
+			ConsoleFormat:  newEncoder(ConsoleFormat, encCfg),
+			JSONFormat:     newEncoder(JSONFormat, encCfg),
+			MessageFormat:  newEncoder(MessageFormat, encCfg),
+			PrettyFormat:   newEncoder(PrettyFormat, encCfg),
+			TemplateFormat: newEncoder(TemplateFormat, encCfg),
+			CSVFormat:      newEncoder(CSVFormat, encCfg),
+			TSVFormat:      newEncoder(TSVFormat, encCfg),
+			MsgpackFormat:  newEncoder(MsgpackFormat, encCfg),
+		},
+	}
+
+	if options.TextTemplate != "" {
+		enc, err := newTemplateEncoder(options.TextTemplate)
+		if err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("invalid log template '%s': %v", options.TextTemplate, err)
+		}
+		out.encoders[TemplateFormat] = enc
 	}
 
-	var rotaterSink zapcore.WriteSyncer
-	if options.RotateOutputPath != "" {
-		rotaterSink = zapcore.AddSync(&lumberjack.Logger{
-			Filename:   options.RotateOutputPath,
-			MaxSize:    options.RotationMaxSize,
-			MaxBackups: options.RotationMaxAge,
-			MaxAge:     options.RotationMaxBackups,
-		})
+	if options.ConsoleFields != FieldsAfterMessage {
+		out.encoders[ConsoleFormat] = newConsoleEncoder(encCfg, options.ConsoleFields)
+	}
+
+	if len(options.CSVColumns) > 0 {
+		for _, f := range []Format{CSVFormat, TSVFormat} {
+			enc, err := newDelimitedEncoder(options.CSVColumns, delimiters[f])
+			if err != nil {
+				return nil, nil, nil, nil, fmt.Errorf("invalid log columns: %v", err)
+			}
+			out.encoders[f] = enc
+		}
+	}
+
+	if len(options.Resource) > 0 {
+		// encoded once, as a field of the encoder itself
+		if err := out.encoders[JSONFormat].AddObject(ResourceKey, resourceAttributes(options.Resource)); err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
+
+	if options.Pretty || os.Getenv(prettyEnvVar) == "1" {
+		out.format = PrettyFormat
+	} else if options.TextTemplate != "" {
+		out.format = TemplateFormat
+	} else if options.CSVEncoding {
+		out.format = CSVFormat
+	} else if options.TSVEncoding {
+		out.format = TSVFormat
+	} else if options.MsgpackEncoding {
+		out.format = MsgpackFormat
+	} else if options.JSONEncoding {
+		out.format = JSONFormat
+	}
+	enc := out.encoders[out.format]
+
+	if options.EncryptArchives {
+		// fail early rather than leaving every archive unencrypted
+		key, err := archiveKey()
+		if err == nil {
+			_, err = newArchiveAEAD(key)
+		}
+		if err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("unable to encrypt the archived log files: %v", err)
+		}
+	}
+
+	rotaterSink, err := newRotaterSink(options)
+	if err != nil {
+		return nil, nil, nil, nil, err
 	}
 
 	errSink, closeErrorSink, err := zap.Open(options.ErrorOutputPaths...)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
+	}
+
+	timed := &TimedFileOptions{
+		MaxAge:   options.TimedOutputMaxAge,
+		MaxFiles: options.TimedOutputMaxFiles,
+		Encrypt:  options.EncryptArchives,
 	}
 
 	var outputSink zapcore.WriteSyncer
 	if len(options.OutputPaths) > 0 {
-		outputSink, _, err = zap.Open(options.OutputPaths...)
+		outputSink, out.files, err = openOutputs(options.OutputPaths, options.LockFiles, timed)
+		if err != nil {
+			closeErrorSink()
+			return nil, nil, nil, nil, err
+		}
+	}
+
+	if len(options.JSONOutputPaths) > 0 {
+		jsonSink, files, err := openOutputs(options.JSONOutputPaths, options.LockFiles, timed)
 		if err != nil {
+			closeFiles(out.files)
 			closeErrorSink()
-			return nil, nil, nil, err
+			return nil, nil, nil, nil, err
 		}
+
+		out.files = append(out.files, files...)
+		out.jsonSink = newAffixSink(jsonSink, options)
 	}
 
 	var sink zapcore.WriteSyncer
@@ -141,6 +219,10 @@ func prepZap(options *Options) (zapcore.Core, zapcore.Core, zapcore.WriteSyncer,
 	}
 
 	var enabler zap.LevelEnablerFunc = func(lvl zapcore.Level) bool {
+		if atomic.LoadInt32(&disabled) != 0 {
+			return false
+		}
+
 		switch lvl {
 		case zapcore.ErrorLevel:
 			return defaultScope.ErrorEnabled()
@@ -152,12 +234,51 @@ func prepZap(options *Options) (zapcore.Core, zapcore.Core, zapcore.WriteSyncer,
 		return defaultScope.DebugEnabled()
 	}
 
-	return zapcore.NewCore(enc, sink, zap.NewAtomicLevelAt(zapcore.DebugLevel)),
-		zapcore.NewCore(enc, sink, enabler),
-		errSink, nil
+	sink = newAffixSink(sink, options)
+	if sink != nil {
+		sink = countingSink{sink}
+	}
+	if out.jsonSink != nil {
+		out.jsonSink = countingSink{out.jsonSink}
+		if sink != nil {
+			out.jsonTee = zapcore.NewMultiWriteSyncer(sink, out.jsonSink)
+		}
+	}
+	out.sink = sink
+
+	coreSink := sink
+	teed := out.jsonTee != nil && out.format == JSONFormat
+	if teed {
+		// both outputs take the same bytes, so the entries are encoded once
+		coreSink = out.jsonTee
+	}
+
+	core := zapcore.NewCore(enc, coreSink, zap.NewAtomicLevelAt(zapcore.DebugLevel))
+	captureCore := zapcore.NewCore(enc, coreSink, enabler)
+
+	if out.jsonSink != nil && !teed {
+		// the fields are processed once, then encoded by each core
+		jsonEnc := out.encoders[JSONFormat]
+		jsonCore := zapcore.NewCore(jsonEnc, out.jsonSink, zap.NewAtomicLevelAt(zapcore.DebugLevel))
+		jsonCaptureCore := zapcore.NewCore(jsonEnc, out.jsonSink, enabler)
+
+		if sink == nil {
+			core, captureCore = jsonCore, jsonCaptureCore
+		} else {
+			core = zapcore.NewTee(core, jsonCore)
+			captureCore = zapcore.NewTee(captureCore, jsonCaptureCore)
+		}
+	}
+
+	return core, captureCore, out, errSink, nil
 }
 
 func formatDate(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+	enc.AppendString(dateString(t))
+}
+
+// dateString renders the time of an entry as formatDate does.
+func dateString(t time.Time) string {
 	t = t.UTC()
 	year, month, day := t.Date()
 	hour, minute, second := t.Clock()
@@ -193,25 +314,35 @@ func formatDate(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
 	buf[25] = byte((micros)%10) + '0'
 	buf[26] = 'Z'
 
-	enc.AppendString(string(buf))
+	return string(buf)
 }
 
-func updateScopes(options *Options, core zapcore.Core, errSink zapcore.WriteSyncer) error {
+func updateScopes(options *Options, core zapcore.Core, out *outputs, errSink zapcore.WriteSyncer) error {
 	// init the global I/O funcs
 	writeFn.Store(core.Write)
+	currentOutputs.Store(out)
 	syncFn.Store(core.Sync)
 	errorSink.Store(errSink)
 
 	// snapshot what's there
 	allScopes := Scopes()
 
+	// the levels of the child scopes not created yet are applied by WithName
+	children := &childLevels{output: map[string]Level{}, stackTrace: map[string]Level{}}
+
 	// update the output levels of all scopes
-	if err := processLevels(allScopes, options.outputLevels, func(s *Scope, l Level) { s.SetOutputLevel(l) }); err != nil {
+	if err := processLevels(allScopes, options.outputLevels, children.output, func(s *Scope, l Level) { s.SetOutputLevelFrom(l, LevelSourceConfigure, "") }); err != nil {
 		return err
 	}
 
 	// update the stack tracing levels of all scopes
-	if err := processLevels(allScopes, options.stackTraceLevels, func(s *Scope, l Level) { s.SetStackTraceLevel(l) }); err != nil {
+	if err := processLevels(allScopes, options.stackTraceLevels, children.stackTrace, func(s *Scope, l Level) { s.SetStackTraceLevel(l) }); err != nil {
+		return err
+	}
+	pendingChildLevels.Store(children)
+
+	// update the sample rates of all scopes
+	if err := processSampleRates(allScopes, options.SampleRates); err != nil {
 		return err
 	}
 
@@ -242,8 +373,10 @@ func updateScopes(options *Options, core zapcore.Core, errSink zapcore.WriteSync
 }
 
 // processLevels breaks down an argument string into a set of scope & levels and then
-// tries to apply the result to the scopes. It supports the use of a global override.
-func processLevels(allScopes map[string]*Scope, arg string, setter func(*Scope, Level)) error {
+// tries to apply the result to the scopes. It supports the use of a global override,
+// and of scope groups. The levels of the children of registered scopes which don't exist
+// yet are kept in pending, for WithName to apply when they're created.
+func processLevels(allScopes map[string]*Scope, arg string, pending map[string]Level, setter func(*Scope, Level)) error {
 	levels := strings.Split(arg, ",")
 	for _, sl := range levels {
 		s, l, err := convertScopedLevel(sl)
@@ -259,6 +392,14 @@ func processLevels(allScopes map[string]*Scope, arg string, setter func(*Scope,
 				setter(scope, l)
 			}
 			return nil
+		} else if members := GroupScopes(s); members != nil {
+			for _, n := range members {
+				if scope, ok := allScopes[n]; ok {
+					setter(scope, l)
+				}
+			}
+		} else if i := strings.IndexByte(s, '.'); i > 0 && allScopes[s[:i]] != nil {
+			pending[s] = l
 		} else {
 			_, _ = fmt.Fprintf(os.Stderr, "unknown scope '%s' specified\n", s)
 		}
@@ -267,20 +408,110 @@ func processLevels(allScopes map[string]*Scope, arg string, setter func(*Scope,
 	return nil
 }
 
+// childLevels holds the levels configured for child scopes before they were created.
+type childLevels struct {
+	output     map[string]Level
+	stackTrace map[string]Level
+}
+
+// reset by the Configure method
+var pendingChildLevels atomic.Value
+
 // Configure initializes Istio's logging subsystem.
 //
 // You typically call this once at process startup.
-// Once this call returns, the logging system is ready to accept data.
+// Once this call returns, the logging system is ready to accept data. The files opened by
+// the previous call, if any, are closed.
 func Configure(options *Options) error {
-	core, captureCore, errSink, err := prepZap(options)
+	core, captureCore, out, errSink, err := prepZap(options)
 	if err != nil {
 		return err
 	}
 
-	if err = updateScopes(options, core, errSink); err != nil {
+	// the level changes made by updateScopes are audited as configured by these options
+	if options.AuditLevelChanges {
+		atomic.StoreInt32(&auditLevelChanges, 1)
+	} else {
+		atomic.StoreInt32(&auditLevelChanges, 0)
+	}
+
+	if options.AuditChain {
+		auditChain.Store(newHashChain(options.AuditChainKey))
+	} else {
+		auditChain.Store((*hashChain)(nil))
+	}
+
+	prev, _ := currentOutputs.Load().(*outputs)
+	err = updateScopes(options, core, out, errSink)
+	if prev != nil {
+		// the new outputs are in place, the files of the previous ones are no longer written
+		closeFiles(prev.files)
+	}
+	if err != nil {
 		return err
 	}
 
+	fieldProcessors.Store(buildFieldProcessors(options))
+	crashDump.Store(newCrashRecorder(options.CrashDumpDir, options.RecentEntries))
+	if options.CrashDumpSuppressed && options.CrashDumpDir != "" {
+		atomic.StoreInt32(&crashDumpSuppressed, 1)
+	} else {
+		atomic.StoreInt32(&crashDumpSuppressed, 0)
+	}
+	startDroppedReporter(options.DroppedSummaryInterval)
+	startErrorReporter(options.ErrorSummaryInterval)
+	setStormRate(options.StormRate)
+
+	if options.MessageTemplates {
+		atomic.StoreInt32(&messageTemplates, 1)
+	} else {
+		atomic.StoreInt32(&messageTemplates, 0)
+	}
+
+	if options.StrictFormat {
+		atomic.StoreInt32(&strictFormat, 1)
+	} else {
+		atomic.StoreInt32(&strictFormat, 0)
+	}
+
+	if options.ContextErrorFields {
+		atomic.StoreInt32(&contextErrorFields, 1)
+	} else {
+		atomic.StoreInt32(&contextErrorFields, 0)
+	}
+	atomic.StoreInt64(&contextDeadlineThreshold, int64(options.ContextDeadlineThreshold))
+
+	if options.GoroutineID {
+		atomic.StoreInt32(&logGoroutineID, 1)
+	} else {
+		atomic.StoreInt32(&logGoroutineID, 0)
+	}
+
+	if options.ScopeDescriptions {
+		atomic.StoreInt32(&scopeDescriptions, 1)
+	} else {
+		atomic.StoreInt32(&scopeDescriptions, 0)
+	}
+
+	if options.TraceSampledOnly {
+		atomic.StoreInt32(&traceSampledOnly, 1)
+	} else {
+		atomic.StoreInt32(&traceSampledOnly, 0)
+	}
+
+	if options.Strict {
+		atomic.StoreInt32(&strictMode, 1)
+	} else {
+		atomic.StoreInt32(&strictMode, 0)
+	}
+
+	if options.AutoRegisterScopes {
+		atomic.StoreInt32(&autoRegisterScopes, 1)
+	} else {
+		atomic.StoreInt32(&autoRegisterScopes, 0)
+	}
+	startSighupHandler(options.ReopenOnSIGHUP)
+
 	opts := []zap.Option{
 		zap.ErrorOutput(errSink),
 		zap.AddCallerSkip(1),
@@ -295,7 +526,7 @@ func Configure(options *Options) error {
 		opts = append(opts, zap.AddStacktrace(levelToZap[l]))
 	}
 
-	captureLogger := zap.New(captureCore, opts...)
+	captureLogger := zap.New(fieldsCore{captureCore}, opts...)
 
 	// capture global zap logging and force it through our logger
 	_ = zap.ReplaceGlobals(captureLogger)
@@ -305,8 +536,7 @@ func Configure(options *Options) error {
 
 	// capture gRPC logging
 	if options.LogGrpc {
-		// TODO(https://github.com/uber-go/zap/issues/534): remove the nolint directive
-		grpclog.SetLogger(zapgrpc.NewLogger(captureLogger.WithOptions(zap.AddCallerSkip(2)))) //nolint: megacheck
+		captureGrpc(captureLogger)
 	}
 
 	return nil
@@ -323,6 +553,19 @@ func Sync() error {
 		err = s()
 	}
 
+	// scopes with their own output need to be flushed as well
+	for _, s := range Scopes() {
+		if ws := s.GetOutput(); ws != nil {
+			if e := ws.Sync(); e != nil && err == nil {
+				err = e
+			}
+		}
+	}
+
+	if e := syncRouted(); e != nil && err == nil {
+		err = e
+	}
+
 	return err
 }
 
@@ -17,6 +17,7 @@
 package log
 
 import (
+	"bytes"
 	"io/ioutil"
 	"log"
 	"os"
@@ -194,6 +195,132 @@ func TestRotateNoStdout(t *testing.T) {
 	}
 }
 
+func TestRotateEncrypted(t *testing.T) {
+	dir := t.TempDir()
+	file := dir + "/rot.log"
+	backup := dir + "/rot-2020-01-01T00-00-00.000.log"
+	_ = ioutil.WriteFile(backup, []byte("rotated entry\n"), 0644)
+
+	key := []byte("0123456789abcdef")
+	SetArchiveKeyFunc(func() ([]byte, error) { return key, nil })
+	defer SetArchiveKeyFunc(nil)
+
+	o := DefaultOptions()
+	o.OutputPaths = []string{}
+	o.RotateOutputPath = file
+	o.EncryptArchives = true
+	if err := Configure(o); err != nil {
+		t.Fatalf("Unable to configure logging: %v", err)
+	}
+	archiveJobs.Wait()
+
+	content, _ := ioutil.ReadFile(backup)
+	r, err := NewArchiveReader(bytes.NewReader(content), key)
+	if err != nil {
+		t.Fatalf("Got failure '%v', expecting the backup to be encrypted", err)
+	}
+	if plain, _ := ioutil.ReadAll(r); string(plain) != "rotated entry\n" {
+		t.Errorf("Got %q, expecting the rotated entry", plain)
+	}
+
+	SetArchiveKeyFunc(func() ([]byte, error) { return []byte("short"), nil })
+	if err := Configure(o); err == nil {
+		t.Errorf("Got success, expecting an invalid key to be rejected")
+	}
+}
+
+func TestJSONOutputPaths(t *testing.T) {
+	dir := t.TempDir()
+	text := dir + "/app.log"
+	json := dir + "/app.json"
+
+	s := RegisterScope("TestJSONOutputPaths", "", 0)
+	s.SetFormat(ConsoleFormat)
+	defer s.SetFormat(DefaultFormat)
+
+	o := DefaultOptions()
+	o.OutputPaths = []string{text}
+	o.JSONOutputPaths = []string{json}
+	if err := Configure(o); err != nil {
+		t.Fatalf("Unable to configure logging: %v", err)
+	}
+	defer func() { _ = Configure(DefaultOptions()) }()
+
+	defaultScope.Info("shared", zap.Int("n", 1))
+	s.Info("overridden")
+	_ = Sync()
+
+	content, _ := ioutil.ReadFile(text)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], "\tinfo\tshared\t") || strings.HasPrefix(lines[1], "{") {
+		t.Errorf("Got %q, expecting two console entries", lines)
+	}
+
+	content, _ = ioutil.ReadFile(json)
+	lines = strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"msg":"shared","n":1}`) || !strings.Contains(lines[1], `"scope":"TestJSONOutputPaths","msg":"overridden"`) {
+		t.Errorf("Got %q, expecting two JSON entries", lines)
+	}
+}
+
+func TestJSONOutputPathsEncodedOnce(t *testing.T) {
+	s := RegisterScope("TestJSONOutputPathsEncodedOnce", "", 0)
+	s.SetFormat(JSONFormat)
+	defer s.SetFormat(DefaultFormat)
+
+	console := RegisterScope("TestJSONOutputPathsConsole", "", 0)
+	console.SetFormat(ConsoleFormat)
+	defer console.SetFormat(DefaultFormat)
+
+	for i, jsonEncoding := range []bool{true, false} {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			dir := t.TempDir()
+			text := dir + "/app.log"
+			json := dir + "/app.json"
+
+			o := DefaultOptions()
+			o.OutputPaths = []string{text}
+			o.JSONOutputPaths = []string{json}
+			o.JSONEncoding = jsonEncoding
+			if err := Configure(o); err != nil {
+				t.Fatalf("Unable to configure logging: %v", err)
+			}
+			defer func() { _ = Configure(DefaultOptions()) }()
+
+			defaultScope.Info("shared", zap.Int("n", 1))
+			s.Info("overridden")
+			console.Info("console")
+			_ = Sync()
+
+			out := currentOutputs.Load().(*outputs)
+			if out.jsonSink == nil || out.jsonTee == nil {
+				t.Errorf("Got no separate JSON output, expected one")
+			}
+
+			textContent, _ := ioutil.ReadFile(text)
+			jsonContent, _ := ioutil.ReadFile(json)
+			textLines := strings.Split(strings.TrimSpace(string(textContent)), "\n")
+			jsonLines := strings.Split(strings.TrimSpace(string(jsonContent)), "\n")
+
+			if len(textLines) != 3 || len(jsonLines) != 3 {
+				t.Fatalf("Got %q and %q, expected three entries in each output", textLines, jsonLines)
+			}
+
+			if jsonEncoding != (textLines[0] == jsonLines[0]) {
+				t.Errorf("Got %q and %q, expected the same entry only when both outputs are JSON", textLines[0], jsonLines[0])
+			}
+
+			if !strings.Contains(textLines[1], `"msg":"overridden"`) || textLines[1] != jsonLines[1] {
+				t.Errorf("Got %q and %q, expected the same JSON entry", textLines[1], jsonLines[1])
+			}
+
+			if strings.HasPrefix(textLines[2], "{") || !strings.Contains(jsonLines[2], `"msg":"console"`) {
+				t.Errorf("Got %q and %q, expected a console entry and a JSON one", textLines[2], jsonLines[2])
+			}
+		})
+	}
+}
+
 func TestRotateAndStdout(t *testing.T) {
 	dir, _ := ioutil.TempDir("", "TestRotateAndStdout")
 	defer func() {
@@ -386,6 +513,26 @@ func captureStdout(f func()) ([]string, error) {
 }
 
 func resetGlobals() {
-	scopes = make(map[string]*Scope, 1)
+	defaultRegistry = newRegistry()
 	defaultScope = registerDefaultScope()
 }
+
+func TestConfigureClosesPreviousFiles(t *testing.T) {
+	o := DefaultOptions()
+	o.OutputPaths = []string{t.TempDir() + "/out.log"}
+	if err := Configure(o); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	files := currentOutputs.Load().(*outputs).files
+	if err := Configure(DefaultOptions()); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	if len(files) != 1 {
+		t.Fatalf("Got %v, expected a single file", files)
+	}
+	if _, err := files[0].Write([]byte("late\n")); err == nil {
+		t.Error("Got success, expected the file of the previous outputs to be closed")
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// FieldsPlacement is an enumeration of the places where ConsoleFormat outputs the fields
+// of the entries, see Options.ConsoleFields.
+type FieldsPlacement int
+
+const (
+	// FieldsAfterMessage outputs the fields as a JSON object after the message.
+	FieldsAfterMessage FieldsPlacement = iota
+	// FieldsBeforeMessage outputs the fields as a JSON object before the message, so that
+	// they line up whatever the length of the messages.
+	FieldsBeforeMessage
+	// FieldsOmitted leaves the fields out, only the message and the leading columns of
+	// each entry being output.
+	FieldsOmitted
+)
+
+var fieldsPlacementToString = map[FieldsPlacement]string{
+	FieldsAfterMessage:  "after",
+	FieldsBeforeMessage: "before",
+	FieldsOmitted:       "none",
+}
+
+var stringToFieldsPlacement = map[string]FieldsPlacement{
+	"after":  FieldsAfterMessage,
+	"before": FieldsBeforeMessage,
+	"none":   FieldsOmitted,
+}
+
+// String returns the name of the fields placement
+func (p FieldsPlacement) String() string {
+	return fieldsPlacementToString[p]
+}
+
+// FieldsPlacementFrom returns the fields placement for the given name
+func FieldsPlacementFrom(name string) (FieldsPlacement, bool) {
+	p, ok := stringToFieldsPlacement[name]
+	return p, ok
+}
+
+// newConsoleEncoder returns the encoder of ConsoleFormat, placing the fields as requested.
+func newConsoleEncoder(encCfg zapcore.EncoderConfig, placement FieldsPlacement) zapcore.Encoder {
+	switch placement {
+	case FieldsBeforeMessage:
+		// the header holds everything up to the fields, the message and stack are added after
+		encCfg.MessageKey = ""
+		encCfg.StacktraceKey = ""
+		return &fieldsFirstEncoder{Encoder: zapcore.NewConsoleEncoder(encCfg)}
+
+	case FieldsOmitted:
+		return &fieldlessEncoder{
+			MapObjectEncoder: zapcore.NewMapObjectEncoder(),
+			line:             zapcore.NewConsoleEncoder(encCfg),
+		}
+	}
+
+	return zapcore.NewConsoleEncoder(encCfg)
+}
+
+// fieldsFirstEncoder outputs the entries like the console encoder, with the fields before
+// the message rather than after it.
+type fieldsFirstEncoder struct {
+	zapcore.Encoder
+}
+
+func (enc *fieldsFirstEncoder) Clone() zapcore.Encoder {
+	return &fieldsFirstEncoder{Encoder: enc.Encoder.Clone()}
+}
+
+func (enc *fieldsFirstEncoder) EncodeEntry(e zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	buf, err := enc.Encoder.EncodeEntry(e, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.TrimNewline()
+	if buf.Len() > 0 {
+		buf.AppendByte('\t')
+	}
+	buf.AppendString(e.Message)
+
+	if e.Stack != "" {
+		buf.AppendByte('\n')
+		buf.AppendString(e.Stack)
+	}
+	buf.AppendString(zapcore.DefaultLineEnding)
+
+	return buf, nil
+}
+
+// fieldlessEncoder outputs the entries like the console encoder, without their fields,
+// including those added to the encoder itself, which are dropped.
+type fieldlessEncoder struct {
+	*zapcore.MapObjectEncoder
+
+	line zapcore.Encoder
+}
+
+func (enc *fieldlessEncoder) Clone() zapcore.Encoder {
+	return &fieldlessEncoder{
+		MapObjectEncoder: zapcore.NewMapObjectEncoder(),
+		line:             enc.line,
+	}
+}
+
+func (enc *fieldlessEncoder) EncodeEntry(e zapcore.Entry, _ []zapcore.Field) (*buffer.Buffer, error) {
+	return enc.line.EncodeEntry(e, nil)
+}
@@ -0,0 +1,113 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestConsoleFields(t *testing.T) {
+	s := RegisterScope("TestConsoleFields", "", 0)
+
+	cases := []struct {
+		placement FieldsPlacement
+		expected  []string
+	}{
+		{FieldsAfterMessage, []string{
+			timePattern + "\tinfo\tTestConsoleFields\tHello\t{\"name\": \"world\"}$",
+			timePattern + "\tinfo\tTestConsoleFields\tBare$",
+		}},
+		{FieldsBeforeMessage, []string{
+			timePattern + "\tinfo\tTestConsoleFields\t{\"name\": \"world\"}\tHello$",
+			timePattern + "\tinfo\tTestConsoleFields\tBare$",
+		}},
+		{FieldsOmitted, []string{
+			timePattern + "\tinfo\tTestConsoleFields\tHello$",
+			timePattern + "\tinfo\tTestConsoleFields\tBare$",
+		}},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			lines, err := captureStdout(func() {
+				o := DefaultOptions()
+				o.ConsoleFields = c.placement
+				_ = Configure(o)
+
+				s.Info("Hello", zap.String("name", "world"))
+				s.Info("Bare")
+				_ = Sync()
+			})
+			if err != nil {
+				t.Fatalf("Got error '%v', expected success", err)
+			}
+			_ = Configure(DefaultOptions())
+
+			if len(lines) != len(c.expected)+1 {
+				t.Fatalf("Got %d lines, expected %d: %q", len(lines), len(c.expected)+1, lines)
+			}
+
+			for j, pat := range c.expected {
+				if match, _ := regexp.MatchString(pat, lines[j]); !match {
+					t.Errorf("Got %q, expected a match with %q", lines[j], pat)
+				}
+			}
+		})
+	}
+}
+
+func TestConsoleFieldsStack(t *testing.T) {
+	s := RegisterScope("TestConsoleFieldsStack", "", 0)
+
+	lines, err := captureStdout(func() {
+		o := DefaultOptions()
+		o.ConsoleFields = FieldsBeforeMessage
+		o.SetStackTraceLevel("TestConsoleFieldsStack", ErrorLevel)
+		_ = Configure(o)
+
+		s.Error("Hello", zap.Int("count", 2))
+		_ = Sync()
+	})
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	_ = Configure(DefaultOptions())
+
+	pat := timePattern + "\terror\tTestConsoleFieldsStack\t{\"count\": 2}\tHello$"
+	if match, _ := regexp.MatchString(pat, lines[0]); !match {
+		t.Errorf("Got %q, expected a match with %q", lines[0], pat)
+	}
+
+	if len(lines) < 3 || !strings.HasPrefix(lines[1], "github.com/tetratelabs/log.") {
+		t.Errorf("Got %q, expected the stack on the following lines", lines)
+	}
+}
+
+func TestFieldsPlacementFrom(t *testing.T) {
+	for p := range fieldsPlacementToString {
+		if got, ok := FieldsPlacementFrom(p.String()); !ok || got != p {
+			t.Errorf("Got %v, expected %v", got, p)
+		}
+	}
+
+	if _, ok := FieldsPlacementFrom("middle"); ok {
+		t.Error("Got true, expected false")
+	}
+}
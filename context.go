@@ -0,0 +1,114 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// The keys of the fields describing the state of the context of error entries.
+const (
+	// ContextErrKey is the key of the error of a canceled or expired context.
+	ContextErrKey = "ctx_err"
+	// ContextDeadlineRemainingKey is the key of the time left before the deadline of a context.
+	ContextDeadlineRemainingKey = "ctx_deadline_remaining"
+)
+
+// set by the Configure method, 1 when error entries describe the state of their context
+var contextErrorFields int32
+
+// set by the Configure method, the time left before the deadline of a context below which
+// error entries report it, in nanoseconds
+var contextDeadlineThreshold int64
+
+type contextFieldsKey struct{}
+
+// ContextWithFields returns a copy of the context carrying the given fields, in addition
+// to any fields it already carried. Scopes obtained through WithContext add these fields
+// to every entry they emit.
+func ContextWithFields(ctx context.Context, fields ...zapcore.Field) context.Context {
+	existing := FieldsFromContext(ctx)
+
+	all := make([]zapcore.Field, 0, len(existing)+len(fields))
+	all = append(all, existing...)
+	all = append(all, fields...)
+
+	return context.WithValue(ctx, contextFieldsKey{}, all)
+}
+
+// FieldsFromContext returns the fields carried by the context, if any.
+func FieldsFromContext(ctx context.Context) []zapcore.Field {
+	if ctx == nil {
+		return nil
+	}
+
+	fields, _ := ctx.Value(contextFieldsKey{}).([]zapcore.Field)
+	return fields
+}
+
+// WithContext returns a scope that adds the fields carried by the context to every
+// entry. The returned scope shares its name, levels and settings with the original.
+//
+// When Options.ContextErrorFields is set, the error entries of the returned scope describe
+// the state of the context when they're logged, see ContextErrKey.
+//
+// The fields pulled from the context by the functions given to RegisterContextExtractor
+// are added to every entry as well.
+//
+// The context is handed to the RouteFunc set by SetRouter, if any.
+//
+// When Options.TraceSampledOnly is set and the trace of the context is known not to be
+// sampled, the returned scope doesn't emit debug and info entries.
+func (s *Scope) WithContext(ctx context.Context) *Scope {
+	fields := FieldsFromContext(ctx)
+	unsampled := traceUnsampled(ctx)
+
+	var bound context.Context
+	errorFields := ctx != nil && ctx.Done() != nil && atomic.LoadInt32(&contextErrorFields) != 0
+	if errorFields || (ctx != nil && (hasContextExtractors() || routing())) {
+		bound = ctx
+	}
+
+	if len(fields) == 0 && unsampled == s.traceUnsampled && bound == nil && s.ctx == nil {
+		return s
+	}
+
+	out := s.copy()
+	out.fields = append(s.fields[:len(s.fields):len(s.fields)], fields...)
+	out.traceUnsampled = unsampled
+	out.ctx = bound
+	return out
+}
+
+// contextFields returns the fields describing the state of a context: its error once it's
+// canceled or expired, or the time left before its deadline if it's below the threshold.
+func contextFields(ctx context.Context, now time.Time) []zapcore.Field {
+	if err := ctx.Err(); err != nil {
+		return []zapcore.Field{zap.NamedError(ContextErrKey, err)}
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := deadline.Sub(now); remaining < time.Duration(atomic.LoadInt64(&contextDeadlineThreshold)) {
+			return []zapcore.Field{zap.Duration(ContextDeadlineRemainingKey, remaining)}
+		}
+	}
+
+	return nil
+}
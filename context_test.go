@@ -0,0 +1,159 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestContextFields(t *testing.T) {
+	ctx := context.Background()
+	if f := FieldsFromContext(ctx); f != nil {
+		t.Errorf("Got %v, expected no fields", f)
+	}
+
+	parent := ContextWithFields(ctx, zap.String("a", "1"))
+	child := ContextWithFields(parent, zap.String("b", "2"))
+
+	if f := FieldsFromContext(parent); len(f) != 1 || f[0].Key != "a" {
+		t.Errorf("Got %v, expected a single a field", f)
+	}
+
+	if f := FieldsFromContext(child); len(f) != 2 || f[0].Key != "a" || f[1].Key != "b" {
+		t.Errorf("Got %v, expected the a and b fields", f)
+	}
+}
+
+func TestScopeWithContext(t *testing.T) {
+	var fields [][]zapcore.Field
+	s := NewWithEmit("TestScopeWithContext", "", 0, func(e zapcore.Entry, f []zapcore.Field) error {
+		fields = append(fields, f)
+		return nil
+	})
+
+	if s.WithContext(context.Background()) != s {
+		t.Error("Expecting the scope itself for a context without fields")
+	}
+
+	ctx := ContextWithFields(context.Background(), zap.String("request_id", "42"))
+	s.WithContext(ctx).Info("with", zap.Int("n", 1))
+	s.Info("without")
+
+	if len(fields) != 2 {
+		t.Fatalf("Got %d entries, expected 2", len(fields))
+	}
+
+	if len(fields[0]) != 2 || fields[0][0].Key != "request_id" || fields[0][1].Key != "n" {
+		t.Errorf("Got %v, expected the request_id and n fields", fields[0])
+	}
+
+	if len(fields[1]) != 0 {
+		t.Errorf("Got %v, expected no fields", fields[1])
+	}
+}
+
+// lookupCountingContext counts the values looked up in the context.
+type lookupCountingContext struct {
+	context.Context
+	lookups int
+}
+
+func (c *lookupCountingContext) Value(key interface{}) interface{} {
+	c.lookups++
+	return c.Context.Value(key)
+}
+
+func TestScopeWithContextReadsOnce(t *testing.T) {
+	var tenants []interface{}
+	s := NewWithEmit("TestScopeWithContextReadsOnce", "", 0, func(e zapcore.Entry, f []zapcore.Field) error {
+		enc := zapcore.NewMapObjectEncoder()
+		for _, field := range f {
+			field.AddTo(enc)
+		}
+		tenants = append(tenants, enc.Fields["tenant"])
+		return nil
+	})
+
+	ctx := &lookupCountingContext{Context: ContextWithFields(context.Background(), zap.String("request_id", "42"))}
+	bound := s.WithContext(ctx)
+	read := ctx.lookups
+
+	for i := 0; i < 3; i++ {
+		bound.Info("Hello")
+	}
+	if ctx.lookups != read {
+		t.Errorf("Got %d lookups, expected the %d of WithContext", ctx.lookups, read)
+	}
+
+	// values changing after WithContext are read for every entry by the extractors
+	tenant := "acme"
+	remove := RegisterContextExtractor(func(context.Context) []interface{} {
+		return []interface{}{"tenant", tenant}
+	})
+	defer remove()
+
+	bound = s.WithContext(ctx)
+	bound.Info("acme")
+	tenant = "globex"
+	bound.Info("globex")
+	if len(tenants) != 5 || tenants[3] != "acme" || tenants[4] != "globex" {
+		t.Errorf("Got %v, expected the tenant at the time of each entry", tenants)
+	}
+}
+
+func TestContextErrorFields(t *testing.T) {
+	var keys [][]string
+	s := NewWithEmit("TestContextErrorFields", "", 0, func(_ zapcore.Entry, fields []zapcore.Field) error {
+		var k []string
+		for _, f := range fields {
+			k = append(k, f.Key)
+		}
+		keys = append(keys, k)
+		return nil
+	})
+
+	o := DefaultOptions()
+	o.ContextErrorFields = true
+	o.ContextDeadlineThreshold = time.Hour
+	if err := Configure(o); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = Configure(DefaultOptions()) }()
+
+	deadline, cancelDeadline := context.WithTimeout(context.Background(), time.Minute)
+	defer cancelDeadline()
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	s.WithContext(context.Background()).Error("background")
+	s.WithContext(deadline).Info("info")
+	s.WithContext(deadline).Error("near deadline")
+	s.WithContext(canceled).Error("canceled")
+
+	expected := [][]string{nil, nil, {ContextDeadlineRemainingKey}, {ContextErrKey}}
+	if len(keys) != len(expected) {
+		t.Fatalf("Got %v, expected %v", keys, expected)
+	}
+	for i := range expected {
+		if len(keys[i]) != len(expected[i]) || (len(keys[i]) > 0 && keys[i][0] != expected[i][0]) {
+			t.Errorf("Got %v, expected %v", keys[i], expected[i])
+		}
+	}
+}
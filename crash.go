@@ -0,0 +1,203 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// crashDumpEntries is the number of recent entries included in crash reports.
+const crashDumpEntries = 100
+
+// reset by the Configure method, holds a *crashRecorder which is nil when crash reports are disabled
+var crashDump atomic.Value
+
+// set by the Configure method, 1 when the entries below the output level of their scope are
+// recorded for crash reports
+var crashDumpSuppressed int32
+
+// overridden by tests
+var exitFn = os.Exit
+
+// crashRecorder keeps the most recent entries in a ring buffer, for inclusion in crash
+// reports and for Query.
+type crashRecorder struct {
+	dir string
+
+	mu      sync.Mutex
+	entries []crashEntry
+	next    int
+}
+
+type crashEntry struct {
+	entry  zapcore.Entry
+	fields []zapcore.Field
+
+	// below the output level of its scope, kept for crash reports only
+	suppressed bool
+}
+
+// newCrashRecorder returns a recorder writing crash reports to the given directory, if
+// any, and keeping the given number of recent entries, at least crashDumpEntries when
+// crash reports are enabled. It returns nil when there's nothing to keep.
+func newCrashRecorder(dir string, size int) *crashRecorder {
+	if dir != "" && size < crashDumpEntries {
+		size = crashDumpEntries
+	}
+
+	if size <= 0 {
+		return nil
+	}
+
+	return &crashRecorder{
+		dir:     dir,
+		entries: make([]crashEntry, 0, size),
+	}
+}
+
+func (c *crashRecorder) record(ce crashEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) < cap(c.entries) {
+		c.entries = append(c.entries, ce)
+		return
+	}
+
+	c.entries[c.next] = ce
+	c.next = (c.next + 1) % len(c.entries)
+}
+
+// snapshot returns the recorded entries, oldest first.
+func (c *crashRecorder) snapshot() []crashEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make([]crashEntry, 0, len(c.entries))
+	out = append(out, c.entries[c.next:]...)
+	return append(out, c.entries[:c.next]...)
+}
+
+// recordForCrash keeps an emitted entry around in case a crash report is written.
+func recordForCrash(e zapcore.Entry, fields []zapcore.Field) {
+	if c, _ := crashDump.Load().(*crashRecorder); c != nil {
+		c.record(crashEntry{entry: e, fields: fields})
+	}
+}
+
+// recordSuppressed keeps an entry below the output level of its scope in case a crash
+// report is written, without writing it anywhere else.
+func (s *Scope) recordSuppressed(level zapcore.Level, msg string, fields []zapcore.Field) {
+	c, _ := crashDump.Load().(*crashRecorder)
+	if c == nil {
+		return
+	}
+
+	e := zapcore.Entry{
+		Message:    msg,
+		Level:      level,
+		Time:       now(),
+		LoggerName: s.nameToEmit,
+	}
+
+	if len(s.fields) > 0 {
+		fields = append(s.fields[:len(s.fields):len(s.fields)], fields...)
+	}
+	c.record(crashEntry{entry: e, fields: processFields(withStaticFields(s.withScopeFields(fields))), suppressed: true})
+}
+
+// writeCrashDump writes a crash report to a new timestamped file in the configured
+// directory and returns its path. It does nothing if crash reports are disabled.
+func writeCrashDump(reason string) (string, error) {
+	c, _ := crashDump.Load().(*crashRecorder)
+	if c == nil || c.dir == "" {
+		return "", nil
+	}
+
+	now := time.Now().UTC()
+
+	var b bytes.Buffer
+	_, _ = fmt.Fprintf(&b, "crash report for %s (pid %d) at %s\n", os.Args[0], os.Getpid(), now.Format(time.RFC3339Nano))
+	_, _ = fmt.Fprintf(&b, "reason: %s\n", reason)
+
+	_, _ = fmt.Fprintf(&b, "\n== build ==\n")
+	_, _ = fmt.Fprintf(&b, "go: %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	if info, ok := debug.ReadBuildInfo(); ok {
+		_, _ = fmt.Fprintf(&b, "main: %s %s\n", info.Main.Path, info.Main.Version)
+		for _, dep := range info.Deps {
+			_, _ = fmt.Fprintf(&b, "dep: %s %s\n", dep.Path, dep.Version)
+		}
+	}
+
+	entries := c.snapshot()
+	_, _ = fmt.Fprintf(&b, "\n== last %d log entries ==\n", len(entries))
+	if out, _ := currentOutputs.Load().(*outputs); out != nil {
+		enc := out.encoders[ConsoleFormat]
+		for _, ce := range entries {
+			if buf, err := enc.EncodeEntry(ce.entry, ce.fields); err == nil {
+				_, _ = b.Write(buf.Bytes())
+				buf.Free()
+			}
+		}
+	}
+
+	_, _ = fmt.Fprintf(&b, "\n== goroutines ==\n")
+	_, _ = b.Write(allStacks())
+
+	path := filepath.Join(c.dir, fmt.Sprintf("crash-%s-%d.log", now.Format("20060102T150405.000000Z"), os.Getpid()))
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return "", err
+	}
+
+	return path, ioutil.WriteFile(path, b.Bytes(), 0644)
+}
+
+// crash writes a crash report, flushes the logs and terminates the process.
+func crash(s *Scope, reason string) {
+	if path, err := writeCrashDump(reason); err != nil {
+		if es, _ := errorSink.Load().(zapcore.WriteSyncer); es != nil {
+			_, _ = fmt.Fprintf(es, "%v unable to write crash report: %v\n", time.Now(), err)
+		}
+	} else if path != "" && s.enabled(ErrorLevel) {
+		s.emit(zapcore.ErrorLevel, false, "crash report written", []zapcore.Field{zap.String("path", path)})
+	}
+
+	_ = Sync()
+	exitFn(1)
+}
+
+// RecoverAndCrash logs a panic like Recover, then writes a crash report if they are
+// enabled and terminates the process. It must be deferred directly, typically at the
+// top of main and of long-lived goroutines:
+//
+//	defer log.RecoverAndCrash(scope)
+func RecoverAndCrash(s *Scope) {
+	if r := recover(); r != nil {
+		logPanic(s, r)
+		crash(s, fmt.Sprintf("panic: %v", r))
+	}
+}
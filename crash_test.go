@@ -0,0 +1,204 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestFatal(t *testing.T) {
+	s := RegisterScope("TestFatal", "", 0)
+
+	dir, err := ioutil.TempDir("", "log_crash")
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer os.RemoveAll(dir)
+
+	exitCode := -1
+	exitFn = func(code int) { exitCode = code }
+	defer func() { exitFn = os.Exit }()
+
+	lines, err := captureStdout(func() {
+		o := DefaultOptions()
+		o.CrashDumpDir = dir
+		_ = Configure(o)
+
+		s.Info("before the crash")
+		s.Fatalf("out of %s", "luck")
+	})
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	_ = Configure(DefaultOptions())
+
+	if exitCode != 1 {
+		t.Errorf("Got exit code %d, expected 1", exitCode)
+	}
+
+	if match, _ := regexp.MatchString("\tfatal\tTestFatal\tout of luck", lines[1]); !match {
+		t.Errorf("Got '%v', expected a fatal entry", lines[1])
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "crash-*.log"))
+	if len(files) != 1 {
+		t.Fatalf("Got %v, expected a single crash report", files)
+	}
+
+	if match, _ := regexp.MatchString(`crash report written\t{"path": "`+regexp.QuoteMeta(files[0])+`"}`, lines[2]); !match {
+		t.Errorf("Got '%v', expected the path of the crash report to be logged", lines[2])
+	}
+
+	content, _ := ioutil.ReadFile(files[0])
+	for _, pat := range []string{
+		`(?m)^reason: out of luck$`,
+		`(?m)^go: go`,
+		`(?s)== last 2 log entries ==\n.*before the crash\n.*out of luck\n`,
+		`(?s)== goroutines ==\ngoroutine \d+ \[running\]`,
+	} {
+		if match, _ := regexp.Match(pat, content); !match {
+			t.Errorf("Got '%s', expected a match with '%v'", content, pat)
+		}
+	}
+}
+
+func TestCrashDumpSuppressed(t *testing.T) {
+	s := RegisterScope("TestCrashDumpSuppressed", "", 0)
+	dir := t.TempDir()
+
+	exitFn = func(int) {}
+	defer func() { exitFn = os.Exit }()
+
+	lines, err := captureStdout(func() {
+		o := DefaultOptions()
+		o.CrashDumpDir = dir
+		o.CrashDumpSuppressed = true
+		_ = Configure(o)
+
+		s.SetOutputLevel(NoneLevel)
+		s.Debug("quiet debug")
+		s.Errorf("quiet %s", "error")
+		s.SetOutputLevel(InfoLevel)
+
+		s.Info("loud info")
+		s.Debug("quiet again")
+		s.Fatal("the end")
+	})
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	_ = Configure(DefaultOptions())
+
+	for _, l := range lines {
+		if strings.Contains(l, "quiet") {
+			t.Errorf("Got '%v', expected suppressed entries not to be written", l)
+		}
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "crash-*.log"))
+	if len(files) != 1 {
+		t.Fatalf("Got %v, expected a single crash report", files)
+	}
+
+	content, _ := ioutil.ReadFile(files[0])
+	pat := `(?s)== last 5 log entries ==\n.*quiet debug\n.*quiet error\n.*loud info\n.*quiet again\n.*the end\n`
+	if match, _ := regexp.Match(pat, content); !match {
+		t.Errorf("Got '%s', expected a match with '%v'", content, pat)
+	}
+}
+
+func TestCrashDumpSuppressedMisuse(t *testing.T) {
+	s := RegisterScope("TestCrashDumpSuppressedMisuse", "", 0)
+	dir := t.TempDir()
+
+	var got []Misuse
+	SetMisuseHandler(func(m Misuse) { got = append(got, m) })
+	defer SetMisuseHandler(nil)
+
+	_, err := captureStdout(func() {
+		o := DefaultOptions()
+		o.CrashDumpDir = dir
+		o.CrashDumpSuppressed = true
+		o.Strict = true
+		o.StrictFormat = true
+		_ = Configure(o)
+
+		s.SetOutputLevel(InfoLevel)
+		s.Debugw("odd", "key")
+		s.Debugf("mismatch %s %d", "one")
+		s.Debugf("kv", KV, "a", 1, "a")
+		s.Infow("loud", "key")
+	})
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	_ = Configure(DefaultOptions())
+
+	if len(got) != 1 || got[0].Message != "loud" {
+		t.Errorf("Got %v, expected only the output entry to be reported", got)
+	}
+}
+
+func TestFatalWithoutCrashDump(t *testing.T) {
+	exitCode := -1
+	exitFn = func(code int) { exitCode = code }
+	defer func() { exitFn = os.Exit }()
+
+	lines, _ := captureStdout(func() {
+		_ = Configure(DefaultOptions())
+		Fatal("bye")
+	})
+
+	if exitCode != 1 {
+		t.Errorf("Got exit code %d, expected 1", exitCode)
+	}
+
+	if match, _ := regexp.MatchString("\tfatal\t.*bye$", lines[0]); !match || lines[1] != "" {
+		t.Errorf("Got %v, expected a single fatal entry", lines)
+	}
+}
+
+func TestCrashRecorder(t *testing.T) {
+	c := newCrashRecorder("dir", 10)
+	for i := 0; i < crashDumpEntries+5; i++ {
+		c.record(crashEntry{entry: zapcore.Entry{Message: strconv.Itoa(i)}})
+	}
+
+	entries := c.snapshot()
+	if len(entries) != crashDumpEntries {
+		t.Fatalf("Got %d entries, expected %d", len(entries), crashDumpEntries)
+	}
+
+	if entries[0].entry.Message != "5" || entries[crashDumpEntries-1].entry.Message != strconv.Itoa(crashDumpEntries+4) {
+		t.Errorf("Got entries from %v to %v, expected the most recent ones in order",
+			entries[0].entry.Message, entries[crashDumpEntries-1].entry.Message)
+	}
+
+	if newCrashRecorder("", 0) != nil {
+		t.Error("Expecting no recorder without a directory")
+	}
+
+	if c := newCrashRecorder("", 10); c == nil || cap(c.entries) != 10 {
+		t.Error("Expecting a recorder of 10 entries for queries")
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !tinygo
+// +build !tinygo
+
+package log // nolint: golint
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DebugPath is the conventional path of DebugHandler, next to the /debug/vars path of expvar.
+	DebugPath = "/debug/logging"
+	// QueryPath is the conventional path of QueryHandler.
+	QueryPath = "/debug/logs"
+	// ExpvarName is the name of the variable published by PublishExpvar.
+	ExpvarName = "logging"
+)
+
+var publishExpvar sync.Once
+
+// ScopeState describes a registered scope and its current settings.
+type ScopeState struct {
+	Name            string     `json:"name"`
+	Description     string     `json:"description"`
+	OutputLevel     Level      `json:"output_level"`
+	StackTraceLevel Level      `json:"stack_trace_level"`
+	LogCallers      bool       `json:"log_callers"`
+	Stats           ScopeStats `json:"stats"`
+}
+
+// ScopeStates returns the current settings of all the registered scopes, sorted by name.
+func ScopeStates() []ScopeState {
+	all := Scopes()
+
+	states := make([]ScopeState, 0, len(all))
+	for _, s := range all {
+		states = append(states, ScopeState{
+			Name:            s.Name(),
+			Description:     s.Description(),
+			OutputLevel:     s.GetOutputLevel(),
+			StackTraceLevel: s.GetStackTraceLevel(),
+			LogCallers:      s.GetLogCallers(),
+			Stats:           s.Stats(),
+		})
+	}
+
+	sort.Slice(states, func(i, j int) bool { return states[i].Name < states[j].Name })
+	return states
+}
+
+// PublishExpvar publishes the scope states under ExpvarName, so they are served along
+// with the other variables at /debug/vars. States are computed each time the variable
+// is read. Calling it more than once has no effect.
+func PublishExpvar() {
+	publishExpvar.Do(func() {
+		expvar.Publish(ExpvarName, expvar.Func(func() interface{} { return ScopeStates() }))
+	})
+}
+
+// DebugHandler returns a handler serving the scope states as JSON. It is typically
+// registered at DebugPath:
+//
+//	http.Handle(log.DebugPath, log.DebugHandler())
+func DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(ScopeStates())
+	})
+}
+
+// QueryHandler returns a handler serving the recent entries matching a query as JSON,
+// see Query. The query is given by the scope, level, since and q parameters, such as
+// /debug/logs?scope=cache&level=error&since=5m&q=timeout, where since is either a duration
+// before now or an RFC 3339 time. The level defaults to debug. It is typically registered
+// at QueryPath:
+//
+//	http.Handle(log.QueryPath, log.QueryHandler())
+func QueryHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		params := req.URL.Query()
+
+		level := DebugLevel
+		if v := params.Get("level"); v != "" {
+			l, err := ParseLevel(v)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			level = l
+		}
+
+		since, err := parseSince(params.Get("since"), now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		entries := Query(params.Get("scope"), level, since, params.Get("q"))
+		if entries == nil {
+			entries = []RecentEntry{}
+		}
+		for i := range entries {
+			entries[i].Fields = encodableFields(entries[i].Fields)
+		}
+
+		// encoded before anything is written, so that failures are reported as such
+		b, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			http.Error(w, fmt.Sprintf("unable to encode the entries: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, _ = w.Write(append(b, '\n'))
+	})
+}
+
+// encodableFields returns the fields with the values JSON can't represent replaced: the
+// non-finite floats and the complex numbers by strings, as the JSON encoder of zap does, and
+// the other values which can't be encoded by the error encoding them.
+func encodableFields(fields map[string]interface{}) map[string]interface{} {
+	if fields == nil {
+		return nil
+	}
+
+	encodable := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		v = encodableValue(v)
+		if _, err := json.Marshal(v); err != nil {
+			v = fmt.Sprintf("unable to encode: %v", err)
+		}
+		encodable[k] = v
+	}
+
+	return encodable
+}
+
+// encodableValue returns a value, as stored by zapcore.MapObjectEncoder, with the non-finite
+// floats and the complex numbers it holds replaced by strings.
+func encodableValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	case float32:
+		if f := float64(v); math.IsNaN(f) || math.IsInf(f, 0) {
+			return strconv.FormatFloat(f, 'f', -1, 32)
+		}
+	case complex128:
+		return strconv.FormatComplex(v, 'f', -1, 128)
+	case complex64:
+		return strconv.FormatComplex(complex128(v), 'f', -1, 64)
+	case map[string]interface{}:
+		// copied, as the values may be shared with the callers
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = encodableValue(e)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = encodableValue(e)
+		}
+		return a
+	}
+
+	return v
+}
+
+// parseSince parses a duration before now or an RFC 3339 time. The empty string gives the
+// zero time.
+func parseSince(v string, now time.Time) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+
+	if d, err := time.ParseDuration(v); err == nil {
+		return now.Add(-d), nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid since '%s', must be a duration or an RFC 3339 time", v)
+	}
+	return t, nil
+}
@@ -0,0 +1,191 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !tinygo
+// +build !tinygo
+
+package log
+
+import (
+	"encoding/json"
+	"expvar"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func findState(states []ScopeState, name string) (ScopeState, bool) {
+	for _, st := range states {
+		if st.Name == name {
+			return st, true
+		}
+	}
+
+	return ScopeState{}, false
+}
+
+func TestScopeStates(t *testing.T) {
+	s := RegisterScope("TestScopeStates", "states", 0)
+	s.SetOutputLevel(DebugLevel)
+	s.SetStackTraceLevel(ErrorLevel)
+	defer s.SetOutputLevel(InfoLevel)
+	defer s.SetStackTraceLevel(NoneLevel)
+
+	states := ScopeStates()
+	for i := 1; i < len(states); i++ {
+		if states[i-1].Name >= states[i].Name {
+			t.Errorf("Got %s before %s, expected states sorted by name", states[i-1].Name, states[i].Name)
+		}
+	}
+
+	expected := ScopeState{Name: "TestScopeStates", Description: "states", OutputLevel: DebugLevel, StackTraceLevel: ErrorLevel}
+	if got, _ := findState(states, "TestScopeStates"); got != expected {
+		t.Errorf("Got %+v, expected %+v", got, expected)
+	}
+}
+
+func TestDebugHandler(t *testing.T) {
+	_ = RegisterScope("TestDebugHandler", "handler", 0)
+
+	rec := httptest.NewRecorder()
+	DebugHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, DebugPath, nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Got status %d, expected %d", rec.Code, http.StatusOK)
+	}
+
+	var states []ScopeState
+	if err := json.Unmarshal(rec.Body.Bytes(), &states); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	expected := ScopeState{Name: "TestDebugHandler", Description: "handler", OutputLevel: InfoLevel, StackTraceLevel: NoneLevel}
+	if got, _ := findState(states, "TestDebugHandler"); got != expected {
+		t.Errorf("Got %+v, expected %+v", got, expected)
+	}
+
+	rec = httptest.NewRecorder()
+	DebugHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, DebugPath, nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Got status %d, expected %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestQueryHandler(t *testing.T) {
+	o := DefaultOptions()
+	o.RecentEntries = 10
+	if err := Configure(o); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = Configure(DefaultOptions()) }()
+
+	s := NewWithEmit("TestQueryHandler", "", 0, func(zapcore.Entry, []zapcore.Field) error { return nil })
+	s.Info("cache miss")
+	s.Error("cache timeout")
+
+	cases := []struct {
+		query    string
+		status   int
+		expected int
+	}{
+		{"?scope=TestQueryHandler", http.StatusOK, 2},
+		{"?scope=TestQueryHandler&level=error", http.StatusOK, 1},
+		{"?scope=TestQueryHandler&since=1h&q=miss", http.StatusOK, 1},
+		{"?scope=TestQueryHandler&since=2100-01-01T00:00:00Z", http.StatusOK, 0},
+		{"?level=verbose", http.StatusBadRequest, 0},
+		{"?since=yesterday", http.StatusBadRequest, 0},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			QueryHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, QueryPath+c.query, nil))
+
+			if rec.Code != c.status {
+				t.Fatalf("Got status %d, expected %d", rec.Code, c.status)
+			}
+			if c.status != http.StatusOK {
+				return
+			}
+
+			var entries []RecentEntry
+			if err := json.Unmarshal(rec.Body.Bytes(), &entries); err != nil {
+				t.Fatalf("Got error '%v', expected success", err)
+			}
+			if len(entries) != c.expected {
+				t.Errorf("Got %v, expected %d entries", entries, c.expected)
+			}
+		})
+	}
+
+	// since is relative to the time source of the entries
+	virtual := time.Now().Add(24 * time.Hour)
+	SetTimeSource(func() time.Time { return virtual })
+	defer SetTimeSource(nil)
+
+	s.Info("virtual")
+	rec := httptest.NewRecorder()
+	QueryHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, QueryPath+"?scope=TestQueryHandler&since=1m", nil))
+
+	var entries []RecentEntry
+	if err := json.Unmarshal(rec.Body.Bytes(), &entries); err != nil || len(entries) != 1 || entries[0].Message != "virtual" {
+		t.Errorf("Got %v, '%v', expected the entry logged at the virtual time", entries, err)
+	}
+
+	// values JSON can't represent don't fail the response
+	s.Info("not a number", zap.Float64("ratio", math.NaN()), zap.Float64("max", math.Inf(1)),
+		zap.Any("nested", map[string]interface{}{"min": math.Inf(-1)}), zap.Any("ch", make(chan int)))
+	rec = httptest.NewRecorder()
+	QueryHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, QueryPath+"?scope=TestQueryHandler&q=number", nil))
+
+	entries = nil
+	if err := json.Unmarshal(rec.Body.Bytes(), &entries); err != nil || rec.Code != http.StatusOK || len(entries) != 1 {
+		t.Fatalf("Got status %d, %v, '%v', expected the entry", rec.Code, entries, err)
+	}
+
+	fields := entries[0].Fields
+	if fields["ratio"] != "NaN" || fields["max"] != "+Inf" || !reflect.DeepEqual(fields["nested"], map[string]interface{}{"min": "-Inf"}) {
+		t.Errorf("Got %v, expected the non-finite floats as strings", fields)
+	}
+	if ch, _ := fields["ch"].(string); !strings.HasPrefix(ch, "unable to encode") {
+		t.Errorf("Got %v, expected the value which can't be encoded replaced", fields["ch"])
+	}
+}
+
+func TestPublishExpvar(t *testing.T) {
+	PublishExpvar()
+	PublishExpvar()
+
+	v := expvar.Get(ExpvarName)
+	if v == nil {
+		t.Fatalf("Got nil, expected the %s variable", ExpvarName)
+	}
+
+	var states []ScopeState
+	if err := json.Unmarshal([]byte(v.String()), &states); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	if _, ok := findState(states, DefaultScopeName); !ok {
+		t.Errorf("Got %v, expected the default scope", states)
+	}
+}
@@ -28,28 +28,65 @@ func registerDefaultScope() *Scope {
 
 var defaultScope = registerDefaultScope()
 
+// Fatal outputs a message at fatal level, writes a crash report if they are enabled and
+// terminates the process. Fatal messages are output whenever error level is enabled.
+func Fatal(msg string, fields ...zapcore.Field) {
+	if defaultScope.enabled(ErrorLevel) {
+		defaultScope.emit(zapcore.FatalLevel, defaultScope.GetStackTraceLevel() >= ErrorLevel, msg, fields)
+	}
+	crash(defaultScope, msg)
+}
+
+// Fatala uses fmt.Sprint to construct and log a message at fatal level, then terminates the process.
+func Fatala(args ...interface{}) {
+	msg := fmt.Sprint(args...)
+	if defaultScope.enabled(ErrorLevel) {
+		defaultScope.emit(zapcore.FatalLevel, defaultScope.GetStackTraceLevel() >= ErrorLevel, msg, nil)
+	}
+	crash(defaultScope, msg)
+}
+
+// Fatalf uses fmt.Sprintf to construct and log a message at fatal level, then terminates the process.
+func Fatalf(template string, args ...interface{}) {
+	msg, fields := defaultScope.formatf(template, args, true)
+	if defaultScope.enabled(ErrorLevel) {
+		defaultScope.emit(zapcore.FatalLevel, defaultScope.GetStackTraceLevel() >= ErrorLevel, msg, fields)
+	}
+	crash(defaultScope, msg)
+}
+
 // Error outputs a message at error level.
 func Error(msg string, fields ...zapcore.Field) {
-	if defaultScope.GetOutputLevel() >= ErrorLevel {
+	if defaultScope.enabled(ErrorLevel) {
+		errType := fieldsErrorType(fields)
 		defaultScope.emit(zapcore.ErrorLevel, defaultScope.GetStackTraceLevel() >= ErrorLevel, msg, fields)
+		defaultScope.countError(msg, errType)
 	}
 }
 
 // Errora uses fmt.Sprint to construct and log a message at error level.
 func Errora(args ...interface{}) {
-	if defaultScope.GetOutputLevel() >= ErrorLevel {
-		defaultScope.emit(zapcore.ErrorLevel, defaultScope.GetStackTraceLevel() >= ErrorLevel, fmt.Sprint(args...), nil)
+	if defaultScope.enabled(ErrorLevel) {
+		msg := fmt.Sprint(args...)
+		defaultScope.emit(zapcore.ErrorLevel, defaultScope.GetStackTraceLevel() >= ErrorLevel, msg, nil)
+		defaultScope.countError(msg, argsErrorType(args))
 	}
 }
 
 // Errorf uses fmt.Sprintf to construct and log a message at error level.
 func Errorf(template string, args ...interface{}) {
-	if defaultScope.GetOutputLevel() >= ErrorLevel {
-		msg := template
-		if len(args) > 0 {
-			msg = fmt.Sprintf(template, args...)
-		}
-		defaultScope.emit(zapcore.ErrorLevel, defaultScope.GetStackTraceLevel() >= ErrorLevel, msg, nil)
+	if defaultScope.enabled(ErrorLevel) {
+		msg, fields := defaultScope.formatf(template, args, ErrorEnabled())
+		defaultScope.emit(zapcore.ErrorLevel, defaultScope.GetStackTraceLevel() >= ErrorLevel, msg, fields)
+		defaultScope.countError(template, argsErrorType(args))
+	}
+}
+
+// Errorw outputs a message at error level, with fields built from alternating keys and values.
+func Errorw(msg string, keysAndValues ...interface{}) {
+	if defaultScope.enabled(ErrorLevel) {
+		defaultScope.emit(zapcore.ErrorLevel, defaultScope.GetStackTraceLevel() >= ErrorLevel, msg, defaultScope.keyValueFields(msg, keysAndValues, ErrorEnabled()))
+		defaultScope.countError(msg, argsErrorType(keysAndValues))
 	}
 }
 
@@ -60,26 +97,30 @@ func ErrorEnabled() bool {
 
 // Warn outputs a message at warn level.
 func Warn(msg string, fields ...zapcore.Field) {
-	if defaultScope.GetOutputLevel() >= WarnLevel {
+	if defaultScope.enabled(WarnLevel) {
 		defaultScope.emit(zapcore.WarnLevel, defaultScope.GetStackTraceLevel() >= WarnLevel, msg, fields)
 	}
 }
 
 // Warna uses fmt.Sprint to construct and log a message at warn level.
 func Warna(args ...interface{}) {
-	if defaultScope.GetOutputLevel() >= WarnLevel {
+	if defaultScope.enabled(WarnLevel) {
 		defaultScope.emit(zapcore.WarnLevel, defaultScope.GetStackTraceLevel() >= WarnLevel, fmt.Sprint(args...), nil)
 	}
 }
 
 // Warnf uses fmt.Sprintf to construct and log a message at warn level.
 func Warnf(template string, args ...interface{}) {
-	if defaultScope.GetOutputLevel() >= WarnLevel {
-		msg := template
-		if len(args) > 0 {
-			msg = fmt.Sprintf(template, args...)
-		}
-		defaultScope.emit(zapcore.WarnLevel, defaultScope.GetStackTraceLevel() >= WarnLevel, msg, nil)
+	if defaultScope.enabled(WarnLevel) {
+		msg, fields := defaultScope.formatf(template, args, WarnEnabled())
+		defaultScope.emit(zapcore.WarnLevel, defaultScope.GetStackTraceLevel() >= WarnLevel, msg, fields)
+	}
+}
+
+// Warnw outputs a message at warn level, with fields built from alternating keys and values.
+func Warnw(msg string, keysAndValues ...interface{}) {
+	if defaultScope.enabled(WarnLevel) {
+		defaultScope.emit(zapcore.WarnLevel, defaultScope.GetStackTraceLevel() >= WarnLevel, msg, defaultScope.keyValueFields(msg, keysAndValues, WarnEnabled()))
 	}
 }
 
@@ -90,26 +131,30 @@ func WarnEnabled() bool {
 
 // Info outputs a message at info level.
 func Info(msg string, fields ...zapcore.Field) {
-	if defaultScope.GetOutputLevel() >= InfoLevel {
+	if defaultScope.enabled(InfoLevel) {
 		defaultScope.emit(zapcore.InfoLevel, defaultScope.GetStackTraceLevel() >= InfoLevel, msg, fields)
 	}
 }
 
 // Infoa uses fmt.Sprint to construct and log a message at info level.
 func Infoa(args ...interface{}) {
-	if defaultScope.GetOutputLevel() >= InfoLevel {
+	if defaultScope.enabled(InfoLevel) {
 		defaultScope.emit(zapcore.InfoLevel, defaultScope.GetStackTraceLevel() >= InfoLevel, fmt.Sprint(args...), nil)
 	}
 }
 
 // Infof uses fmt.Sprintf to construct and log a message at info level.
 func Infof(template string, args ...interface{}) {
-	if defaultScope.GetOutputLevel() >= InfoLevel {
-		msg := template
-		if len(args) > 0 {
-			msg = fmt.Sprintf(template, args...)
-		}
-		defaultScope.emit(zapcore.InfoLevel, defaultScope.GetStackTraceLevel() >= InfoLevel, msg, nil)
+	if defaultScope.enabled(InfoLevel) {
+		msg, fields := defaultScope.formatf(template, args, InfoEnabled())
+		defaultScope.emit(zapcore.InfoLevel, defaultScope.GetStackTraceLevel() >= InfoLevel, msg, fields)
+	}
+}
+
+// Infow outputs a message at info level, with fields built from alternating keys and values.
+func Infow(msg string, keysAndValues ...interface{}) {
+	if defaultScope.enabled(InfoLevel) {
+		defaultScope.emit(zapcore.InfoLevel, defaultScope.GetStackTraceLevel() >= InfoLevel, msg, defaultScope.keyValueFields(msg, keysAndValues, InfoEnabled()))
 	}
 }
 
@@ -120,26 +165,30 @@ func InfoEnabled() bool {
 
 // Debug outputs a message at debug level.
 func Debug(msg string, fields ...zapcore.Field) {
-	if defaultScope.GetOutputLevel() >= DebugLevel {
+	if defaultScope.enabled(DebugLevel) {
 		defaultScope.emit(zapcore.DebugLevel, defaultScope.GetStackTraceLevel() >= DebugLevel, msg, fields)
 	}
 }
 
 // Debuga uses fmt.Sprint to construct and log a message at debug level.
 func Debuga(args ...interface{}) {
-	if defaultScope.GetOutputLevel() >= DebugLevel {
+	if defaultScope.enabled(DebugLevel) {
 		defaultScope.emit(zapcore.DebugLevel, defaultScope.GetStackTraceLevel() >= DebugLevel, fmt.Sprint(args...), nil)
 	}
 }
 
 // Debugf uses fmt.Sprintf to construct and log a message at debug level.
 func Debugf(template string, args ...interface{}) {
-	if defaultScope.GetOutputLevel() >= DebugLevel {
-		msg := template
-		if len(args) > 0 {
-			msg = fmt.Sprintf(template, args...)
-		}
-		defaultScope.emit(zapcore.DebugLevel, defaultScope.GetStackTraceLevel() >= DebugLevel, msg, nil)
+	if defaultScope.enabled(DebugLevel) {
+		msg, fields := defaultScope.formatf(template, args, DebugEnabled())
+		defaultScope.emit(zapcore.DebugLevel, defaultScope.GetStackTraceLevel() >= DebugLevel, msg, fields)
+	}
+}
+
+// Debugw outputs a message at debug level, with fields built from alternating keys and values.
+func Debugw(msg string, keysAndValues ...interface{}) {
+	if defaultScope.enabled(DebugLevel) {
+		defaultScope.emit(zapcore.DebugLevel, defaultScope.GetStackTraceLevel() >= DebugLevel, msg, defaultScope.keyValueFields(msg, keysAndValues, DebugEnabled()))
 	}
 }
 
@@ -34,21 +34,26 @@ func TestDefault(t *testing.T) {
 		{func() { Debugf("Hello") }, timePattern + "\tdebug\tHello", false, false, NoneLevel},
 		{func() { Debugf("%s", "Hello") }, timePattern + "\tdebug\tHello", false, false, NoneLevel},
 		{func() { Debuga("Hello") }, timePattern + "\tdebug\tHello", false, false, NoneLevel},
+		{func() { Debugw("Hello", "k", 1) }, timePattern + "\tdebug\tHello\t{\"k\": 1}", false, false, NoneLevel},
 
 		{func() { Info("Hello") }, timePattern + "\tinfo\tHello", false, false, NoneLevel},
 		{func() { Infof("Hello") }, timePattern + "\tinfo\tHello", false, false, NoneLevel},
 		{func() { Infof("%s", "Hello") }, timePattern + "\tinfo\tHello", false, false, NoneLevel},
 		{func() { Infoa("Hello") }, timePattern + "\tinfo\tHello", false, false, NoneLevel},
+		{func() { Infow("Hello", "k", 1) }, timePattern + "\tinfo\tHello\t{\"k\": 1}", false, false, NoneLevel},
+		{func() { Infof("%s", "Hello", KV, "k", 1) }, timePattern + "\tinfo\tHello\t{\"k\": 1}", false, false, NoneLevel},
 
 		{func() { Warn("Hello") }, timePattern + "\twarn\tHello", false, false, NoneLevel},
 		{func() { Warnf("Hello") }, timePattern + "\twarn\tHello", false, false, NoneLevel},
 		{func() { Warnf("%s", "Hello") }, timePattern + "\twarn\tHello", false, false, NoneLevel},
 		{func() { Warna("Hello") }, timePattern + "\twarn\tHello", false, false, NoneLevel},
+		{func() { Warnw("Hello", "k", 1) }, timePattern + "\twarn\tHello\t{\"k\": 1}", false, false, NoneLevel},
 
 		{func() { Error("Hello") }, timePattern + "\terror\tHello", false, false, NoneLevel},
 		{func() { Errorf("Hello") }, timePattern + "\terror\tHello", false, false, NoneLevel},
 		{func() { Errorf("%s", "Hello") }, timePattern + "\terror\tHello", false, false, NoneLevel},
 		{func() { Errora("Hello") }, timePattern + "\terror\tHello", false, false, NoneLevel},
+		{func() { Errorw("Hello", "k", 1) }, timePattern + "\terror\tHello\t{\"k\": 1}", false, false, NoneLevel},
 
 		{func() { Debug("Hello") }, timePattern + "\tdebug\tlog/default_test.go:.*\tHello", false, true, NoneLevel},
 
@@ -0,0 +1,127 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// defaultCSVColumns are the columns of CSVFormat and TSVFormat unless Options.CSVColumns is set.
+var defaultCSVColumns = []string{"time", "level", "scope", "msg"}
+
+// delimitedEncoder outputs each entry as a row of a fixed set of columns, separated by
+// commas or tabs and quoted as needed, see CSVFormat.
+type delimitedEncoder struct {
+	*zapcore.MapObjectEncoder
+
+	columns []templatePart
+	comma   rune
+}
+
+var delimitedBuffers = buffer.NewPool()
+
+func newDelimitedEncoder(columns []string, comma rune) (zapcore.Encoder, error) {
+	if len(columns) == 0 {
+		return nil, errors.New("no columns")
+	}
+
+	parts := make([]templatePart, len(columns))
+	for i, c := range columns {
+		if c == "" {
+			return nil, fmt.Errorf("empty name for column %d", i)
+		}
+
+		if e, ok := templateElements[c]; ok && e != fieldsElement {
+			parts[i] = templatePart{element: e}
+		} else {
+			parts[i] = templatePart{element: fieldElement, text: c}
+		}
+	}
+
+	return &delimitedEncoder{
+		MapObjectEncoder: zapcore.NewMapObjectEncoder(),
+		columns:          parts,
+		comma:            comma,
+	}, nil
+}
+
+func (enc *delimitedEncoder) Clone() zapcore.Encoder {
+	clone := &delimitedEncoder{
+		MapObjectEncoder: zapcore.NewMapObjectEncoder(),
+		columns:          enc.columns,
+		comma:            enc.comma,
+	}
+
+	for k, v := range enc.Fields {
+		clone.Fields[k] = v
+	}
+
+	return clone
+}
+
+func (enc *delimitedEncoder) EncodeEntry(e zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	m := zapcore.NewMapObjectEncoder()
+	for k, v := range enc.Fields {
+		m.Fields[k] = v
+	}
+	for _, f := range fields {
+		f.AddTo(m)
+	}
+
+	row := make([]string, len(enc.columns))
+	for i, c := range enc.columns {
+		switch c.element {
+		case timeElement:
+			row[i] = dateString(e.Time)
+		case levelElement:
+			row[i] = e.Level.String()
+		case upperLevelElement:
+			row[i] = e.Level.CapitalString()
+		case scopeElement:
+			row[i] = e.LoggerName
+		case msgElement:
+			row[i] = e.Message
+		case callerElement:
+			if e.Caller.Defined {
+				row[i] = e.Caller.TrimmedPath()
+			}
+		case fieldElement:
+			if v, ok := m.Fields[c.text]; ok {
+				row[i] = prettyValue(v)
+			}
+		}
+	}
+
+	buf := delimitedBuffers.Get()
+	w := csv.NewWriter(buf)
+	w.Comma = enc.comma
+	if err := w.Write(row); err != nil {
+		buf.Free()
+		return nil, err
+	}
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		buf.Free()
+		return nil, err
+	}
+
+	return buf, nil
+}
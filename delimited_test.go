@@ -0,0 +1,108 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestDelimitedEncoder(t *testing.T) {
+	e := zapcore.Entry{
+		Level:      zapcore.InfoLevel,
+		Time:       time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC),
+		LoggerName: "scope",
+		Message:    `said "hi", twice`,
+	}
+	fields := []zapcore.Field{zap.String("request_id", "r1"), zap.Int("n", 1)}
+
+	cases := []struct {
+		columns []string
+		comma   rune
+		result  string
+	}{
+		{defaultCSVColumns, ',', "2000-01-01T00:00:00.000000Z,info,scope,\"said \"\"hi\"\", twice\"\n"},
+		{defaultCSVColumns, '\t', "2000-01-01T00:00:00.000000Z\tinfo\tscope\t\"said \"\"hi\"\", twice\"\n"},
+		{[]string{"LEVEL", "request_id", "missing", "n"}, ',', "INFO,r1,,1\n"},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			enc, err := newDelimitedEncoder(c.columns, c.comma)
+			if err != nil {
+				t.Fatalf("Got error '%v', expected success", err)
+			}
+
+			buf, err := enc.EncodeEntry(e, fields)
+			if err != nil {
+				t.Fatalf("Got error '%v', expected success", err)
+			}
+
+			if got := buf.String(); got != c.result {
+				t.Errorf("Got '%v', expected '%v'", got, c.result)
+			}
+		})
+	}
+}
+
+func TestDelimitedEncoderWith(t *testing.T) {
+	enc, _ := newDelimitedEncoder([]string{"msg", "tenant", "n"}, ',')
+	zap.String("tenant", "a").AddTo(enc)
+
+	clone := enc.Clone()
+	zap.Int("n", 2).AddTo(clone)
+
+	buf, _ := clone.EncodeEntry(zapcore.Entry{Message: "Hello"}, nil)
+	if got, expected := buf.String(), "Hello,a,2\n"; got != expected {
+		t.Errorf("Got '%v', expected '%v'", got, expected)
+	}
+
+	buf, _ = enc.EncodeEntry(zapcore.Entry{Message: "Hello"}, nil)
+	if got, expected := buf.String(), "Hello,a,\n"; got != expected {
+		t.Errorf("Got '%v', expected '%v'", got, expected)
+	}
+}
+
+func TestCSVColumns(t *testing.T) {
+	o := DefaultOptions()
+	o.CSVColumns = []string{"msg", ""}
+	if err := Configure(o); err == nil {
+		t.Error("Got success, expected an error for an empty column")
+	}
+
+	lines, err := captureStdout(func() {
+		o := DefaultOptions()
+		o.TSVEncoding = true
+		o.CSVColumns = []string{"level", "msg", "k"}
+		if err := Configure(o); err != nil {
+			t.Errorf("Got error '%v', expected success", err)
+		}
+
+		Info("Hello", zap.String("k", "v"))
+		_ = Sync()
+	})
+	_ = Configure(DefaultOptions())
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	if expected := "info\tHello\tv"; lines[0] != expected {
+		t.Errorf("Got '%v', expected '%v'", lines[0], expected)
+	}
+}
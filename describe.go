@@ -0,0 +1,55 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"encoding/json"
+)
+
+// ScopeDescriptionKey is the key of the field holding the description of the scope of an
+// entry, when Options.ScopeDescriptions is set.
+const ScopeDescriptionKey = "scope_desc"
+
+// set by the Configure method, 1 when entries carry the description of their scope
+var scopeDescriptions int32
+
+// ScopeDescription documents a registered scope and its current settings.
+type ScopeDescription struct {
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	OutputLevel     Level  `json:"output_level"`
+	StackTraceLevel Level  `json:"stack_trace_level"`
+	LogCallers      bool   `json:"log_callers"`
+	Format          string `json:"format"`
+}
+
+// DescribeScopes returns a JSON document describing all the registered scopes, sorted by
+// name, with their descriptions, current levels and formats. It's meant to be embedded in
+// the help of commands, admin interfaces or the documentation generated for operators.
+func DescribeScopes() ([]byte, error) {
+	return json.MarshalIndent(ReadOnlyRegistry().Scopes(), "", "  ")
+}
+
+// describeScope returns the description of a scope and its current settings.
+func describeScope(s *Scope) ScopeDescription {
+	return ScopeDescription{
+		Name:            s.Name(),
+		Description:     s.Description(),
+		OutputLevel:     s.GetOutputLevel(),
+		StackTraceLevel: s.GetStackTraceLevel(),
+		LogCallers:      s.GetLogCallers(),
+		Format:          s.GetFormat().String(),
+	}
+}
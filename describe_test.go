@@ -0,0 +1,89 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestDescribeScopes(t *testing.T) {
+	s := RegisterScope("TestDescribeScopes", "described", 0)
+	s.SetOutputLevel(DebugLevel)
+	s.SetFormat(JSONFormat)
+	defer s.SetOutputLevel(InfoLevel)
+	defer s.SetFormat(DefaultFormat)
+
+	doc, err := DescribeScopes()
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	var descriptions []ScopeDescription
+	if err := json.Unmarshal(doc, &descriptions); err != nil {
+		t.Fatalf("Got error '%v', expected a JSON document", err)
+	}
+
+	for i := 1; i < len(descriptions); i++ {
+		if descriptions[i-1].Name >= descriptions[i].Name {
+			t.Errorf("Got %s before %s, expected scopes sorted by name", descriptions[i-1].Name, descriptions[i].Name)
+		}
+	}
+
+	expected := ScopeDescription{Name: "TestDescribeScopes", Description: "described", OutputLevel: DebugLevel, StackTraceLevel: NoneLevel, Format: "json"}
+	found := false
+	for _, d := range descriptions {
+		if d.Name == expected.Name {
+			found = true
+			if d != expected {
+				t.Errorf("Got %+v, expected %+v", d, expected)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Got %v, expected the TestDescribeScopes scope", descriptions)
+	}
+}
+
+func TestScopeDescriptions(t *testing.T) {
+	var got []string
+	s := NewWithEmit("TestScopeDescriptions", "caching subsystem", 0, func(_ zapcore.Entry, fields []zapcore.Field) error {
+		for _, f := range fields {
+			if f.Key == ScopeDescriptionKey {
+				got = append(got, f.String)
+			}
+		}
+		return nil
+	})
+
+	s.Info("disabled")
+	if len(got) != 0 {
+		t.Errorf("Got %v, expected no description by default", got)
+	}
+
+	o := DefaultOptions()
+	o.ScopeDescriptions = true
+	if err := Configure(o); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = Configure(DefaultOptions()) }()
+
+	s.Info("enabled")
+	if len(got) != 1 || got[0] != "caching subsystem" {
+		t.Errorf("Got %v, expected the description of the scope", got)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Keys under which entries dropped by sinks are accounted. Since scope names cannot
+// contain colons, these never collide with the name of a scope.
+const (
+	DroppedByAsyncSink   = "sink:async"
+	DroppedByNetworkSink = "sink:network"
+	// DroppedByHTTPSink accounts for the batches of entries dropped by HTTPWriter spill files.
+	DroppedByHTTPSink = "sink:http"
+)
+
+// dropped holds a *uint64 counter per scope name or sink key.
+var dropped sync.Map
+
+// reset by the Configure method
+var droppedReporter struct {
+	sync.Mutex
+	stop chan struct{}
+}
+
+// recordDropped accounts for n entries that were dropped on behalf of the given scope or sink.
+func recordDropped(key string, n uint64) {
+	c, ok := dropped.Load(key)
+	if !ok {
+		c, _ = dropped.LoadOrStore(key, new(uint64))
+	}
+
+	atomic.AddUint64(c.(*uint64), n)
+}
+
+// DroppedCounts returns a snapshot of the number of entries dropped since the process
+// started, keyed by scope name. Entries dropped by sinks, which don't know which scope
+// produced them, are accounted under the DroppedByAsyncSink, DroppedByNetworkSink and
+// DroppedByHTTPSink keys.
+func DroppedCounts() map[string]uint64 {
+	counts := make(map[string]uint64)
+	dropped.Range(func(k, v interface{}) bool {
+		counts[k.(string)] = atomic.LoadUint64(v.(*uint64))
+		return true
+	})
+
+	return counts
+}
+
+// startDroppedReporter replaces any running reporter with one that logs a summary of the
+// entries dropped during each interval. An interval of 0 disables the reporter.
+func startDroppedReporter(interval time.Duration) {
+	droppedReporter.Lock()
+	defer droppedReporter.Unlock()
+
+	if droppedReporter.stop != nil {
+		close(droppedReporter.stop)
+		droppedReporter.stop = nil
+	}
+
+	if interval <= 0 {
+		return
+	}
+
+	stop := make(chan struct{})
+	droppedReporter.stop = stop
+
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+
+		last := DroppedCounts()
+		for {
+			select {
+			case <-t.C:
+				current := DroppedCounts()
+				reportDropped(last, current)
+				last = current
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// reportDropped logs the difference between two snapshots of dropped counts, if there's any.
+func reportDropped(last, current map[string]uint64) {
+	keys := make([]string, 0, len(current))
+	for k, v := range current {
+		if v != last[k] {
+			keys = append(keys, k)
+		}
+	}
+
+	if len(keys) == 0 {
+		return
+	}
+
+	sort.Strings(keys)
+
+	var total uint64
+	fields := make([]zapcore.Field, 0, len(keys)+1)
+	for _, k := range keys {
+		n := current[k] - last[k]
+		total += n
+		fields = append(fields, zap.Uint64(k, n))
+	}
+	fields = append(fields, zap.Uint64("total", total))
+
+	Warn("log entries were dropped", fields...)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestDroppedCounts(t *testing.T) {
+	before := DroppedCounts()
+
+	recordDropped("TestDroppedCounts", 2)
+	recordDropped("TestDroppedCounts", 3)
+	recordDropped(DroppedByAsyncSink, 1)
+
+	after := DroppedCounts()
+	if n := after["TestDroppedCounts"]; n != 5 {
+		t.Errorf("Got %d, expected 5", n)
+	}
+
+	if n := after[DroppedByAsyncSink] - before[DroppedByAsyncSink]; n != 1 {
+		t.Errorf("Got %d, expected 1", n)
+	}
+}
+
+func TestDroppedSummary(t *testing.T) {
+	lines, err := captureStdout(func() {
+		o := DefaultOptions()
+		o.JSONEncoding = true
+		_ = Configure(o)
+
+		reportDropped(map[string]uint64{"a": 1, "b": 2}, map[string]uint64{"a": 1, "b": 5, "c": 1})
+		reportDropped(map[string]uint64{"a": 1}, map[string]uint64{"a": 1})
+		_ = Sync()
+	})
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	pat := `"msg":"log entries were dropped","b":3,"c":1,"total":4}`
+	if match, _ := regexp.MatchString(pat, lines[0]); !match {
+		t.Errorf("Got '%v', expected a match with '%v'", lines[0], pat)
+	}
+
+	if lines[1] != "" {
+		t.Errorf("Got '%v', expected no summary when nothing was dropped", lines[1])
+	}
+}
@@ -0,0 +1,318 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// ArchiveKeyEnvVar is the environment variable holding the base64-encoded AES key used to
+// encrypt the archived log files, unless a function is set with SetArchiveKeyFunc.
+const ArchiveKeyEnvVar = "LOG_ARCHIVE_KEY"
+
+const (
+	// archiveMagic starts every encrypted archive, followed by the base nonce.
+	archiveMagic = "TLOGAES1"
+
+	// archiveChunkSize is the size of the chunks the archives are encrypted by.
+	archiveChunkSize = 64 * 1024
+
+	// archiveFinalChunk flags the length of the last chunk, so truncated archives are detected.
+	archiveFinalChunk = 1 << 31
+)
+
+var (
+	// func() ([]byte, error), nil to use ArchiveKeyEnvVar
+	archiveKeyFunc atomic.Value
+
+	// serializes the encryption of archives, which are rewritten in place
+	archiveEncryption sync.Mutex
+
+	// lets tests wait for the archives to be encrypted
+	archiveJobs sync.WaitGroup
+)
+
+// SetArchiveKeyFunc sets the function returning the AES key, 16, 24 or 32 bytes long, used
+// to encrypt the archived log files when Options.EncryptArchives is set, such as a function
+// fetching it from a secret manager. It is called for every archive, so keys can be rotated.
+// Passing nil reverts to reading the key from the environment variable ArchiveKeyEnvVar.
+func SetArchiveKeyFunc(fn func() ([]byte, error)) {
+	archiveKeyFunc.Store(fn)
+}
+
+// archiveKey returns the key archives are encrypted with.
+func archiveKey() ([]byte, error) {
+	if fn, _ := archiveKeyFunc.Load().(func() ([]byte, error)); fn != nil {
+		return fn()
+	}
+
+	value := os.Getenv(ArchiveKeyEnvVar)
+	if value == "" {
+		return nil, fmt.Errorf("no key to encrypt the archived log files, %s is not set", ArchiveKeyEnvVar)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key in %s: %v", ArchiveKeyEnvVar, err)
+	}
+
+	return key, nil
+}
+
+func newArchiveAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// chunkNonce returns the nonce of a chunk, the base nonce of the archive XORed with the
+// index of the chunk, so no nonce is ever reused with a key.
+func chunkNonce(base []byte, index uint64) []byte {
+	nonce := append([]byte(nil), base...)
+	tail := nonce[len(nonce)-8:]
+	binary.BigEndian.PutUint64(tail, binary.BigEndian.Uint64(tail)^index)
+	return nonce
+}
+
+// encryptArchiveStream encrypts src to dst with AES-GCM, in chunks each authenticated along
+// with its length, the last one being flagged.
+func encryptArchiveStream(dst io.Writer, src io.Reader, key []byte) error {
+	aead, err := newArchiveAEAD(key)
+	if err != nil {
+		return err
+	}
+
+	base := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(base); err != nil {
+		return err
+	}
+
+	if _, err := dst.Write(append([]byte(archiveMagic), base...)); err != nil {
+		return err
+	}
+
+	buf := make([]byte, archiveChunkSize)
+	var header [4]byte
+	for index := uint64(0); ; index++ {
+		n, err := io.ReadFull(src, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+
+		final := n < len(buf)
+		length := uint32(n + aead.Overhead())
+		if final {
+			length |= archiveFinalChunk
+		}
+		binary.BigEndian.PutUint32(header[:], length)
+
+		sealed := aead.Seal(append([]byte(nil), header[:]...), chunkNonce(base, index), buf[:n], header[:])
+		if _, err := dst.Write(sealed); err != nil {
+			return err
+		}
+
+		if final {
+			return nil
+		}
+	}
+}
+
+// archiveReader decrypts an archive chunk by chunk.
+type archiveReader struct {
+	in    io.Reader
+	aead  cipher.AEAD
+	base  []byte
+	index uint64
+	buf   []byte
+	done  bool
+}
+
+// NewArchiveReader returns a reader of the entries of an archived log file encrypted with
+// the given key. Reading fails if the archive was altered or truncated.
+func NewArchiveReader(r io.Reader, key []byte) (io.Reader, error) {
+	aead, err := newArchiveAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, len(archiveMagic)+aead.NonceSize())
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("invalid archive header: %v", err)
+	}
+
+	if string(header[:len(archiveMagic)]) != archiveMagic {
+		return nil, errors.New("not an encrypted log archive")
+	}
+
+	return &archiveReader{in: r, aead: aead, base: header[len(archiveMagic):]}, nil
+}
+
+// Read returns the decrypted entries.
+func (ar *archiveReader) Read(p []byte) (int, error) {
+	for len(ar.buf) == 0 {
+		if ar.done {
+			return 0, io.EOF
+		}
+
+		if err := ar.next(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, ar.buf)
+	ar.buf = ar.buf[n:]
+	return n, nil
+}
+
+// next decrypts the next chunk.
+func (ar *archiveReader) next() error {
+	var header [4]byte
+	if _, err := io.ReadFull(ar.in, header[:]); err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+
+	length := binary.BigEndian.Uint32(header[:])
+	final := length&archiveFinalChunk != 0
+	length &^= archiveFinalChunk
+	if length > uint32(archiveChunkSize+ar.aead.Overhead()) {
+		return errors.New("corrupted log archive")
+	}
+
+	sealed := make([]byte, length)
+	if _, err := io.ReadFull(ar.in, sealed); err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+
+	plain, err := ar.aead.Open(sealed[:0], chunkNonce(ar.base, ar.index), sealed, header[:])
+	if err != nil {
+		return errors.New("corrupted log archive")
+	}
+
+	ar.index++
+	ar.buf = plain
+	ar.done = final
+	return nil
+}
+
+// encryptArchive encrypts a log file in place, unless it already is, keeping its name,
+// permissions and modification time so that retention policies are unaffected.
+func encryptArchive(path string, key []byte) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	magic := make([]byte, len(archiveMagic))
+	if n, _ := io.ReadFull(f, magic); bytes.Equal(magic[:n], []byte(archiveMagic)) {
+		return nil
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".enc-")
+	if err != nil {
+		return err
+	}
+
+	err = encryptArchiveStream(tmp, f, key)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if e := tmp.Close(); e != nil && err == nil {
+		err = e
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), info.Mode().Perm())
+	}
+	if err == nil {
+		err = os.Chtimes(tmp.Name(), info.ModTime(), info.ModTime())
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// encryptArchivesAsync encrypts, in the background, the archives listed by the given function.
+// Failures are reported to the error output, the archives being left as they are.
+func encryptArchivesAsync(list func() []string) {
+	archiveJobs.Add(1)
+	go func() {
+		defer archiveJobs.Done()
+
+		archiveEncryption.Lock()
+		defer archiveEncryption.Unlock()
+
+		paths := list()
+		if len(paths) == 0 {
+			return
+		}
+
+		key, err := archiveKey()
+		if err != nil {
+			reportArchiveError(paths[0], err)
+			return
+		}
+
+		for _, path := range paths {
+			if err := encryptArchive(path, key); err != nil {
+				reportArchiveError(path, err)
+			}
+		}
+	}()
+}
+
+func reportArchiveError(path string, err error) {
+	if es, _ := errorSink.Load().(zapcore.WriteSyncer); es != nil {
+		_, _ = fmt.Fprintf(es, "%v log unable to encrypt archive %s: %v\n", time.Now(), path, err)
+		_ = es.Sync()
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestArchiveStream(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+
+	cases := []int{0, 10, archiveChunkSize, 3*archiveChunkSize + 5}
+	for i, size := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			plain := bytes.Repeat([]byte("entry\n"), size/6+1)[:size]
+
+			var buf bytes.Buffer
+			if err := encryptArchiveStream(&buf, bytes.NewReader(plain), key); err != nil {
+				t.Fatalf("Got error '%v', expected success", err)
+			}
+
+			if size > 0 && bytes.Contains(buf.Bytes(), []byte("entry")) {
+				t.Errorf("Got plain text in the archive, expected it to be encrypted")
+			}
+
+			r, err := NewArchiveReader(bytes.NewReader(buf.Bytes()), key)
+			if err != nil {
+				t.Fatalf("Got error '%v', expected success", err)
+			}
+
+			got, err := ioutil.ReadAll(r)
+			if err != nil {
+				t.Fatalf("Got error '%v', expected success", err)
+			}
+			if !bytes.Equal(got, plain) {
+				t.Errorf("Got %d bytes, expected the %d bytes encrypted", len(got), len(plain))
+			}
+
+			// truncated archives are rejected
+			r, _ = NewArchiveReader(bytes.NewReader(buf.Bytes()[:buf.Len()-1]), key)
+			if _, err := ioutil.ReadAll(r); err == nil {
+				t.Errorf("Got success, expected a truncated archive to be rejected")
+			}
+		})
+	}
+}
+
+func TestArchiveReaderErrors(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+
+	var buf bytes.Buffer
+	_ = encryptArchiveStream(&buf, bytes.NewReader([]byte("secret entry\n")), key)
+
+	r, _ := NewArchiveReader(bytes.NewReader(buf.Bytes()), bytes.Repeat([]byte{8}, 32))
+	if _, err := ioutil.ReadAll(r); err == nil {
+		t.Errorf("Got success, expected the wrong key to be rejected")
+	}
+
+	altered := append([]byte(nil), buf.Bytes()...)
+	altered[len(altered)-1] ^= 1
+	r, _ = NewArchiveReader(bytes.NewReader(altered), key)
+	if _, err := ioutil.ReadAll(r); err == nil {
+		t.Errorf("Got success, expected an altered archive to be rejected")
+	}
+
+	if _, err := NewArchiveReader(bytes.NewReader([]byte("plain text entry\n")), key); err == nil {
+		t.Errorf("Got success, expected a plain file to be rejected")
+	}
+
+	if _, err := NewArchiveReader(&buf, []byte("short")); err == nil {
+		t.Errorf("Got success, expected an invalid key to be rejected")
+	}
+}
+
+func TestEncryptArchive(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 16)
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := ioutil.WriteFile(path, []byte("entry\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	_ = os.Chtimes(path, modTime, modTime)
+
+	// encrypting twice is harmless
+	for i := 0; i < 2; i++ {
+		if err := encryptArchive(path, key); err != nil {
+			t.Fatalf("Got error '%v', expected success", err)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.ModTime().Equal(modTime) || info.Mode().Perm() != 0600 {
+		t.Errorf("Got %v %v, expected the modification time and permissions to be kept", info.ModTime(), info.Mode())
+	}
+
+	f, _ := os.Open(path)
+	defer func() { _ = f.Close() }()
+	r, err := NewArchiveReader(f, key)
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	if got, _ := ioutil.ReadAll(r); string(got) != "entry\n" {
+		t.Errorf("Got %q, expected the original entry", got)
+	}
+
+	if matches, _ := filepath.Glob(path + "*"); len(matches) != 1 {
+		t.Errorf("Got %v, expected no temporary file to be left", matches)
+	}
+}
+
+func TestArchiveKey(t *testing.T) {
+	defer SetArchiveKeyFunc(nil)
+	defer func() { _ = os.Unsetenv(ArchiveKeyEnvVar) }()
+
+	_ = os.Unsetenv(ArchiveKeyEnvVar)
+	if _, err := archiveKey(); err == nil {
+		t.Errorf("Got success, expected an error without a key")
+	}
+
+	_ = os.Setenv(ArchiveKeyEnvVar, "not base64!")
+	if _, err := archiveKey(); err == nil {
+		t.Errorf("Got success, expected an error with an invalid key")
+	}
+
+	_ = os.Setenv(ArchiveKeyEnvVar, base64.StdEncoding.EncodeToString([]byte("0123456789abcdef")))
+	if key, err := archiveKey(); err != nil || string(key) != "0123456789abcdef" {
+		t.Errorf("Got %q, %v, expected the key of the environment", key, err)
+	}
+
+	SetArchiveKeyFunc(func() ([]byte, error) { return nil, errors.New("unavailable") })
+	if _, err := archiveKey(); err == nil || err.Error() != "unavailable" {
+		t.Errorf("Got %v, expected the error of the key function", err)
+	}
+}
+
+func TestTimedFileEncrypt(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+	SetArchiveKeyFunc(func() ([]byte, error) { return key, nil })
+	defer SetArchiveKeyFunc(nil)
+
+	dir := t.TempDir()
+	old := filepath.Join(dir, "app-2020-01-01.log")
+	if err := ioutil.WriteFile(old, []byte("old entry\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tf, err := NewTimedFile(filepath.Join(dir, "app-%Y-%m-%d.log"), &TimedFileOptions{Encrypt: true})
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = tf.Close() }()
+
+	_, _ = tf.Write([]byte("new entry\n"))
+	archiveJobs.Wait()
+
+	if b, _ := ioutil.ReadFile(tf.Name()); string(b) != "new entry\n" {
+		t.Errorf("Got %q, expected the current file to be left alone", b)
+	}
+
+	f, _ := os.Open(old)
+	defer func() { _ = f.Close() }()
+	r, err := NewArchiveReader(f, key)
+	if err != nil {
+		t.Fatalf("Got error '%v', expected the previous file to be encrypted", err)
+	}
+	if got, _ := ioutil.ReadAll(r); string(got) != "old entry\n" {
+		t.Errorf("Got %q, expected the previous entry", got)
+	}
+}
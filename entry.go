@@ -0,0 +1,150 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Entry builds an entry field by field, as an alternative to the variadic methods of
+// Scope, for callers and code generators which prefer typed calls over key/value lists:
+//
+//	s.NewEntry(log.InfoLevel).Str("user", id).Int("attempts", n).Err(err).Msg("logged in")
+//
+// NewEntry returns nil when the level is disabled, and the methods of a nil Entry do
+// nothing, so a disabled entry costs neither allocations nor encoding. An Entry must not
+// be used once Msg or Msgf returned.
+type Entry struct {
+	s      *Scope
+	level  Level
+	fields []zapcore.Field
+}
+
+// NewEntry starts an entry at the given level, or returns nil if the level is disabled.
+func (s *Scope) NewEntry(level Level) *Entry {
+	if level == NoneLevel || !s.enabled(level) {
+		return nil
+	}
+
+	return &Entry{s: s, level: level, fields: make([]zapcore.Field, 0, 8)}
+}
+
+// Enabled returns whether the entry is output.
+func (e *Entry) Enabled() bool {
+	return e != nil
+}
+
+// Str adds a string field.
+func (e *Entry) Str(key string, value string) *Entry {
+	return e.Field(zap.String(key, value))
+}
+
+// Int adds an integer field.
+func (e *Entry) Int(key string, value int) *Entry {
+	return e.Field(zap.Int(key, value))
+}
+
+// Int64 adds a 64-bit integer field.
+func (e *Entry) Int64(key string, value int64) *Entry {
+	return e.Field(zap.Int64(key, value))
+}
+
+// Uint64 adds an unsigned 64-bit integer field.
+func (e *Entry) Uint64(key string, value uint64) *Entry {
+	return e.Field(zap.Uint64(key, value))
+}
+
+// Float64 adds a floating-point field.
+func (e *Entry) Float64(key string, value float64) *Entry {
+	return e.Field(zap.Float64(key, value))
+}
+
+// Bool adds a boolean field.
+func (e *Entry) Bool(key string, value bool) *Entry {
+	return e.Field(zap.Bool(key, value))
+}
+
+// Dur adds a duration field.
+func (e *Entry) Dur(key string, value time.Duration) *Entry {
+	return e.Field(zap.Duration(key, value))
+}
+
+// Time adds a time field.
+func (e *Entry) Time(key string, value time.Time) *Entry {
+	return e.Field(zap.Time(key, value))
+}
+
+// Err adds an error field, under the configured error key. See Err.
+func (e *Entry) Err(err error) *Entry {
+	return e.Field(Err(err))
+}
+
+// Any adds a field of any type, encoded by reflection if zap doesn't know the type.
+func (e *Entry) Any(key string, value interface{}) *Entry {
+	return e.Field(zap.Any(key, value))
+}
+
+// Field adds fields built by zap or by this package.
+func (e *Entry) Field(fields ...zapcore.Field) *Entry {
+	if e != nil {
+		e.fields = append(e.fields, fields...)
+	}
+	return e
+}
+
+// Msg emits the entry with the given message.
+func (e *Entry) Msg(msg string) {
+	if e == nil {
+		return
+	}
+
+	var errType string
+	if e.level == ErrorLevel {
+		// taken before the fields are processed by emit
+		errType = fieldsErrorType(e.fields)
+	}
+	e.s.emit(levelToZap[e.level], e.s.GetStackTraceLevel() >= e.level, msg, e.fields)
+	if e.level == ErrorLevel {
+		e.s.countError(msg, errType)
+	}
+}
+
+// Msgf emits the entry with a message formatted with fmt.Sprintf. As with Infof, the
+// arguments following KV are keys and values added to the fields of the entry.
+func (e *Entry) Msgf(template string, args ...interface{}) {
+	if e == nil {
+		return
+	}
+
+	var errType string
+	if e.level == ErrorLevel {
+		// taken before the fields are processed by emit
+		if errType = fieldsErrorType(e.fields); errType == "" {
+			errType = argsErrorType(args)
+		}
+	}
+
+	msg, fields := e.s.formatf(template, args, e.s.GetOutputLevel() >= e.level)
+	if len(fields) > 0 {
+		e.fields = append(e.fields, fields...)
+	}
+	e.s.emit(levelToZap[e.level], e.s.GetStackTraceLevel() >= e.level, msg, e.fields)
+	if e.level == ErrorLevel {
+		e.s.countError(template, errType)
+	}
+}
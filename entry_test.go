@@ -0,0 +1,107 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"errors"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestEntry(t *testing.T) {
+	var entries []zapcore.Entry
+	var fields [][]zapcore.Field
+	s := NewWithEmit("TestEntry", "", 0, func(e zapcore.Entry, f []zapcore.Field) error {
+		entries = append(entries, e)
+		fields = append(fields, f)
+		return nil
+	})
+	s.SetLogCallers(true)
+
+	_, _, line, _ := runtime.Caller(0)
+	s.NewEntry(InfoLevel).Str("user", "alice").Int("attempts", 3).Dur("took", time.Second).Err(errors.New("denied")).Msg("logged in")
+	s.NewEntry(DebugLevel).Str("user", "bob").Msg("disabled")
+	s.NewEntry(WarnLevel).Bool("retry", true).Msgf("attempt %d", 2)
+
+	if len(entries) != 2 {
+		t.Fatalf("Got %d entries, expected 2", len(entries))
+	}
+
+	if entries[0].Message != "logged in" || entries[0].Level != zapcore.InfoLevel || entries[1].Message != "attempt 2" {
+		t.Errorf("Got %v, expected the messages and levels of the entries", entries)
+	}
+
+	if entries[0].Caller.Line != line+1 || !strings.HasSuffix(entries[0].Caller.File, "entry_test.go") {
+		t.Errorf("Got %v, expected entry_test.go:%d", entries[0].Caller, line+1)
+	}
+
+	expected := []string{"user", "attempts", "took", "error"}
+	if len(fields[0]) != len(expected) {
+		t.Fatalf("Got %v, expected the fields %v", fields[0], expected)
+	}
+	for i, k := range expected {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if fields[0][i].Key != k {
+				t.Errorf("Got %s, expected %s", fields[0][i].Key, k)
+			}
+		})
+	}
+}
+
+func TestNilEntry(t *testing.T) {
+	s := RegisterScope("TestNilEntry", "", 0)
+
+	e := s.NewEntry(DebugLevel)
+	if e.Enabled() {
+		t.Errorf("Got an enabled entry, expected debug to be disabled")
+	}
+
+	// all methods are safe on disabled entries
+	e.Str("k", "v").Int("n", 1).Any("a", []int{1}).Msg("ignored")
+
+	if s.NewEntry(NoneLevel) != nil {
+		t.Errorf("Got an entry, expected none for NoneLevel")
+	}
+}
+
+func TestEntryMsgf(t *testing.T) {
+	var fields [][]zapcore.Field
+	s := NewWithEmit("TestEntryMsgf", "", 0, func(_ zapcore.Entry, f []zapcore.Field) error {
+		fields = append(fields, f)
+		return nil
+	})
+
+	o := DefaultOptions()
+	o.ErrorSummaryInterval = time.Hour
+	_ = Configure(o)
+	defer func() { _ = Configure(DefaultOptions()) }()
+
+	s.NewEntry(ErrorLevel).Msgf("read %d failed", 1)
+	s.NewEntry(ErrorLevel).Str("k", "v").Msgf("read %d failed", 2, KV, "path", "/tmp")
+
+	counts := ErrorCounts()
+	if len(counts) != 1 || counts[0].Template != "read %d failed" || counts[0].Count != 2 {
+		t.Errorf("Got %v, expected the errors counted by template", counts)
+	}
+
+	if len(fields) != 2 || len(fields[1]) != 2 || fields[1][0].Key != "k" || fields[1][1].Key != "path" {
+		t.Errorf("Got %v, expected the keys and values following KV as fields", fields)
+	}
+}
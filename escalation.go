@@ -0,0 +1,200 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// EscalatedKey is the key of the field marking the alerts of the escalation policies.
+const EscalatedKey = "escalated"
+
+// maxEscalationFingerprints is the number of fingerprints tracked by a policy above which
+// those not seen during the window are forgotten.
+const maxEscalationFingerprints = 1024
+
+// EscalationPolicy triggers actions when an error entry repeats, that is when Threshold
+// error entries with the same fingerprint are logged within Window. This lets programs
+// react to a failing dependency, e.g. by tripping a circuit breaker, from their logs alone.
+// Once triggered, the count of the fingerprint starts over.
+type EscalationPolicy struct {
+	// Threshold is the number of error entries which trigger the policy, 1 if lower.
+	Threshold int
+	// Window is the duration within which the entries are counted, without limit if 0.
+	Window time.Duration
+	// Alert logs an error entry, with a stack trace and the EscalatedKey field set to true,
+	// through the scope of the repeated error. It's logged at error rather than fatal level,
+	// which the forwarders, such as Sentry's, take for the termination of the process.
+	Alert bool
+	// Action, if set, is called with the fingerprint of the repeated error and the number of
+	// entries. It's called synchronously by the goroutine logging the last entry, so it must
+	// return quickly.
+	Action func(fp ErrorFingerprint, count int)
+	// Metric, if set, is incremented each time the policy triggers.
+	Metric Metric
+}
+
+type escalation struct {
+	id     int
+	policy EscalationPolicy
+
+	mu   sync.Mutex
+	seen map[ErrorFingerprint][]time.Time
+}
+
+var escalations struct {
+	sync.Mutex
+	next       int
+	registered []*escalation
+}
+
+// holds the []*escalation checked by countError, replaced whenever policies are added or removed
+var activeEscalations atomic.Value
+
+// AddEscalation registers a policy applied to the error entries of all scopes. The returned
+// function removes the policy.
+func AddEscalation(p EscalationPolicy) (remove func()) {
+	if p.Threshold < 1 {
+		p.Threshold = 1
+	}
+
+	escalations.Lock()
+	defer escalations.Unlock()
+
+	id := escalations.next
+	escalations.next++
+	escalations.registered = append(escalations.registered, &escalation{
+		id:     id,
+		policy: p,
+		seen:   make(map[ErrorFingerprint][]time.Time),
+	})
+	activeEscalations.Store(append([]*escalation(nil), escalations.registered...))
+
+	return func() {
+		escalations.Lock()
+		defer escalations.Unlock()
+
+		for i, e := range escalations.registered {
+			if e.id == id {
+				escalations.registered = append(escalations.registered[:i:i], escalations.registered[i+1:]...)
+				break
+			}
+		}
+		activeEscalations.Store(append([]*escalation(nil), escalations.registered...))
+	}
+}
+
+// record accounts for an error entry, and returns the number of entries counted when the
+// policy triggers, 0 otherwise.
+func (e *escalation) record(fp ErrorFingerprint, now time.Time) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	times, ok := e.seen[fp]
+	if !ok && len(e.seen) >= maxEscalationFingerprints {
+		e.forget(now)
+	}
+
+	times = append(e.expire(times, now), now)
+	if len(times) < e.policy.Threshold {
+		e.seen[fp] = times
+		return 0
+	}
+
+	delete(e.seen, fp)
+	return len(times)
+}
+
+// expire returns the times still within the window.
+func (e *escalation) expire(times []time.Time, now time.Time) []time.Time {
+	if e.policy.Window <= 0 {
+		return times
+	}
+
+	oldest := now.Add(-e.policy.Window)
+	i := 0
+	for i < len(times) && !times[i].After(oldest) {
+		i++
+	}
+
+	return times[i:]
+}
+
+// forget drops the fingerprints not seen during the window.
+func (e *escalation) forget(now time.Time) {
+	for fp, times := range e.seen {
+		if len(e.expire(times, now)) == 0 {
+			delete(e.seen, fp)
+		}
+	}
+}
+
+// alert returns the scope emitting the escalation alerts for the scope: the registered scope,
+// without the gates and fields of the derived ones, and whose entries are never sampled.
+// The alerts are attributed to the call logging the last of the repeated entries.
+func (s *Scope) alert() *Scope {
+	base := s.registry.lookup(s.name)
+	if base == nil {
+		base = s
+	}
+
+	a := base.copy()
+	a.gate = nil
+	a.unsampled = true
+	a.callerSkip = s.callerSkip + 2
+
+	return a
+}
+
+// escalate applies the registered policies to an error entry of the scope, once the entry
+// is emitted, so that the alerts follow it.
+func (s *Scope) escalate(fp ErrorFingerprint) {
+	active, _ := activeEscalations.Load().([]*escalation)
+	if len(active) == 0 {
+		return
+	}
+
+	at := now()
+	for _, e := range active {
+		count := e.record(fp, at)
+		if count == 0 {
+			continue
+		}
+
+		p := e.policy
+		if p.Metric != nil {
+			p.Metric.Record(1)
+		}
+
+		if p.Alert {
+			s.alert().emit(zapcore.ErrorLevel, true, "error escalated", []zapcore.Field{
+				zap.Bool(EscalatedKey, true),
+				zap.String("template", fp.Template),
+				zap.String("error_type", fp.ErrorType),
+				zap.Int("count", count),
+				zap.Duration("window", p.Window),
+			})
+		}
+
+		if p.Action != nil {
+			p.Action(fp, count)
+		}
+	}
+}
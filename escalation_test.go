@@ -0,0 +1,142 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestEscalationRecord(t *testing.T) {
+	base := time.Unix(1000, 0)
+	a := ErrorFingerprint{Scope: "s", Template: "a"}
+	b := ErrorFingerprint{Scope: "s", Template: "b"}
+	cases := []struct {
+		fp    ErrorFingerprint
+		at    time.Duration
+		count int
+	}{
+		{a, 0, 0},
+		{b, time.Second, 0},
+		{a, 2 * time.Second, 0},
+		{a, 3 * time.Second, 3},
+		// counts start over once triggered
+		{a, 4 * time.Second, 0},
+		{b, 5 * time.Second, 0},
+		// the first entry of b is out of the window
+		{b, 11 * time.Second, 0},
+		{b, 12 * time.Second, 3},
+	}
+
+	e := &escalation{policy: EscalationPolicy{Threshold: 3, Window: 10 * time.Second}, seen: map[ErrorFingerprint][]time.Time{}}
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if count := e.record(c.fp, base.Add(c.at)); count != c.count {
+				t.Errorf("Got %v, expected %v", count, c.count)
+			}
+		})
+	}
+}
+
+type countingMetric struct{ total float64 }
+
+func (m *countingMetric) Record(value float64) { m.total += value }
+
+func TestAddEscalation(t *testing.T) {
+	var levels []zapcore.Level
+	var alerts int
+	s := NewWithEmit("TestAddEscalation", "", 0, func(e zapcore.Entry, fields []zapcore.Field) error {
+		levels = append(levels, e.Level)
+		for _, f := range fields {
+			if f.Key == EscalatedKey && f.Integer == 1 {
+				alerts++
+			}
+		}
+		return nil
+	})
+
+	var triggered []ErrorFingerprint
+	m := &countingMetric{}
+	remove := AddEscalation(EscalationPolicy{
+		Threshold: 2,
+		Window:    time.Minute,
+		Alert:     true,
+		Metric:    m,
+		Action: func(fp ErrorFingerprint, count int) {
+			triggered = append(triggered, fp)
+		},
+	})
+
+	err := errors.New("boom")
+	s.Errorf("failed: %v", err)
+	s.Errorf("failed: %v", err)
+	remove()
+	s.Errorf("failed: %v", err)
+	s.Errorf("failed: %v", err)
+
+	expected := ErrorFingerprint{Scope: "TestAddEscalation", Template: "failed: %v", ErrorType: "*errors.errorString"}
+	if len(triggered) != 1 || triggered[0] != expected {
+		t.Errorf("Got %v, expected %v", triggered, expected)
+	}
+	if m.total != 1 {
+		t.Errorf("Got %v, expected 1", m.total)
+	}
+
+	if len(levels) != 5 || alerts != 1 {
+		t.Errorf("Got %v, expected a single alert", levels)
+	}
+
+	for _, l := range levels {
+		if l != zapcore.ErrorLevel {
+			t.Errorf("Got %v, expected %v", l, zapcore.ErrorLevel)
+		}
+	}
+}
+
+func TestEscalationAlertOrder(t *testing.T) {
+	var messages []string
+	s := NewWithEmit("TestEscalationAlertOrder", "", 0, func(e zapcore.Entry, _ []zapcore.Field) error {
+		messages = append(messages, e.Message)
+		return nil
+	})
+
+	remove := AddEscalation(EscalationPolicy{Threshold: 2, Alert: true})
+	defer remove()
+
+	// the second entry is gated, the alert is emitted regardless
+	gated := s.Every(time.Hour)
+	gated.Error("failed")
+	gated.Error("failed")
+
+	expected := []string{"failed", "error escalated"}
+	if len(messages) != len(expected) || messages[0] != expected[0] || messages[1] != expected[1] {
+		t.Errorf("Got %v, expected %v", messages, expected)
+	}
+
+	// the alert isn't sampled along with the entries
+	messages = nil
+	s.SetSampleRate(ErrorLevel, 0)
+	defer s.SetSampleRate(ErrorLevel, 1)
+	s.Error("failed")
+	s.Error("failed")
+
+	if len(messages) != 1 || messages[0] != "error escalated" {
+		t.Errorf("Got %v, expected only the alert", messages)
+	}
+}
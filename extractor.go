@@ -0,0 +1,95 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// ContextExtractor returns the fields to add to an entry, as alternating keys and values,
+// pulled from the context the scope was bound to, such as a tenant or user identifier
+// stored under a key private to the application. It returns nil when the context holds
+// nothing of interest.
+type ContextExtractor func(ctx context.Context) []interface{}
+
+type registeredExtractor struct {
+	id int
+	fn ContextExtractor
+}
+
+var contextExtractors struct {
+	sync.Mutex
+	next int
+
+	// []registeredExtractor, replaced on every change so emit can read it without locking
+	all atomic.Value
+}
+
+// RegisterContextExtractor registers a function pulling fields from the contexts scopes
+// are bound to with WithContext. The function is called when each entry is emitted, so
+// the values are those of the context at that time, and callers needn't copy them with
+// ContextWithFields. It must be cheap and safe for concurrent use. The returned function
+// removes the extractor.
+//
+// Only the scopes obtained through WithContext once an extractor is registered call it.
+func RegisterContextExtractor(fn ContextExtractor) (remove func()) {
+	contextExtractors.Lock()
+	defer contextExtractors.Unlock()
+
+	id := contextExtractors.next
+	contextExtractors.next++
+
+	current, _ := contextExtractors.all.Load().([]registeredExtractor)
+	updated := append(current[:len(current):len(current)], registeredExtractor{id, fn})
+	contextExtractors.all.Store(updated)
+
+	return func() {
+		contextExtractors.Lock()
+		defer contextExtractors.Unlock()
+
+		current, _ := contextExtractors.all.Load().([]registeredExtractor)
+		updated := make([]registeredExtractor, 0, len(current))
+		for _, e := range current {
+			if e.id != id {
+				updated = append(updated, e)
+			}
+		}
+		contextExtractors.all.Store(updated)
+	}
+}
+
+// hasContextExtractors returns whether any extractor is registered.
+func hasContextExtractors() bool {
+	all, _ := contextExtractors.all.Load().([]registeredExtractor)
+	return len(all) > 0
+}
+
+// extractContextFields returns the fields pulled from the context by the extractors.
+func (s *Scope) extractContextFields(ctx context.Context, msg string) []zapcore.Field {
+	all, _ := contextExtractors.all.Load().([]registeredExtractor)
+
+	var fields []zapcore.Field
+	for _, e := range all {
+		if kv := e.fn(ctx); len(kv) > 0 {
+			fields = append(fields, s.keyValueFields(msg, kv, true)...)
+		}
+	}
+
+	return fields
+}
@@ -0,0 +1,63 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+type tenantKey struct{}
+
+func TestContextExtractor(t *testing.T) {
+	var got []string
+	s := NewWithEmit("TestContextExtractor", "", 0, func(_ zapcore.Entry, fields []zapcore.Field) error {
+		for _, f := range fields {
+			if f.Key == "tenant" {
+				got = append(got, f.String)
+			}
+		}
+		return nil
+	})
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+
+	s.WithContext(ctx).Info("before")
+	if len(got) != 0 {
+		t.Errorf("Got %v, expected no field without an extractor", got)
+	}
+
+	remove := RegisterContextExtractor(func(ctx context.Context) []interface{} {
+		if tenant, ok := ctx.Value(tenantKey{}).(string); ok {
+			return []interface{}{"tenant", tenant}
+		}
+		return nil
+	})
+
+	bound := s.WithContext(ctx)
+	bound.Info("registered")
+	s.WithContext(context.Background()).Info("no tenant")
+	if len(got) != 1 || got[0] != "acme" {
+		t.Errorf("Got %v, expected the tenant of the context", got)
+	}
+
+	remove()
+	bound.Info("removed")
+	if len(got) != 1 {
+		t.Errorf("Got %v, expected no field once the extractor is removed", got)
+	}
+}
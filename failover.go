@@ -0,0 +1,172 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	defaultFailoverMaxFailures   = 3
+	defaultFailoverProbeInterval = 30 * time.Second
+)
+
+// FailoverOptions controls when a FailoverWriter switches between its writers.
+type FailoverOptions struct {
+	// MaxFailures is the number of consecutive failed writes to the primary writer after
+	// which the fallback writer takes over.
+	MaxFailures int
+
+	// ProbeInterval is how often the primary writer is tried again once the fallback
+	// writer took over.
+	ProbeInterval time.Duration
+}
+
+// DefaultFailoverOptions returns a new set of failover options, initialized to the defaults
+func DefaultFailoverOptions() *FailoverOptions {
+	return &FailoverOptions{
+		MaxFailures:   defaultFailoverMaxFailures,
+		ProbeInterval: defaultFailoverProbeInterval,
+	}
+}
+
+// FailoverWriter writes entries to a primary writer, such as a file or a network sink,
+// and switches to a fallback writer, such as stderr or a spill file, when the primary
+// writer keeps failing, because the disk is full or the network is down for example.
+// The primary writer is probed periodically and takes over again once it recovers.
+//
+// Entries the primary writer fails to write are written to the fallback writer, so
+// none are lost while switching. Transitions are logged through the default scope.
+type FailoverWriter struct {
+	primary  io.Writer
+	fallback io.Writer
+	options  FailoverOptions
+
+	mu         sync.Mutex
+	failures   int
+	onFallback bool
+	nextProbe  time.Time
+}
+
+// NewFailoverWriter returns a writer that falls back to the given fallback writer when
+// the primary writer fails.
+func NewFailoverWriter(primary, fallback io.Writer, options *FailoverOptions) *FailoverWriter {
+	if options == nil {
+		options = DefaultFailoverOptions()
+	}
+
+	fw := &FailoverWriter{
+		primary:  primary,
+		fallback: fallback,
+		options:  *options,
+	}
+
+	if fw.options.MaxFailures <= 0 {
+		fw.options.MaxFailures = 1
+	}
+
+	return fw
+}
+
+// Write writes an entry to the primary writer, or to the fallback writer if the primary
+// writer failed.
+func (fw *FailoverWriter) Write(p []byte) (int, error) {
+	fw.mu.Lock()
+
+	if fw.onFallback && time.Now().Before(fw.nextProbe) {
+		fw.mu.Unlock()
+		return fw.fallback.Write(p)
+	}
+
+	_, err := fw.primary.Write(p)
+	if err == nil {
+		recovered := fw.onFallback
+		fw.failures = 0
+		fw.onFallback = false
+		fw.mu.Unlock()
+
+		if recovered {
+			fw.logTransition("log output recovered, switching back to the primary writer", nil)
+		}
+
+		return len(p), nil
+	}
+
+	switched := false
+	fw.failures++
+	if fw.onFallback || fw.failures >= fw.options.MaxFailures {
+		switched = !fw.onFallback
+		fw.onFallback = true
+		fw.nextProbe = time.Now().Add(fw.options.ProbeInterval)
+	}
+	fw.mu.Unlock()
+
+	if switched {
+		fw.logTransition("log output failing, switching to the fallback writer", err)
+	}
+
+	return fw.fallback.Write(p)
+}
+
+// Sync syncs both writers, if they support it.
+func (fw *FailoverWriter) Sync() error {
+	var err error
+	for _, w := range []io.Writer{fw.primary, fw.fallback} {
+		if s, ok := w.(zapcore.WriteSyncer); ok {
+			if e := s.Sync(); e != nil && err == nil {
+				err = e
+			}
+		}
+	}
+
+	return err
+}
+
+// Close closes both writers, if they support it.
+func (fw *FailoverWriter) Close() error {
+	var err error
+	for _, w := range []io.Writer{fw.primary, fw.fallback} {
+		if c, ok := w.(io.Closer); ok {
+			if e := c.Close(); e != nil && err == nil {
+				err = e
+			}
+		}
+	}
+
+	return err
+}
+
+// OnFallback returns whether the fallback writer is currently in use.
+func (fw *FailoverWriter) OnFallback() bool {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+
+	return fw.onFallback
+}
+
+// logTransition logs asynchronously, as the writer may well be where the entry goes,
+// possibly behind a lock held by the caller.
+func (fw *FailoverWriter) logTransition(msg string, err error) {
+	if err != nil {
+		go Warn(msg, zap.Error(err))
+	} else {
+		go Info(msg)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flakyWriter fails while broken is set.
+type flakyWriter struct {
+	recordingWriter
+
+	mu     sync.Mutex
+	broken bool
+}
+
+func (w *flakyWriter) setBroken(broken bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.broken = broken
+}
+
+func (w *flakyWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	broken := w.broken
+	w.mu.Unlock()
+
+	if broken {
+		return 0, errors.New("disk full")
+	}
+
+	return w.recordingWriter.Write(p)
+}
+
+func TestFailoverWriter(t *testing.T) {
+	primary := &flakyWriter{}
+	fallback := &recordingWriter{}
+
+	fw := NewFailoverWriter(primary, fallback, &FailoverOptions{MaxFailures: 2, ProbeInterval: 50 * time.Millisecond})
+
+	_, _ = fw.Write([]byte("1"))
+	primary.setBroken(true)
+	_, _ = fw.Write([]byte("2"))
+	if fw.OnFallback() {
+		t.Error("Expecting the primary writer to be kept after a single failure")
+	}
+
+	_, _ = fw.Write([]byte("3"))
+	if !fw.OnFallback() {
+		t.Error("Expecting the fallback writer to take over")
+	}
+
+	// the primary writer isn't probed before the interval elapses
+	primary.setBroken(false)
+	_, _ = fw.Write([]byte("4"))
+
+	time.Sleep(60 * time.Millisecond)
+	_, _ = fw.Write([]byte("5"))
+	if fw.OnFallback() {
+		t.Error("Expecting the primary writer to take over again")
+	}
+
+	if got := primary.get(); len(got) != 2 || got[0] != "1" || got[1] != "5" {
+		t.Errorf("Got %v, expected [1 5] in the primary writer", got)
+	}
+
+	if got := fallback.get(); len(got) != 3 || got[0] != "2" || got[1] != "3" || got[2] != "4" {
+		t.Errorf("Got %v, expected [2 3 4] in the fallback writer", got)
+	}
+}
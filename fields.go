@@ -0,0 +1,182 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	defaultErrorKey = "error"
+	defaultScopeKey = "scope"
+)
+
+// fieldProcessor rewrites the fields of an entry before it is encoded. Processors must
+// not modify the slice they are given, as it belongs to the caller.
+type fieldProcessor func([]zapcore.Field) []zapcore.Field
+
+// set by the Configure method
+var fieldProcessors atomic.Value
+
+func init() {
+	fieldProcessors.Store([]fieldProcessor(nil))
+}
+
+// processFields runs the configured processors over the fields of an entry.
+func processFields(fields []zapcore.Field) []zapcore.Field {
+	for _, p := range fieldProcessors.Load().([]fieldProcessor) {
+		fields = p(fields)
+	}
+
+	return fields
+}
+
+// buildFieldProcessors returns the processors needed to honor the given options.
+func buildFieldProcessors(options *Options) []fieldProcessor {
+	var processors []fieldProcessor
+
+	if (options.ErrorKey != "" && options.ErrorKey != defaultErrorKey) || options.NilErrorValue != "" {
+		processors = append(processors, errorProcessor(options.ErrorKey, options.NilErrorValue))
+	}
+
+	if len(options.PseudonymizedFields) > 0 {
+		processors = append(processors, pseudonymizationProcessor(options.PseudonymizedFields, options.PseudonymizationKey))
+	}
+
+	rules := options.RedactionRules
+	if options.MaxValueSize > 0 {
+		rules = append(rules[:len(rules):len(rules)], RedactionRule{MaxSize: options.MaxValueSize})
+	}
+
+	if len(rules) > 0 {
+		processors = append(processors, redactionProcessor(rules))
+	}
+
+	if options.BinaryEncoding != Base64Binary || options.BinaryMaxBytes > 0 {
+		processors = append(processors, binaryProcessor(options.BinaryEncoding, options.BinaryMaxBytes))
+	}
+
+	if options.MaxFields > 0 {
+		processors = append(processors, maxFieldsProcessor(options.MaxFields))
+	}
+
+	return processors
+}
+
+// rewriteFields returns fields with the ones selected by match replaced by the result of
+// rewrite, copying the slice only if needed.
+func rewriteFields(fields []zapcore.Field, match func(*zapcore.Field) bool, rewrite func(zapcore.Field) zapcore.Field) []zapcore.Field {
+	var out []zapcore.Field
+	for i := range fields {
+		if !match(&fields[i]) {
+			continue
+		}
+
+		if out == nil {
+			out = make([]zapcore.Field, len(fields))
+			copy(out, fields)
+		}
+		out[i] = rewrite(fields[i])
+	}
+
+	if out == nil {
+		return fields
+	}
+
+	return out
+}
+
+// nilError marks the field produced by Err for a nil error.
+type nilError struct{}
+
+// Err constructs a field carrying an error under the configured error key. Unlike
+// zap.Error, the rendering of nil errors can be configured through Options.NilErrorValue;
+// by default they are omitted.
+func Err(err error) zapcore.Field {
+	if err == nil {
+		return zapcore.Field{Key: defaultErrorKey, Type: zapcore.SkipType, Interface: nilError{}}
+	}
+
+	return zap.Error(err)
+}
+
+func errorProcessor(key string, nilValue string) fieldProcessor {
+	if key == "" {
+		key = defaultErrorKey
+	}
+
+	match := func(f *zapcore.Field) bool {
+		if f.Key != defaultErrorKey {
+			return false
+		}
+
+		if f.Type == zapcore.ErrorType {
+			return true
+		}
+
+		_, isNil := f.Interface.(nilError)
+		return f.Type == zapcore.SkipType && isNil && nilValue != ""
+	}
+
+	return func(fields []zapcore.Field) []zapcore.Field {
+		return rewriteFields(fields, match, func(f zapcore.Field) zapcore.Field {
+			if f.Type == zapcore.SkipType {
+				return zap.String(key, nilValue)
+			}
+
+			f.Key = key
+			return f
+		})
+	}
+}
+
+// maxFieldsProcessor keeps the first max fields of an entry and replaces the rest with
+// a count of the omitted fields.
+func maxFieldsProcessor(max int) fieldProcessor {
+	return func(fields []zapcore.Field) []zapcore.Field {
+		if len(fields) <= max {
+			return fields
+		}
+
+		out := make([]zapcore.Field, max, max+1)
+		copy(out, fields)
+
+		return append(out, zap.Int("fields_omitted", len(fields)-max))
+	}
+}
+
+// fieldsCore runs the configured field processors over entries sent through the zap API.
+type fieldsCore struct {
+	zapcore.Core
+}
+
+func (c fieldsCore) With(fields []zapcore.Field) zapcore.Core {
+	return fieldsCore{c.Core.With(processFields(fields))}
+}
+
+func (c fieldsCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(e.Level) {
+		return ce.AddCore(e, c)
+	}
+
+	return ce
+}
+
+func (c fieldsCore) Write(e zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(e, processFields(withStaticFields(fields)))
+}
@@ -0,0 +1,156 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestErrorFields(t *testing.T) {
+	cases := []struct {
+		errorKey string
+		nilValue string
+		fields   []zapcore.Field
+		want     string
+	}{
+		{"", "", []zapcore.Field{zap.Error(errors.New("bad"))}, `"msg":"Hello","error":"bad"}`},
+		{"", "", []zapcore.Field{Err(errors.New("bad"))}, `"msg":"Hello","error":"bad"}`},
+		{"", "", []zapcore.Field{Err(nil)}, `"msg":"Hello"}`},
+		{"err", "", []zapcore.Field{zap.Error(errors.New("bad"))}, `"msg":"Hello","err":"bad"}`},
+		{"err", "", []zapcore.Field{zap.NamedError("cause", errors.New("bad"))}, `"msg":"Hello","cause":"bad"}`},
+		{"", "<nil>", []zapcore.Field{Err(nil)}, `"msg":"Hello","error":"<nil>"}`},
+		{"error.message", "none", []zapcore.Field{Err(nil), zap.Int("n", 1)}, `"msg":"Hello","error.message":"none","n":1}`},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			lines, err := captureStdout(func() {
+				o := DefaultOptions()
+				o.JSONEncoding = true
+				o.ErrorKey = c.errorKey
+				o.NilErrorValue = c.nilValue
+				_ = Configure(o)
+
+				Info("Hello", c.fields...)
+				_ = Sync()
+			})
+			if err != nil {
+				t.Fatalf("Got error '%v', expected success", err)
+			}
+
+			if match, _ := regexp.MatchString(regexp.QuoteMeta(c.want), lines[0]); !match {
+				t.Errorf("Got '%v', expected a match with '%v'", lines[0], c.want)
+			}
+		})
+	}
+
+	_ = Configure(DefaultOptions())
+}
+
+func TestProcessorsKeepCallerFields(t *testing.T) {
+	fields := []zapcore.Field{zap.Error(errors.New("bad"))}
+
+	out := errorProcessor("err", "")(fields)
+	if out[0].Key != "err" {
+		t.Errorf("Got %s, expected err", out[0].Key)
+	}
+
+	if fields[0].Key != "error" {
+		t.Errorf("Got %s, expected the caller's slice to be left alone", fields[0].Key)
+	}
+}
+
+func TestCapturedFieldsProcessed(t *testing.T) {
+	lines, err := captureStdout(func() {
+		o := DefaultOptions()
+		o.JSONEncoding = true
+		o.ErrorKey = "err"
+		_ = Configure(o)
+
+		zap.L().With(zap.Error(errors.New("first"))).Info("Hello", zap.NamedError("error", errors.New("second")))
+		_ = Sync()
+	})
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	want := `"msg":"Hello","err":"first","err":"second"}`
+	if match, _ := regexp.MatchString(want, lines[0]); !match {
+		t.Errorf("Got '%v', expected a match with '%v'", lines[0], want)
+	}
+
+	_ = Configure(DefaultOptions())
+}
+
+func TestScopeKey(t *testing.T) {
+	s := RegisterScope("TestScopeKey", "", 0)
+
+	cases := []struct {
+		key  string
+		want string
+	}{
+		{"", `"scope":"TestScopeKey"`},
+		{"subsystem", `"subsystem":"TestScopeKey"`},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			lines, err := captureStdout(func() {
+				o := DefaultOptions()
+				o.JSONEncoding = true
+				o.ScopeKey = c.key
+				_ = Configure(o)
+
+				s.Info("Hello")
+				_ = Sync()
+			})
+			if err != nil {
+				t.Fatalf("Got error '%v', expected success", err)
+			}
+
+			if !strings.Contains(lines[0], c.want) {
+				t.Errorf("Got '%v', expected it to contain '%v'", lines[0], c.want)
+			}
+		})
+	}
+
+	_ = Configure(DefaultOptions())
+}
+
+func TestMaxFields(t *testing.T) {
+	p := maxFieldsProcessor(2)
+
+	fields := []zapcore.Field{zap.Int("a", 1), zap.Int("b", 2)}
+	if out := p(fields); len(out) != 2 {
+		t.Errorf("Got %v, expected the fields to be left alone", out)
+	}
+
+	fields = append(fields, zap.Int("c", 3), zap.Int("d", 4), zap.Int("e", 5))
+	out := p(fields)
+	if len(out) != 3 || out[0].Key != "a" || out[1].Key != "b" || out[2] != zap.Int("fields_omitted", 3) {
+		t.Errorf("Got %v, expected a, b and fields_omitted=3", out)
+	}
+
+	if fields[2].Key != "c" {
+		t.Errorf("Got %s, expected the caller's slice to be left alone", fields[2].Key)
+	}
+}
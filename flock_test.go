@@ -0,0 +1,74 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+// +build linux darwin freebsd netbsd openbsd dragonfly
+
+package log
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReopenableFileLocking(t *testing.T) {
+	dir, err := ioutil.TempDir("", "log_flock")
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "shared.log")
+
+	rf, err := NewReopenableFile(path)
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer rf.Close()
+	rf.SetLocking(true)
+
+	// another process holding the lock
+	other, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	defer other.Close()
+	if err := lockFile(other); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	written := make(chan struct{})
+	go func() {
+		_, _ = rf.Write([]byte("entry\n"))
+		close(written)
+	}()
+
+	select {
+	case <-written:
+		t.Fatal("Expecting the write to wait for the lock")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlockFile(other)
+
+	select {
+	case <-written:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expecting the write to proceed once the lock is released")
+	}
+
+	if content, _ := ioutil.ReadFile(path); string(content) != "entry\n" {
+		t.Errorf("Got %q, expected the entry", content)
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Format is an enumeration of the supported output formats.
+type Format int
+
+const (
+	// DefaultFormat uses the format configured for the whole process through Options.JSONEncoding
+	// and Options.Pretty.
+	DefaultFormat Format = iota
+	// ConsoleFormat produces plain console-friendly output, the fields of each entry being
+	// placed according to Options.ConsoleFields.
+	ConsoleFormat
+	// JSONFormat produces one JSON object per entry.
+	JSONFormat
+	// MessageFormat only outputs the message of each entry, followed by its fields if it
+	// has any. It suits scopes whose messages are already fully formatted lines, such as
+	// access logs.
+	MessageFormat
+	// PrettyFormat is meant for developers reading the output in a terminal. It outputs
+	// each entry on a line, followed by its fields, one per line, indented and colored.
+	PrettyFormat
+	// TemplateFormat outputs each entry on a line laid out by Options.TextTemplate, such as
+	// "{time} [{LEVEL}] {scope} {msg} {fields}", which lets the output match a legacy format.
+	// The placeholders are {time}, {level}, {LEVEL}, {scope}, {msg}, {caller} and {fields},
+	// the fields output as key=value pairs. Any other placeholder names a field, output in
+	// place rather than with the other fields. Literal braces are doubled.
+	TemplateFormat
+	// CSVFormat outputs each entry as a row of comma-separated values, quoted as needed, for
+	// ingestion by analytics tools. The columns are set by Options.CSVColumns, and default to
+	// time, level, scope and msg. Besides these, and LEVEL and caller, the columns name fields,
+	// left empty for the entries without them. Stack traces and the other fields are left out.
+	CSVFormat
+	// TSVFormat is like CSVFormat, with the values separated by tabs.
+	TSVFormat
+	// MsgpackFormat outputs each entry as a MessagePack map, holding the same keys as in
+	// JSONFormat, for high-volume machine-to-machine shipping where the cost and size of
+	// JSON matter. Times are timestamp extensions, and durations are integer nanoseconds.
+	// The entries aren't delimited, as MessagePack values are self-delimiting. See
+	// logtest.ParseMsgpack.
+	MsgpackFormat
+)
+
+var formatToString = map[Format]string{
+	DefaultFormat:  "default",
+	ConsoleFormat:  "console",
+	JSONFormat:     "json",
+	MessageFormat:  "message",
+	PrettyFormat:   "pretty",
+	TemplateFormat: "template",
+	CSVFormat:      "csv",
+	TSVFormat:      "tsv",
+	MsgpackFormat:  "msgpack",
+}
+
+var stringToFormat = map[string]Format{
+	"default":  DefaultFormat,
+	"console":  ConsoleFormat,
+	"json":     JSONFormat,
+	"message":  MessageFormat,
+	"pretty":   PrettyFormat,
+	"template": TemplateFormat,
+	"csv":      CSVFormat,
+	"tsv":      TSVFormat,
+	"msgpack":  MsgpackFormat,
+}
+
+// String returns the name of the format
+func (f Format) String() string {
+	return formatToString[f]
+}
+
+// FormatFrom returns the format for the given name
+func FormatFrom(name string) (Format, bool) {
+	f, ok := stringToFormat[name]
+	return f, ok
+}
+
+// Formats returns the formats entries can be output in, DefaultFormat excluded.
+func Formats() []Format {
+	formats := make([]Format, 0, len(formatToString)-1)
+	for f := range formatToString {
+		if f != DefaultFormat {
+			formats = append(formats, f)
+		}
+	}
+
+	sort.Slice(formats, func(i, j int) bool { return formats[i] < formats[j] })
+	return formats
+}
+
+func newEncoder(f Format, encCfg zapcore.EncoderConfig) zapcore.Encoder {
+	if f == JSONFormat {
+		return zapcore.NewJSONEncoder(encCfg)
+	}
+
+	if f == MessageFormat {
+		return zapcore.NewConsoleEncoder(zapcore.EncoderConfig{
+			MessageKey: encCfg.MessageKey,
+			LineEnding: encCfg.LineEnding,
+		})
+	}
+
+	if f == PrettyFormat {
+		return newPrettyEncoder(encCfg)
+	}
+
+	if f == TemplateFormat {
+		enc, _ := newTemplateEncoder(defaultTextTemplate)
+		return enc
+	}
+
+	if f == MsgpackFormat {
+		return newMsgpackEncoder(encCfg)
+	}
+
+	if f == CSVFormat || f == TSVFormat {
+		enc, _ := newDelimitedEncoder(defaultCSVColumns, delimiters[f])
+		return enc
+	}
+
+	return zapcore.NewConsoleEncoder(encCfg)
+}
+
+var delimiters = map[Format]rune{
+	CSVFormat: ',',
+	TSVFormat: '\t',
+}
+
+// SetFormat overrides the output format of the scope. Use DefaultFormat to revert to the
+// format configured for the whole process.
+func (s *Scope) SetFormat(f Format) {
+	s.format.Store(f)
+}
+
+// GetFormat returns the output format of the scope.
+func (s *Scope) GetFormat() Format {
+	return s.format.Load().(Format)
+}
+
+// Encode renders an entry as it is output in the given format, including the line ending.
+// It is typically used by the functions given to NewWithEmit. DefaultFormat stands for
+// the format configured for the whole process.
+func Encode(f Format, e zapcore.Entry, fields []zapcore.Field) ([]byte, error) {
+	out, _ := currentOutputs.Load().(*outputs)
+	if out == nil {
+		return nil, nil
+	}
+
+	if f == DefaultFormat {
+		f = out.format
+	}
+
+	enc, ok := out.encoders[f]
+	if !ok {
+		return nil, fmt.Errorf("unknown format %d", int(f))
+	}
+
+	buf, err := enc.EncodeEntry(e, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	b := append([]byte(nil), buf.Bytes()...)
+	buf.Free()
+
+	return b, nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestScopeFormat(t *testing.T) {
+	s := RegisterScope("TestScopeFormat", "", 0)
+	defer s.SetFormat(DefaultFormat)
+
+	cases := []struct {
+		json   bool
+		format Format
+		pat    string
+	}{
+		{false, DefaultFormat, timePattern + "\tinfo\tTestScopeFormat\tHello"},
+		{false, JSONFormat, `{"level":"info","time":"` + timePattern + `","scope":"TestScopeFormat","msg":"Hello"}`},
+		{true, DefaultFormat, `{"level":"info","time":"` + timePattern + `","scope":"TestScopeFormat","msg":"Hello"}`},
+		{true, ConsoleFormat, timePattern + "\tinfo\tTestScopeFormat\tHello"},
+		{true, MessageFormat, "^Hello$"},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			lines, err := captureStdout(func() {
+				o := DefaultOptions()
+				o.JSONEncoding = c.json
+				_ = Configure(o)
+
+				s.SetFormat(c.format)
+				s.Info("Hello")
+				_ = Sync()
+			})
+			if err != nil {
+				t.Fatalf("Got error '%v', expected success", err)
+			}
+
+			if match, _ := regexp.MatchString(c.pat, lines[0]); !match {
+				t.Errorf("Got '%v', expected a match with '%v'", lines[0], c.pat)
+			}
+		})
+	}
+}
+
+func TestFormatFrom(t *testing.T) {
+	for f, name := range formatToString {
+		if got, ok := FormatFrom(name); !ok || got != f {
+			t.Errorf("Got (%v, %v), expected (%v, true)", got, ok, f)
+		}
+
+		if f.String() != name {
+			t.Errorf("Got %s, expected %s", f.String(), name)
+		}
+	}
+
+	if _, ok := FormatFrom("flattened"); ok {
+		t.Error("Got true, expected false")
+	}
+}
+
+func TestFormats(t *testing.T) {
+	got := Formats()
+	expected := []Format{ConsoleFormat, JSONFormat, MessageFormat, PrettyFormat, TemplateFormat, CSVFormat, TSVFormat, MsgpackFormat}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Got %v, expected %v", got, expected)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	_ = Configure(DefaultOptions())
+
+	e := zapcore.Entry{
+		Level:      zapcore.InfoLevel,
+		Time:       time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC),
+		LoggerName: "scope",
+		Message:    "Hello",
+	}
+
+	cases := []struct {
+		format Format
+		result string
+	}{
+		{DefaultFormat, "2000-01-01T00:00:00.000000Z\tinfo\tscope\tHello\t{\"k\": \"v\"}\n"},
+		{JSONFormat, `{"level":"info","time":"2000-01-01T00:00:00.000000Z","scope":"scope","msg":"Hello","k":"v"}` + "\n"},
+		{MessageFormat, "Hello\t{\"k\": \"v\"}\n"},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			b, err := Encode(c.format, e, []zapcore.Field{zap.String("k", "v")})
+			if err != nil {
+				t.Fatalf("Got error '%v', expected success", err)
+			}
+
+			if string(b) != c.result {
+				t.Errorf("Got %q, expected %q", b, c.result)
+			}
+		})
+	}
+
+	if _, err := Encode(Format(42), e, nil); err == nil {
+		t.Error("Got success, expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// FrozenScope is an immutable snapshot of a scope, with the cheapest possible emit path for
+// the hottest loops. Its level, format, output and fields are fixed when it's created by
+// Freeze: changes made to the scope or by Configure afterwards don't affect it.
+//
+// To be cheap, a frozen scope only encodes and writes its entries. Callers, stack traces,
+// sampling, throttling, metrics, hooks, the kill switch and the other per-entry features
+// don't apply to it. The fields given to its methods are still processed, redacted or
+// pseudonymized for instance, as configured when the scope was frozen, which costs a pass
+// over them per entry when such processing is configured.
+type FrozenScope struct {
+	level zapcore.Level
+	name  string
+
+	// the field processors applied to the fields of each entry
+	processors []fieldProcessor
+
+	// either encodes with the fields of the scope, and writes to ws
+	enc zapcore.Encoder
+	ws  zapcore.WriteSyncer
+
+	// or hands the fields of the scope along with those of the entry to the emit function
+	emit   EmitFunc
+	fields []zapcore.Field
+}
+
+// Freeze returns an immutable snapshot of the scope. The fields of the scope, along with the
+// static fields and those added by AddFields, are processed and encoded once and for all.
+func (s *Scope) Freeze() *FrozenScope {
+	f := &FrozenScope{
+		level:      levelToZap[s.GetOutputLevel()],
+		name:       s.nameToEmit,
+		processors: fieldProcessors.Load().([]fieldProcessor),
+	}
+
+	fields := withStaticFields(s.withScopeFields(s.fields[:len(s.fields):len(s.fields)]))
+	if n := s.keyNormalizer.Load().(KeyNormalizer); n != nil {
+		fields = normalizeKeys(fields, n, "")
+	}
+	fields = processFields(fields)
+
+	if fn := s.emitFn.Load().(EmitFunc); fn != nil {
+		f.emit = fn
+		f.fields = fields[:len(fields):len(fields)]
+		return f
+	}
+
+	out, _ := currentOutputs.Load().(*outputs)
+	if out == nil {
+		return f
+	}
+
+	format := s.GetFormat()
+	if format == DefaultFormat {
+		format = out.format
+	}
+
+	f.enc = out.encoders[format].Clone()
+	for _, field := range fields {
+		field.AddTo(f.enc)
+	}
+
+	f.ws = s.GetOutput()
+	if f.ws != nil {
+		f.ws = out.scopeOutput(f.ws)
+	} else {
+		f.ws = out.sink
+	}
+
+	return f
+}
+
+// Enabled returns whether the entries of the given level are output.
+func (f *FrozenScope) Enabled(l Level) bool {
+	return levelToZap[l] >= f.level && l != NoneLevel
+}
+
+// Error outputs a message at error level.
+func (f *FrozenScope) Error(msg string, fields ...zapcore.Field) {
+	f.write(zapcore.ErrorLevel, msg, fields)
+}
+
+// Warn outputs a message at warn level.
+func (f *FrozenScope) Warn(msg string, fields ...zapcore.Field) {
+	f.write(zapcore.WarnLevel, msg, fields)
+}
+
+// Info outputs a message at info level.
+func (f *FrozenScope) Info(msg string, fields ...zapcore.Field) {
+	f.write(zapcore.InfoLevel, msg, fields)
+}
+
+// Debug outputs a message at debug level.
+func (f *FrozenScope) Debug(msg string, fields ...zapcore.Field) {
+	f.write(zapcore.DebugLevel, msg, fields)
+}
+
+func (f *FrozenScope) write(level zapcore.Level, msg string, fields []zapcore.Field) {
+	if level < f.level {
+		return
+	}
+
+	e := zapcore.Entry{
+		Message:    msg,
+		Level:      level,
+		Time:       now(),
+		LoggerName: f.name,
+	}
+
+	for _, p := range f.processors {
+		fields = p(fields)
+	}
+
+	var err error
+	if f.emit != nil {
+		if len(f.fields) > 0 {
+			fields = append(f.fields, fields...)
+		}
+		err = f.emit(e, fields)
+	} else if f.ws != nil {
+		err = writeEntry(f.enc, f.ws, e, fields)
+	}
+
+	if err != nil {
+		if es := errorSink.Load().(zapcore.WriteSyncer); es != nil {
+			_, _ = fmt.Fprintf(es, "%v log write error: %v\n", time.Now(), err)
+			_ = es.Sync()
+		}
+	}
+}
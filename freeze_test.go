@@ -0,0 +1,99 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type bufferSyncer struct {
+	bytes.Buffer
+}
+
+func (b *bufferSyncer) Sync() error { return nil }
+
+func TestFreeze(t *testing.T) {
+	s := RegisterScope("TestFreeze", "", 0)
+	buf := &bufferSyncer{}
+	s.SetOutput(buf)
+	s.SetFormat(JSONFormat)
+	defer s.SetOutput(nil)
+	defer s.SetFormat(DefaultFormat)
+
+	f := s.WithContext(ContextWithFields(context.Background(), zap.String("k", "v"))).Freeze()
+
+	// the snapshot isn't affected by later changes
+	s.SetOutputLevel(DebugLevel)
+	defer s.SetOutputLevel(InfoLevel)
+
+	if f.Enabled(DebugLevel) || !f.Enabled(InfoLevel) || f.Enabled(NoneLevel) {
+		t.Errorf("Got debug %v, info %v, expected the info level", f.Enabled(DebugLevel), f.Enabled(InfoLevel))
+	}
+
+	f.Debug("hidden")
+	f.Info("hello", zap.Int("n", 1))
+
+	out := buf.String()
+	if strings.Contains(out, "hidden") || !strings.Contains(out, `"scope":"TestFreeze","msg":"hello","k":"v","n":1}`) {
+		t.Errorf("Got %v, expected a single entry with the fields of the scope", out)
+	}
+}
+
+func TestFreezeWithEmit(t *testing.T) {
+	var fields [][]zapcore.Field
+	s := NewWithEmit("TestFreezeWithEmit", "", 0, func(_ zapcore.Entry, f []zapcore.Field) error {
+		fields = append(fields, f)
+		return nil
+	})
+
+	f := s.WithContext(ContextWithFields(context.Background(), zap.String("k", "v"))).Freeze()
+	f.Warn("a", zap.Int("n", 1))
+	f.Warn("b", zap.Int("n", 2))
+
+	if len(fields) != 2 || len(fields[0]) != 2 || fields[0][1].Integer != 1 || fields[1][1].Integer != 2 {
+		t.Errorf("Got %v, expected the fields of the scope followed by those of the entries", fields)
+	}
+}
+
+func TestFreezeProcessesFields(t *testing.T) {
+	s := RegisterScope("TestFreezeProcessesFields", "", 0)
+	buf := &bufferSyncer{}
+	s.SetOutput(buf)
+	s.SetFormat(JSONFormat)
+	defer s.SetOutput(nil)
+	defer s.SetFormat(DefaultFormat)
+
+	o := DefaultOptions()
+	o.RedactionRules = []RedactionRule{{Pattern: regexp.MustCompile("secret")}}
+	_ = Configure(o)
+	f := s.Freeze()
+
+	// the processing configured when frozen still applies
+	_ = Configure(DefaultOptions())
+
+	f.Info("hello", zap.String("token", "a secret"), zap.Int("n", 1))
+
+	out := buf.String()
+	if strings.Contains(out, "secret") || !strings.Contains(out, `"token":`) || !strings.Contains(out, `"n":1`) {
+		t.Errorf("Got %v, expected the fields of the entry to be redacted", out)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// GoroutineKey is the key of the field holding the goroutine identifier, when
+	// Options.GoroutineID is set.
+	GoroutineKey = "goroutine"
+	// WorkerKey is the key of the field added by ContextWithWorkerID and GoWorkers.
+	WorkerKey = "worker_id"
+	// TaskKey is the key of the field added by Go and GoWorkers.
+	TaskKey = "task"
+)
+
+// set by the Configure method, 1 when entries carry the identifier of their goroutine
+var logGoroutineID int32
+
+// ContextWithWorkerID returns a copy of the context carrying the identifier of a worker,
+// for example the index of a worker in a pool. Scopes obtained through WithContext add it
+// to every entry, which helps following the interleaving of concurrent work. Unlike
+// goroutine identifiers, it costs nothing when logging.
+func ContextWithWorkerID(ctx context.Context, id string) context.Context {
+	return ContextWithFields(ctx, zap.String(WorkerKey, id))
+}
+
+// Go runs fn in a new goroutine, handing it a scope derived from s which adds the name of the
+// task to every entry, under TaskKey. This makes the entries of concurrent work attributable
+// without each goroutine deriving its own scope. The returned function waits for fn to return.
+func Go(s *Scope, task string, fn func(s *Scope)) (wait func()) {
+	return GoWorkers(s, task, 1, func(s *Scope, _ int) { fn(s) })
+}
+
+// GoWorkers runs n workers in new goroutines, handing each of them its index and a scope
+// derived from s which adds the name of the task, under TaskKey, and the index of the worker,
+// under WorkerKey, to every entry. A single worker isn't given the WorkerKey field. The
+// returned function waits for all the workers to return.
+func GoWorkers(s *Scope, task string, n int, fn func(s *Scope, worker int)) (wait func()) {
+	var wg sync.WaitGroup
+	wg.Add(n)
+
+	for i := 0; i < n; i++ {
+		fields := []zapcore.Field{zap.String(TaskKey, task)}
+		if n > 1 {
+			fields = append(fields, zap.String(WorkerKey, strconv.Itoa(i)))
+		}
+
+		worker := s.copy()
+		worker.fields = append(s.fields[:len(s.fields):len(s.fields)], fields...)
+
+		go func(i int) {
+			defer wg.Done()
+			fn(worker, i)
+		}(i)
+	}
+
+	return wg.Wait
+}
@@ -0,0 +1,104 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestGoroutineID(t *testing.T) {
+	var mu sync.Mutex
+	var ids []uint64
+	s := NewWithEmit("TestGoroutineID", "", 0, func(_ zapcore.Entry, fields []zapcore.Field) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		for _, f := range fields {
+			if f.Key == GoroutineKey {
+				ids = append(ids, uint64(f.Integer))
+			}
+		}
+		return nil
+	})
+
+	s.Info("disabled")
+	if len(ids) != 0 {
+		t.Errorf("Got %v, expected no goroutine identifier by default", ids)
+	}
+
+	o := DefaultOptions()
+	o.GoroutineID = true
+	if err := Configure(o); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = Configure(DefaultOptions()) }()
+
+	s.Info("here")
+	s.Info("here again")
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.Info("elsewhere")
+	}()
+	wg.Wait()
+
+	if len(ids) != 3 || ids[0] == 0 || ids[0] != ids[1] || ids[0] == ids[2] {
+		t.Errorf("Got %v, expected the same identifier twice, then another one", ids)
+	}
+}
+
+func TestContextWithWorkerID(t *testing.T) {
+	fields := FieldsFromContext(ContextWithWorkerID(context.Background(), "3"))
+	if len(fields) != 1 || fields[0].Key != WorkerKey || fields[0].String != "3" {
+		t.Errorf("Got %v, expected the worker identifier", fields)
+	}
+}
+
+func TestGoWorkers(t *testing.T) {
+	var mu sync.Mutex
+	var labels []string
+	s := NewWithEmit("TestGoWorkers", "", 0, func(_ zapcore.Entry, fields []zapcore.Field) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		label := ""
+		for _, f := range fields {
+			label += f.Key + "=" + f.String + " "
+		}
+		labels = append(labels, label)
+		return nil
+	})
+
+	Go(s, "sync", func(s *Scope) { s.Info("syncing") })()
+	GoWorkers(s, "fetch", 2, func(s *Scope, worker int) { s.Info("fetching") })()
+
+	sort.Strings(labels)
+	expected := []string{"task=fetch worker_id=0 ", "task=fetch worker_id=1 ", "task=sync "}
+	if len(labels) != len(expected) {
+		t.Fatalf("Got %v, expected %v", labels, expected)
+	}
+	for i := range expected {
+		if labels[i] != expected[i] {
+			t.Errorf("Got %v, expected %v", labels[i], expected[i])
+		}
+	}
+}
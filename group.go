@@ -0,0 +1,67 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"fmt"
+	"sync"
+)
+
+var groups = struct {
+	sync.Mutex
+	members map[string][]string
+}{members: make(map[string][]string)}
+
+// DefineGroup defines a named group of scopes, whose levels can then be set at once with
+// SetGroupLevel, or by using the name of the group in place of a scope name in the
+// --log-output-level and --log-stacktrace-level options. Scopes don't need to be
+// registered yet, and defining a group again replaces its members.
+func DefineGroup(name string, scopeNames ...string) {
+	members := append([]string(nil), scopeNames...)
+
+	groups.Lock()
+	defer groups.Unlock()
+
+	groups.members[name] = members
+}
+
+// GroupScopes returns the names of the scopes of a group, or nil if the group isn't defined.
+func GroupScopes(name string) []string {
+	groups.Lock()
+	defer groups.Unlock()
+
+	members, ok := groups.members[name]
+	if !ok {
+		return nil
+	}
+
+	return append([]string(nil), members...)
+}
+
+// SetGroupLevel sets the output level of the registered scopes of a group.
+func SetGroupLevel(name string, l Level) error {
+	members := GroupScopes(name)
+	if members == nil {
+		return fmt.Errorf("unknown scope group '%s'", name)
+	}
+
+	for _, n := range members {
+		if s := defaultRegistry.lookup(n); s != nil {
+			s.SetOutputLevelFrom(l, LevelSourceGroup, "")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"testing"
+)
+
+func TestGroups(t *testing.T) {
+	cache := RegisterScope("TestGroupsCache", "", 0)
+	proxy := RegisterScope("TestGroupsProxy", "", 0)
+	other := RegisterScope("TestGroupsOther", "", 0)
+	defer func() {
+		for _, s := range []*Scope{cache, proxy, other} {
+			s.SetOutputLevel(InfoLevel)
+			s.SetStackTraceLevel(NoneLevel)
+		}
+	}()
+
+	DefineGroup("test-data-plane", "TestGroupsCache", "TestGroupsProxy", "TestGroupsRouter")
+
+	if got := GroupScopes("test-data-plane"); len(got) != 3 || got[0] != "TestGroupsCache" {
+		t.Errorf("Got %v, expected the scopes of the group", got)
+	}
+
+	if got := GroupScopes("test-unknown"); got != nil {
+		t.Errorf("Got %v, expected nil", got)
+	}
+
+	if err := SetGroupLevel("test-data-plane", DebugLevel); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	if cache.GetOutputLevel() != DebugLevel || proxy.GetOutputLevel() != DebugLevel {
+		t.Errorf("Got %v and %v, expected %v", cache.GetOutputLevel(), proxy.GetOutputLevel(), DebugLevel)
+	}
+
+	if other.GetOutputLevel() != InfoLevel {
+		t.Errorf("Got %v, expected %v", other.GetOutputLevel(), InfoLevel)
+	}
+
+	if err := SetGroupLevel("test-unknown", DebugLevel); err == nil {
+		t.Error("Got success, expected an error for an unknown group")
+	}
+
+	o := DefaultOptions()
+	o.SetOutputLevel("test-data-plane", ErrorLevel)
+	o.SetStackTraceLevel("test-data-plane", WarnLevel)
+	if err := Configure(o); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	if cache.GetOutputLevel() != ErrorLevel || proxy.GetStackTraceLevel() != WarnLevel {
+		t.Errorf("Got %v and %v, expected %v and %v", cache.GetOutputLevel(), proxy.GetStackTraceLevel(), ErrorLevel, WarnLevel)
+	}
+}
+
+func TestSetGroupLevelUnregistered(t *testing.T) {
+	o := DefaultOptions()
+	o.AutoRegisterScopes = true
+	if err := Configure(o); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = Configure(DefaultOptions()) }()
+
+	DefineGroup("test-unregistered", "TestSetGroupLevelUnregistered")
+	if err := SetGroupLevel("test-unregistered", DebugLevel); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	if _, ok := Scopes()["TestSetGroupLevelUnregistered"]; ok {
+		t.Error("Got the member registered, expected it to be left alone")
+	}
+
+	if n := ScopeMisses()["TestSetGroupLevelUnregistered"]; n != 0 {
+		t.Errorf("Got %d misses, expected the lookup not to be counted", n)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"compress/gzip"
+	"io"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// GzipWriter compresses log entries on their way to an underlying writer, for archival
+// sinks where the volume of raw text dominates storage costs.
+//
+// Entries are never split: Flush and Sync only ever cut the compressed stream between
+// two entries, so a reader of the stream always sees whole entries. Rotate and Close
+// end the stream with a proper gzip footer, making each rotated file a valid archive.
+// Since concatenated gzip streams form a valid gzip file, appending to an existing
+// archive is safe as well, provided the previous writer was closed.
+//
+//	f, _ := os.OpenFile("archive.log.gz", os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+//	w, _ := log.NewGzipWriter(f, gzip.DefaultCompression)
+//	scope.SetOutput(w)
+//	defer w.Close()
+type GzipWriter struct {
+	mu  sync.Mutex
+	out io.Writer
+	zw  *gzip.Writer
+}
+
+// NewGzipWriter returns a writer compressing entries with the given level, such as
+// gzip.DefaultCompression or gzip.BestSpeed, on their way to the given writer.
+func NewGzipWriter(w io.Writer, level int) (*GzipWriter, error) {
+	zw, err := gzip.NewWriterLevel(w, level)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GzipWriter{out: w, zw: zw}, nil
+}
+
+// Write compresses an entry.
+func (w *GzipWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.zw.Write(p)
+}
+
+// Flush writes out the entries compressed so far, without ending the stream.
+func (w *GzipWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.zw.Flush()
+}
+
+// Sync flushes the compressed entries and then syncs the underlying writer, if it supports it.
+func (w *GzipWriter) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.zw.Flush(); err != nil {
+		return err
+	}
+
+	if s, ok := w.out.(zapcore.WriteSyncer); ok {
+		return s.Sync()
+	}
+
+	return nil
+}
+
+// Rotate ends the stream written to the current writer with a gzip footer, closes that
+// writer if it supports it, and continues with a new stream on the given writer.
+func (w *GzipWriter) Rotate(next io.Writer) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	err := w.finish()
+
+	w.out = next
+	w.zw.Reset(next)
+
+	return err
+}
+
+// Close ends the stream with a gzip footer and closes the underlying writer, if it supports it.
+func (w *GzipWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.finish()
+}
+
+// finish writes the footer and closes the underlying writer. Must be called with the lock held.
+func (w *GzipWriter) finish() error {
+	err := w.zw.Close()
+
+	if c, ok := w.out.(io.Closer); ok {
+		if e := c.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"testing"
+)
+
+func gunzip(t *testing.T, b []byte, multistream bool) string {
+	t.Helper()
+
+	zr, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("Got error '%v', expected a gzip stream", err)
+	}
+	zr.Multistream(multistream)
+
+	out, err := ioutil.ReadAll(zr)
+	if err != nil && multistream {
+		t.Fatalf("Got error '%v', expected a complete gzip stream", err)
+	}
+
+	return string(out)
+}
+
+func TestGzipWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewGzipWriter(&buf, gzip.BestSpeed)
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	_, _ = w.Write([]byte("first\n"))
+	_, _ = w.Write([]byte("second\n"))
+
+	// once flushed, whole entries can be read even though the stream isn't over
+	if err := w.Sync(); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	if got := gunzip(t, buf.Bytes(), false); got != "first\nsecond\n" {
+		t.Errorf("Got %q, expected both entries", got)
+	}
+
+	var next bytes.Buffer
+	if err := w.Rotate(&next); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	_, _ = w.Write([]byte("third\n"))
+	_ = w.Close()
+
+	if got := gunzip(t, buf.Bytes(), true); got != "first\nsecond\n" {
+		t.Errorf("Got %q, expected the entries written before the rotation", got)
+	}
+
+	if got := gunzip(t, next.Bytes(), true); got != "third\n" {
+		t.Errorf("Got %q, expected the entry written after the rotation", got)
+	}
+}
+
+func TestGzipWriterInvalidLevel(t *testing.T) {
+	if _, err := NewGzipWriter(&bytes.Buffer{}, 42); err == nil {
+		t.Error("Got success, expected an error")
+	}
+}
+
+func TestGzipWriterAppend(t *testing.T) {
+	var buf bytes.Buffer
+	for _, msg := range []string{"one\n", "two\n"} {
+		w, _ := NewGzipWriter(&buf, gzip.DefaultCompression)
+		_, _ = w.Write([]byte(msg))
+		_ = w.Close()
+	}
+
+	if got := gunzip(t, buf.Bytes(), true); got != "one\ntwo\n" {
+		t.Errorf("Got %q, expected the entries of both streams", got)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Hook is handed the entries emitted by all scopes, along with their fields, in addition
+// to them being written to the outputs. It lets integrations such as error trackers see
+// the entries without requiring a second call at every logging site.
+type Hook func(zapcore.Entry, []zapcore.Field)
+
+type registeredHook struct {
+	id    int
+	level zapcore.Level
+	fn    Hook
+}
+
+var hooks struct {
+	sync.Mutex
+	next       int
+	registered []registeredHook
+}
+
+// holds the []registeredHook called by emit, replaced whenever hooks are added or removed
+var activeHooks atomic.Value
+
+// AddHook registers a hook called with every entry emitted at the given level or above,
+// Fatal entries being above ErrorLevel. Hooks see the fields once processed, redacted
+// values included. The returned function removes the hook.
+//
+// Hooks are called synchronously by the goroutine logging the entry, so they must return
+// quickly, and must not log through the scope they're handed entries from.
+func AddHook(l Level, fn Hook) (remove func()) {
+	hooks.Lock()
+	defer hooks.Unlock()
+
+	id := hooks.next
+	hooks.next++
+	hooks.registered = append(hooks.registered, registeredHook{id: id, level: levelToZap[l], fn: fn})
+	activeHooks.Store(append([]registeredHook(nil), hooks.registered...))
+
+	return func() {
+		hooks.Lock()
+		defer hooks.Unlock()
+
+		for i, h := range hooks.registered {
+			if h.id == id {
+				hooks.registered = append(hooks.registered[:i:i], hooks.registered[i+1:]...)
+				break
+			}
+		}
+		activeHooks.Store(append([]registeredHook(nil), hooks.registered...))
+	}
+}
+
+// runHooks hands an entry to the hooks registered for its level.
+func runHooks(e zapcore.Entry, fields []zapcore.Field) {
+	active, _ := activeHooks.Load().([]registeredHook)
+	for _, h := range active {
+		if e.Level >= h.level {
+			h.fn(e, fields)
+		}
+	}
+}
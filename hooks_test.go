@@ -0,0 +1,56 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestHooks(t *testing.T) {
+	s := NewWithEmit("TestHooks", "", 0, func(zapcore.Entry, []zapcore.Field) error { return nil })
+	s.SetOutputLevel(DebugLevel)
+	defer s.SetOutputLevel(InfoLevel)
+
+	var errors, all []string
+	removeErrors := AddHook(ErrorLevel, func(e zapcore.Entry, fields []zapcore.Field) {
+		if e.LoggerName == "TestHooks" {
+			errors = append(errors, e.Message)
+		}
+	})
+	removeAll := AddHook(DebugLevel, func(e zapcore.Entry, fields []zapcore.Field) {
+		if e.LoggerName == "TestHooks" && len(fields) == 1 && fields[0].Key == "k" {
+			all = append(all, e.Message)
+		}
+	})
+
+	s.Debug("debug", zap.String("k", "v"))
+	s.Error("error", zap.String("k", "v"))
+
+	removeErrors()
+	s.Error("removed", zap.String("k", "v"))
+	removeAll()
+	s.Error("none", zap.String("k", "v"))
+
+	if len(errors) != 1 || errors[0] != "error" {
+		t.Errorf("Got %v, expected [error]", errors)
+	}
+
+	if len(all) != 3 || all[2] != "removed" {
+		t.Errorf("Got %v, expected [debug error removed]", all)
+	}
+}
@@ -0,0 +1,303 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"time"
+)
+
+const (
+	defaultHTTPTimeout       = 10 * time.Second
+	defaultHTTPSpillMaxBytes = 64 * 1024 * 1024
+)
+
+// HTTPOptions controls the behavior of an HTTPWriter.
+type HTTPOptions struct {
+	// Headers are added to every request, typically to authenticate with the event store,
+	// e.g. X-Honeycomb-Team for Honeycomb or Authorization for OpenObserve.
+	Headers http.Header
+
+	// Batch controls how many entries are sent per request, and how long they can wait.
+	// The batch defaults are used when nil.
+	Batch *BatchOptions
+
+	// Compress gzips the request bodies.
+	Compress bool
+
+	// EntryKey, if set, makes each entry be sent as an object holding the entry under
+	// that key, as some event stores expect, e.g. "data" for Honeycomb. The entries are
+	// sent as they are otherwise.
+	EntryKey string
+
+	// Timeout is the maximum amount of time a request may take.
+	Timeout time.Duration
+
+	// Client sends the requests. A client with the given Timeout is used when nil.
+	Client *http.Client
+
+	// SpillDir, if set, is a directory where the batches which can't be delivered because
+	// the endpoint is unavailable are spilled, up to SpillMaxBytes bytes, the oldest being
+	// dropped first. Spilled batches survive a restart of the process, and are delivered in
+	// order before the next batches once the endpoint is available again.
+	SpillDir string
+
+	// SpillMaxBytes is the maximum size of the batches spilled to SpillDir, 64MiB if zero.
+	SpillMaxBytes int
+}
+
+// DefaultHTTPOptions returns a new set of HTTP options, initialized to the defaults
+func DefaultHTTPOptions() *HTTPOptions {
+	return &HTTPOptions{
+		Batch:         DefaultBatchOptions(),
+		Timeout:       defaultHTTPTimeout,
+		SpillMaxBytes: defaultHTTPSpillMaxBytes,
+	}
+}
+
+// HTTPWriter ships batches of JSON entries to an HTTP endpoint, each batch being POSTed
+// as a JSON array. This suits the batch APIs of event stores such as Honeycomb or
+// OpenObserve, without requiring a sink per vendor. The entries must be encoded as
+// JSON, see Options.JSONEncoding and Scope.SetFormat.
+//
+// A batch which can't be delivered is discarded, unless it can be spilled to disk, and the
+// error is returned by the write which triggered it, or by Sync.
+type HTTPWriter struct {
+	*BatchWriter
+}
+
+type httpPoster struct {
+	url     string
+	options HTTPOptions
+	client  *http.Client
+	disk    *spillFile
+}
+
+// httpStatusError reports a response other than a success.
+type httpStatusError struct {
+	url    string
+	status string
+	code   int
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("unable to post log entries to %s: %s", e.url, e.status)
+}
+
+// retryable returns whether a batch which failed to be delivered can succeed later.
+func retryable(err error) bool {
+	var se *httpStatusError
+	if !errors.As(err, &se) {
+		return true
+	}
+
+	return se.code >= 500 || se.code == http.StatusTooManyRequests || se.code == http.StatusRequestTimeout
+}
+
+// NewHTTPWriter returns a writer posting batches of entries to the given URL. Close must
+// be called to deliver the pending entries and release the background flusher.
+func NewHTTPWriter(endpoint string, options *HTTPOptions) (*HTTPWriter, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid HTTP endpoint '%s': %v", endpoint, err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported scheme '%s', expecting http or https", u.Scheme)
+	}
+
+	if options == nil {
+		options = DefaultHTTPOptions()
+	}
+
+	p := &httpPoster{
+		url:     u.String(),
+		options: *options,
+		client:  options.Client,
+	}
+
+	if p.client == nil {
+		p.client = &http.Client{Timeout: options.Timeout}
+	}
+
+	if options.SpillDir != "" {
+		maxBytes := options.SpillMaxBytes
+		if maxBytes <= 0 {
+			maxBytes = defaultHTTPSpillMaxBytes
+		}
+
+		disk, err := openSpillFile(filepath.Join(options.SpillDir, spillFileName("http", p.url)), maxBytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open the spill file of '%s': %v", p.url, err)
+		}
+		p.disk = disk
+	}
+
+	return &HTTPWriter{NewBatchWriter(p, options.Batch)}, nil
+}
+
+// Write posts a batch of newline-terminated entries, after the spilled ones, if any.
+func (p *httpPoster) Write(batch []byte) (int, error) {
+	if p.disk == nil {
+		if err := p.post(batch); err != nil {
+			return 0, err
+		}
+		return len(batch), nil
+	}
+
+	err := p.replay()
+	if err == nil {
+		err = p.post(batch)
+	}
+
+	if err != nil {
+		if !retryable(err) {
+			return 0, err
+		}
+
+		dropped, spillErr := p.disk.push(batch)
+		if dropped > 0 {
+			recordDropped(DroppedByHTTPSink, uint64(dropped))
+		}
+		if spillErr != nil {
+			return 0, spillErr
+		}
+	}
+
+	return len(batch), nil
+}
+
+// Close closes the spill file, if any, keeping the batches not delivered yet.
+func (p *httpPoster) Close() error {
+	if p.disk == nil {
+		return nil
+	}
+
+	return p.disk.close()
+}
+
+// replay posts the spilled batches, in order.
+func (p *httpPoster) replay() error {
+	for {
+		batch, err := p.disk.peek()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := p.post(batch); err != nil && retryable(err) {
+			return err
+		}
+
+		// delivered, or never will be
+		if err := p.disk.pop(); err != nil {
+			return err
+		}
+	}
+}
+
+// post sends a batch of newline-terminated entries.
+func (p *httpPoster) post(batch []byte) error {
+	body, err := p.encode(batch)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	for k, v := range p.options.Headers {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.options.Compress {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &httpStatusError{url: p.url, status: resp.Status, code: resp.StatusCode}
+	}
+
+	return nil
+}
+
+// encode turns a batch of entries into the body of a request.
+func (p *httpPoster) encode(batch []byte) ([]byte, error) {
+	var key []byte
+	if p.options.EntryKey != "" {
+		key, _ = json.Marshal(p.options.EntryKey)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	n := 0
+	for _, entry := range bytes.Split(batch, []byte{'\n'}) {
+		entry = bytes.TrimSpace(entry)
+		if len(entry) == 0 {
+			continue
+		}
+
+		if n > 0 {
+			buf.WriteByte(',')
+		}
+		n++
+
+		if key != nil {
+			buf.WriteByte('{')
+			buf.Write(key)
+			buf.WriteByte(':')
+			buf.Write(entry)
+			buf.WriteByte('}')
+		} else {
+			buf.Write(entry)
+		}
+	}
+	buf.WriteByte(']')
+
+	if !p.options.Compress {
+		return buf.Bytes(), nil
+	}
+
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	if _, err := zw.Write(buf.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return compressed.Bytes(), nil
+}
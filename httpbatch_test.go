@@ -0,0 +1,151 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestHTTPWriter(t *testing.T) {
+	cases := []struct {
+		options  HTTPOptions
+		expected string
+	}{
+		{HTTPOptions{}, `[{"msg":"a"},{"msg":"b"}]`},
+		{HTTPOptions{EntryKey: "data"}, `[{"data":{"msg":"a"}},{"data":{"msg":"b"}}]`},
+		{HTTPOptions{Compress: true}, `[{"msg":"a"},{"msg":"b"}]`},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var bodies []string
+			var headers []http.Header
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				body := req.Body
+				if req.Header.Get("Content-Encoding") == "gzip" {
+					zr, err := gzip.NewReader(req.Body)
+					if err != nil {
+						t.Errorf("Got error '%v', expected a gzipped body", err)
+						return
+					}
+					body = zr
+				}
+				b, _ := ioutil.ReadAll(body)
+				bodies = append(bodies, string(b))
+				headers = append(headers, req.Header)
+			}))
+			defer srv.Close()
+
+			c.options.Headers = http.Header{"X-Honeycomb-Team": []string{"key"}}
+			w, err := NewHTTPWriter(srv.URL, &c.options)
+			if err != nil {
+				t.Fatalf("Got error '%v', expected success", err)
+			}
+
+			_, _ = w.Write([]byte("{\"msg\":\"a\"}\n"))
+			_, _ = w.Write([]byte("{\"msg\":\"b\"}\n"))
+			if err := w.Close(); err != nil {
+				t.Fatalf("Got error '%v', expected success", err)
+			}
+
+			if len(bodies) != 1 || bodies[0] != c.expected {
+				t.Errorf("Got %v, expected %v", bodies, c.expected)
+			}
+			if len(headers) == 1 && (headers[0].Get("X-Honeycomb-Team") != "key" || headers[0].Get("Content-Type") != "application/json") {
+				t.Errorf("Got %v, expected the configured headers", headers[0])
+			}
+		})
+	}
+}
+
+func TestHTTPWriterErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	w, err := NewHTTPWriter(srv.URL, &HTTPOptions{Batch: &BatchOptions{MaxEntries: 1}})
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = w.Close() }()
+
+	if _, err := w.Write([]byte("{}\n")); err == nil {
+		t.Errorf("Got success, expected an error")
+	}
+
+	if _, err := NewHTTPWriter("ftp://example.com", nil); err == nil {
+		t.Errorf("Got success, expected an unsupported scheme error")
+	}
+}
+
+func TestHTTPWriterSpill(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		down     = true
+		received []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if down {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, _ := ioutil.ReadAll(req.Body)
+		received = append(received, string(body))
+	}))
+	defer srv.Close()
+
+	w, err := NewHTTPWriter(srv.URL, &HTTPOptions{
+		Batch:    &BatchOptions{MaxEntries: 1},
+		SpillDir: t.TempDir(),
+	})
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = w.Close() }()
+
+	for _, e := range []string{`{"n":1}`, `{"n":2}`} {
+		if _, err := w.Write([]byte(e + "\n")); err != nil {
+			t.Errorf("Got error '%v', expected the entry to be spilled", err)
+		}
+	}
+
+	mu.Lock()
+	down = false
+	mu.Unlock()
+
+	if _, err := w.Write([]byte(`{"n":3}` + "\n")); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	expected := []string{`[{"n":1}]`, `[{"n":2}]`, `[{"n":3}]`}
+	if !reflect.DeepEqual(received, expected) {
+		t.Errorf("Got %v, expected %v", received, expected)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// LevelsEnvVar is the environment variable through which child processes inherit the
+// output levels of their parent, see LevelsEnv and Options.InheritLevels.
+const LevelsEnvVar = "LOG_OUTPUT_LEVELS"
+
+// LevelsEnv returns the current output levels of the scopes registered in the process,
+// as an environment variable in the form used by exec.Cmd, so that child processes inherit
+// the verbosity chosen by the operator:
+//
+//	cmd := exec.Command("helper")
+//	cmd.Env = append(os.Environ(), log.LevelsEnv())
+func LevelsEnv() string {
+	return defaultRegistry.LevelsEnv()
+}
+
+// LevelsEnv returns the current output levels of the scopes of the registry, as an
+// environment variable in the form used by exec.Cmd, see the package-level LevelsEnv.
+func (r *Registry) LevelsEnv() string {
+	all := r.Scopes()
+
+	levels := make([]string, 0, len(all))
+	for name, s := range all {
+		levels = append(levels, name+":"+levelToString[s.GetOutputLevel()])
+	}
+	sort.Strings(levels)
+
+	return LevelsEnvVar + "=" + strings.Join(levels, ",")
+}
+
+// InheritLevels sets the output levels of the options to those found in LevelsEnvVar, as
+// set by the parent process with LevelsEnv. Only the levels of the scopes already registered
+// are inherited, so that scopes the child doesn't have aren't reported as unknown by
+// Configure. Call it before AttachFlags, so that the flags take precedence:
+//
+//	o := log.DefaultOptions()
+//	if err := o.InheritLevels(); err != nil {
+//		// handle the error
+//	}
+//	o.AttachFlags(cmd)
+func (o *Options) InheritLevels() error {
+	value := os.Getenv(LevelsEnvVar)
+	if value == "" {
+		return nil
+	}
+
+	for _, sl := range strings.Split(value, ",") {
+		scope, level, err := convertScopedLevel(sl)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %v", LevelsEnvVar, err)
+		}
+
+		if defaultRegistry.lookup(scope) != nil {
+			o.SetOutputLevel(scope, level)
+		}
+	}
+
+	return nil
+}
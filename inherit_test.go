@@ -0,0 +1,69 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLevelsEnv(t *testing.T) {
+	r := NewRegistry()
+	r.RegisterScope("b", "", 0).SetOutputLevel(DebugLevel)
+	r.RegisterScope("a", "", 0).SetOutputLevel(ErrorLevel)
+
+	if got, expected := r.LevelsEnv(), LevelsEnvVar+"=a:error,b:debug"; got != expected {
+		t.Errorf("Got %v, expected %v", got, expected)
+	}
+
+	s := RegisterScope("TestLevelsEnv", "", 0)
+	s.SetOutputLevel(WarnLevel)
+	defer s.SetOutputLevel(InfoLevel)
+
+	if env := LevelsEnv(); !strings.Contains(env, "TestLevelsEnv:warn") {
+		t.Errorf("Got %v, expected the level of the registered scope", env)
+	}
+}
+
+func TestInheritLevels(t *testing.T) {
+	RegisterScope("TestInheritLevels", "", 0)
+	defer func() { _ = os.Unsetenv(LevelsEnvVar) }()
+
+	o := DefaultOptions()
+	if err := o.InheritLevels(); err != nil {
+		t.Errorf("Got error '%v', expected success without the variable", err)
+	}
+
+	_ = os.Setenv(LevelsEnvVar, "TestInheritLevels:debug,TestInheritLevelsUnknown:debug,default:warn")
+	if err := o.InheritLevels(); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	for scope, expected := range map[string]Level{"TestInheritLevels": DebugLevel, DefaultScopeName: WarnLevel} {
+		if l, err := o.GetOutputLevel(scope); err != nil || l != expected {
+			t.Errorf("Got %v, '%v', expected %v for %s", l, err, expected, scope)
+		}
+	}
+
+	if _, err := o.GetOutputLevel("TestInheritLevelsUnknown"); err == nil {
+		t.Error("Got success, expected the unknown scope to be skipped")
+	}
+
+	_ = os.Setenv(LevelsEnvVar, "TestInheritLevels:loud")
+	if err := o.InheritLevels(); err == nil {
+		t.Error("Got success, expected an error for an invalid level")
+	}
+}
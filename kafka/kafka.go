@@ -0,0 +1,103 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kafka provides a log sink that publishes entries to a Kafka topic.
+//
+// The package does not depend on any particular Kafka client. Applications adapt
+// the client they already use to the Producer interface and register it:
+//
+//	if err := kafka.Register(myProducer); err != nil {
+//		// handle the error
+//	}
+//
+//	options := log.DefaultOptions()
+//	options.JSONEncoding = true
+//	options.OutputPaths = append(options.OutputPaths, "kafka://app-logs")
+//	_ = log.Configure(options)
+//
+// Each log entry is published as a single message. Entries are published as
+// encoded by the log package, so JSONEncoding should be enabled when the topic
+// is consumed by a log pipeline.
+package kafka
+
+import (
+	"bytes"
+	"errors"
+	"net/url"
+
+	"go.uber.org/zap"
+)
+
+// Scheme is the URL scheme used to target a Kafka topic, as in kafka://<topic>.
+const Scheme = "kafka"
+
+// Producer publishes messages to Kafka.
+type Producer interface {
+	// Produce publishes a message to the given topic. The producer takes ownership
+	// of the value and may deliver it asynchronously.
+	Produce(topic string, value []byte) error
+
+	// Flush blocks until all the messages produced so far have been delivered.
+	Flush() error
+}
+
+// Sink writes log entries to a Kafka topic.
+type Sink struct {
+	producer Producer
+	topic    string
+}
+
+// NewSink returns a sink that publishes every entry to the given topic.
+func NewSink(p Producer, topic string) *Sink {
+	return &Sink{
+		producer: p,
+		topic:    topic,
+	}
+}
+
+// Register makes kafka://<topic> URLs usable as log output paths, publishing
+// through the given producer. It can only be called once per process.
+func Register(p Producer) error {
+	return zap.RegisterSink(Scheme, func(u *url.URL) (zap.Sink, error) {
+		if u.Host == "" {
+			return nil, errors.New("missing topic in kafka URL, expecting kafka://<topic>")
+		}
+
+		return NewSink(p, u.Host), nil
+	})
+}
+
+// Write publishes a single encoded log entry.
+func (s *Sink) Write(p []byte) (int, error) {
+	// the log package reuses its buffers, so hand a private copy to the producer
+	msg := make([]byte, len(p))
+	copy(msg, p)
+
+	if err := s.producer.Produce(s.topic, bytes.TrimRight(msg, "\n")); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Sync waits for pending messages to be delivered.
+func (s *Sink) Sync() error {
+	return s.producer.Flush()
+}
+
+// Close flushes pending messages. The producer itself is owned by the caller
+// and is left open.
+func (s *Sink) Close() error {
+	return s.producer.Flush()
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+type testProducer struct {
+	topics   []string
+	messages []string
+	flushes  int
+	err      error
+}
+
+func (p *testProducer) Produce(topic string, value []byte) error {
+	if p.err != nil {
+		return p.err
+	}
+
+	p.topics = append(p.topics, topic)
+	p.messages = append(p.messages, string(value))
+	return nil
+}
+
+func (p *testProducer) Flush() error {
+	p.flushes++
+	return nil
+}
+
+func TestSink(t *testing.T) {
+	p := &testProducer{}
+	s := NewSink(p, "logs")
+
+	buf := []byte(`{"msg":"Hello"}` + "\n")
+	if n, err := s.Write(buf); err != nil || n != len(buf) {
+		t.Errorf("Got (%d, %v), expected (%d, nil)", n, err, len(buf))
+	}
+
+	// the sink must not retain the caller's buffer
+	buf[2] = 'X'
+
+	if len(p.messages) != 1 || p.messages[0] != `{"msg":"Hello"}` || p.topics[0] != "logs" {
+		t.Errorf("Got %v on %v, expected a single Hello message on logs", p.messages, p.topics)
+	}
+
+	if err := s.Sync(); err != nil || p.flushes != 1 {
+		t.Errorf("Got (%d, %v), expected a single successful flush", p.flushes, err)
+	}
+
+	p.err = errors.New("broker down")
+	if _, err := s.Write(buf); err == nil {
+		t.Error("Got success, expecting an error")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	p := &testProducer{}
+	if err := Register(p); err != nil {
+		t.Fatalf("Got err '%v', expecting success", err)
+	}
+
+	if _, _, err := zap.Open("kafka://"); err == nil {
+		t.Error("Got success for a URL without a topic, expecting an error")
+	}
+
+	ws, closer, err := zap.Open("kafka://app-logs")
+	if err != nil {
+		t.Fatalf("Got err '%v', expecting success", err)
+	}
+	defer closer()
+
+	_, _ = ws.Write([]byte("Hello\n"))
+	if len(p.topics) != 1 || p.topics[0] != "app-logs" {
+		t.Errorf("Got %v, expected a single message on app-logs", p.topics)
+	}
+}
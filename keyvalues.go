@@ -0,0 +1,179 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// KeyPolicy decides how the keys given to the key/value logging methods, such as Infow,
+// are rendered when they aren't strings. The policy applies to every output format.
+type KeyPolicy int
+
+const (
+	// StringifyKeys renders keys which aren't strings with fmt.Sprint.
+	StringifyKeys KeyPolicy = iota
+	// IndexKeys replaces keys which aren't strings with invalid_key_N, N being the
+	// position of the key in the list of keys and values.
+	IndexKeys
+)
+
+// MissingValuePolicy decides what the key/value logging methods do with a trailing key
+// given without a value. The policy applies to every output format.
+type MissingValuePolicy int
+
+const (
+	// PadMissing gives the key the (MISSING) placeholder value.
+	PadMissing MissingValuePolicy = iota
+	// DropMissing leaves the key out of the entry.
+	DropMissing
+	// ReportMissing pads the key like PadMissing, and additionally reports the mistake
+	// to the error output, which helps catching it during development.
+	ReportMissing
+)
+
+// KV separates the arguments of the printf-style methods, such as Infof, from trailing
+// keys and values turned into fields, as with Infow. It eases adding fields to existing
+// printf-style calls:
+//
+//	s.Infof("synced %d routes", n, log.KV, "cluster", name)
+var KV interface{} = kvSeparator{}
+
+type kvSeparator struct{}
+
+// missingValue is the value of a trailing key given without a value
+const missingValue = "(MISSING)"
+
+// SetKeyPolicy sets how the scope renders keys which aren't strings.
+func (s *Scope) SetKeyPolicy(p KeyPolicy) {
+	s.keyPolicy.Store(p)
+}
+
+// GetKeyPolicy returns how the scope renders keys which aren't strings.
+func (s *Scope) GetKeyPolicy() KeyPolicy {
+	return s.keyPolicy.Load().(KeyPolicy)
+}
+
+// SetMissingValuePolicy sets what the scope does with a trailing key given without a value.
+func (s *Scope) SetMissingValuePolicy(p MissingValuePolicy) {
+	s.missingValuePolicy.Store(p)
+}
+
+// GetMissingValuePolicy returns what the scope does with a trailing key given without a value.
+func (s *Scope) GetMissingValuePolicy() MissingValuePolicy {
+	return s.missingValuePolicy.Load().(MissingValuePolicy)
+}
+
+// keyValueFields turns alternating keys and values into fields. Fields can also be given
+// in place of a key, in which case they are used as they are. Malformed lists are only
+// reported as misuse when report is set, which it isn't for the entries recorded for
+// crash reports only.
+func (s *Scope) keyValueFields(msg string, keysAndValues []interface{}, report bool) []zapcore.Field {
+	if len(keysAndValues) == 0 {
+		return nil
+	}
+
+	policy := s.GetKeyPolicy()
+	fields := make([]zapcore.Field, 0, (len(keysAndValues)+1)/2)
+
+	var seen map[string]struct{}
+	if report && checkMisuse() {
+		seen = make(map[string]struct{}, cap(fields))
+	}
+
+	for i := 0; i < len(keysAndValues); {
+		if f, ok := keysAndValues[i].(zapcore.Field); ok {
+			if seen != nil {
+				checkDuplicateKey(seen, msg, f.Key)
+			}
+			fields = append(fields, f)
+			i++
+			continue
+		}
+
+		key := keyString(keysAndValues[i], i, policy)
+		if seen != nil {
+			if _, ok := keysAndValues[i].(string); !ok {
+				reportMisuse(Misuse{Kind: NonStringKey, Message: msg, Detail: fmt.Sprintf("%#v at position %d", keysAndValues[i], i)})
+			}
+			checkDuplicateKey(seen, msg, key)
+		}
+
+		if i+1 == len(keysAndValues) {
+			policy := s.GetMissingValuePolicy()
+			if report && policy == ReportMissing {
+				reportMissingValue(msg, key)
+			}
+			if seen != nil {
+				reportMisuse(Misuse{Kind: MissingValue, Message: msg, Detail: "key '" + key + "'"})
+			}
+
+			if policy != DropMissing {
+				fields = append(fields, zap.String(key, missingValue))
+			}
+			break
+		}
+
+		fields = append(fields, zap.Any(key, keysAndValues[i+1]))
+		i += 2
+	}
+
+	return fields
+}
+
+// formatf formats the message of a printf-style call, and builds the fields from the keys
+// and values following KV in the arguments, if any. Misuse is reported as by keyValueFields.
+func (s *Scope) formatf(template string, args []interface{}, report bool) (string, []zapcore.Field) {
+	for i, a := range args {
+		if _, ok := a.(kvSeparator); ok {
+			msg := formatMessage(template, args[:i], report)
+			return msg, s.keyValueFields(msg, args[i+1:], report)
+		}
+	}
+
+	return formatMessage(template, args, report), nil
+}
+
+// checkDuplicateKey reports a key already seen for the message.
+func checkDuplicateKey(seen map[string]struct{}, msg string, key string) {
+	if _, ok := seen[key]; ok {
+		reportMisuse(Misuse{Kind: DuplicateKey, Message: msg, Detail: "key '" + key + "'"})
+	}
+	seen[key] = struct{}{}
+}
+
+func reportMissingValue(msg string, key string) {
+	if es, _ := errorSink.Load().(zapcore.WriteSyncer); es != nil {
+		_, _ = fmt.Fprintf(es, "%v log key '%s' given without a value, for message '%s'\n", time.Now(), key, msg)
+		_ = es.Sync()
+	}
+}
+
+func keyString(key interface{}, pos int, policy KeyPolicy) string {
+	if k, ok := key.(string); ok {
+		return k
+	}
+
+	if policy == IndexKeys {
+		return "invalid_key_" + strconv.Itoa(pos)
+	}
+
+	return fmt.Sprint(key)
+}
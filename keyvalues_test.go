@@ -0,0 +1,203 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestKeyValueFields(t *testing.T) {
+	s := RegisterScope("TestKeyValueFields", "", 0)
+	defer s.SetKeyPolicy(StringifyKeys)
+
+	cases := []struct {
+		policy        KeyPolicy
+		keysAndValues []interface{}
+		expected      map[string]interface{}
+	}{
+		{StringifyKeys, nil, map[string]interface{}{}},
+		{StringifyKeys, []interface{}{"a", 1, "b", "x"}, map[string]interface{}{"a": int64(1), "b": "x"}},
+		{StringifyKeys, []interface{}{42, "x", true, 1}, map[string]interface{}{"42": "x", "true": int64(1)}},
+		{IndexKeys, []interface{}{42, "x", "a", 1, true, 2}, map[string]interface{}{"invalid_key_0": "x", "a": int64(1), "invalid_key_4": int64(2)}},
+		{StringifyKeys, []interface{}{zap.Int("f", 3), "a", 1}, map[string]interface{}{"f": int64(3), "a": int64(1)}},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			s.SetKeyPolicy(c.policy)
+			if s.GetKeyPolicy() != c.policy {
+				t.Errorf("Got %v, expected %v", s.GetKeyPolicy(), c.policy)
+			}
+
+			enc := zapcore.NewMapObjectEncoder()
+			for _, f := range s.keyValueFields("Hello", c.keysAndValues, true) {
+				f.AddTo(enc)
+			}
+
+			if len(enc.Fields) != len(c.expected) {
+				t.Fatalf("Got %v, expected %v", enc.Fields, c.expected)
+			}
+
+			for k, v := range c.expected {
+				if enc.Fields[k] != v {
+					t.Errorf("Got %v for %s, expected %v", enc.Fields[k], k, v)
+				}
+			}
+		})
+	}
+}
+
+func TestMissingValuePolicy(t *testing.T) {
+	s := RegisterScope("TestMissingValuePolicy", "", 0)
+	defer s.SetMissingValuePolicy(PadMissing)
+
+	errPath := filepath.Join(t.TempDir(), "errors.log")
+	o := DefaultOptions()
+	o.ErrorOutputPaths = []string{errPath}
+	if err := Configure(o); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = Configure(DefaultOptions()) }()
+
+	cases := []struct {
+		policy   MissingValuePolicy
+		expected map[string]interface{}
+		reported bool
+	}{
+		{PadMissing, map[string]interface{}{"a": int64(1), "b": missingValue}, false},
+		{DropMissing, map[string]interface{}{"a": int64(1)}, false},
+		{ReportMissing, map[string]interface{}{"a": int64(1), "b": missingValue}, true},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			s.SetMissingValuePolicy(c.policy)
+			if s.GetMissingValuePolicy() != c.policy {
+				t.Errorf("Got %v, expected %v", s.GetMissingValuePolicy(), c.policy)
+			}
+
+			_ = ioutil.WriteFile(errPath, nil, 0644)
+
+			enc := zapcore.NewMapObjectEncoder()
+			for _, f := range s.keyValueFields("Hello", []interface{}{"a", 1, "b"}, true) {
+				f.AddTo(enc)
+			}
+
+			if !reflect.DeepEqual(enc.Fields, c.expected) {
+				t.Errorf("Got %v, expected %v", enc.Fields, c.expected)
+			}
+
+			b, _ := ioutil.ReadFile(errPath)
+			if reported := strings.Contains(string(b), "log key 'b' given without a value, for message 'Hello'"); reported != c.reported {
+				t.Errorf("Got %q reported, expected a report: %v", b, c.reported)
+			}
+		})
+	}
+}
+
+func TestFormatf(t *testing.T) {
+	s := RegisterScope("TestFormatf", "", 0)
+
+	cases := []struct {
+		template string
+		args     []interface{}
+		msg      string
+		fields   map[string]interface{}
+	}{
+		{"synced %d routes", []interface{}{3}, "synced 3 routes", map[string]interface{}{}},
+		{"synced %d routes", []interface{}{3, KV, "cluster", "c1"}, "synced 3 routes", map[string]interface{}{"cluster": "c1"}},
+		{"synced", []interface{}{KV, "cluster", "c1", "n", 2}, "synced", map[string]interface{}{"cluster": "c1", "n": int64(2)}},
+		{"synced %d", []interface{}{KV, "cluster"}, "synced %d", map[string]interface{}{"cluster": missingValue}},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			msg, fields := s.formatf(c.template, c.args, true)
+			if msg != c.msg {
+				t.Errorf("Got %q, expected %q", msg, c.msg)
+			}
+
+			enc := zapcore.NewMapObjectEncoder()
+			for _, f := range fields {
+				f.AddTo(enc)
+			}
+
+			if !reflect.DeepEqual(enc.Fields, c.fields) {
+				t.Errorf("Got %v, expected %v", enc.Fields, c.fields)
+			}
+		})
+	}
+}
+
+func TestKeyValueStackTraces(t *testing.T) {
+	var stacks []bool
+	s := NewWithEmit("TestKeyValueStackTraces", "", 0, func(e zapcore.Entry, _ []zapcore.Field) error {
+		stacks = append(stacks, e.Stack != "")
+		return nil
+	})
+	s.SetOutputLevel(DebugLevel)
+	s.SetStackTraceLevel(DebugLevel)
+	defer s.SetOutputLevel(InfoLevel)
+	defer s.SetStackTraceLevel(NoneLevel)
+
+	// the key/value methods trace the stack like their siblings
+	s.Warn("a")
+	s.Warnw("a")
+	s.Info("b")
+	s.Infow("b")
+	s.Debug("c")
+	s.Debugw("c")
+
+	if len(stacks) != 6 {
+		t.Fatalf("Got %d entries, expected 6", len(stacks))
+	}
+	for i := 0; i < len(stacks); i += 2 {
+		if stacks[i] != stacks[i+1] {
+			t.Errorf("Got %v, expected the same stack traces for both methods", stacks)
+		}
+	}
+}
+
+func TestKeyValueFieldsFormats(t *testing.T) {
+	s := RegisterScope("TestKeyValueFieldsFormats", "", 0)
+	buf := &bufferSyncer{}
+	s.SetOutput(buf)
+	defer s.SetOutput(nil)
+	defer s.SetFormat(DefaultFormat)
+
+	// the keys and values are turned into fields once, before any encoder sees them
+	for i, f := range []Format{ConsoleFormat, JSONFormat, PrettyFormat, MsgpackFormat} {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			buf.Reset()
+			s.SetFormat(f)
+			s.Infow("Hello", 42, "answer", "dangling")
+
+			got := buf.String()
+			for _, expected := range []string{"42", "answer", "dangling", missingValue} {
+				if !strings.Contains(got, expected) {
+					t.Errorf("Got %q, expected %v in the %v output", got, expected, f)
+				}
+			}
+		})
+	}
+}
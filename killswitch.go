@@ -0,0 +1,43 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"sync/atomic"
+)
+
+// set to 1 by DisableAll, checked before anything else is done for an entry
+var disabled int32
+
+// DisableAll silences all logging, regardless of the levels of the scopes, until EnableAll
+// is called. It is meant for benchmarks and for operations that must not produce any
+// output, and costs a single atomic load per entry. The levels of the scopes are left
+// untouched, so EnableAll restores the output as it was.
+//
+// Metrics attached to scopes don't record the silenced entries, and Fatal still
+// terminates the process.
+func DisableAll() {
+	atomic.StoreInt32(&disabled, 1)
+}
+
+// EnableAll reverts DisableAll.
+func EnableAll() {
+	atomic.StoreInt32(&disabled, 0)
+}
+
+// AllDisabled returns whether logging is silenced by DisableAll.
+func AllDisabled() bool {
+	return atomic.LoadInt32(&disabled) != 0
+}
@@ -0,0 +1,88 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestDisableAll(t *testing.T) {
+	s := RegisterScope("TestDisableAll", "", 0)
+	defer EnableAll()
+
+	lines, err := captureStdout(func() {
+		_ = Configure(DefaultOptions())
+
+		DisableAll()
+		if !AllDisabled() {
+			t.Error("Got false, expected true")
+		}
+
+		s.Error("scope")
+		Error("default")
+		zap.L().Error("zap")
+
+		EnableAll()
+		if AllDisabled() {
+			t.Error("Got true, expected false")
+		}
+
+		s.Info("enabled")
+		_ = Sync()
+	})
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	if len(lines) != 2 || !strings.Contains(lines[0], "enabled") {
+		t.Errorf("Got %v, expected only the entry logged once enabled", lines)
+	}
+}
+
+func TestDisableAllSkipsErrorAccounting(t *testing.T) {
+	s := RegisterScope("TestDisableAllSkipsErrorAccounting", "", 0)
+	defer EnableAll()
+
+	var misuses []Misuse
+	SetMisuseHandler(func(m Misuse) { misuses = append(misuses, m) })
+	defer SetMisuseHandler(nil)
+
+	_, err := captureStdout(func() {
+		o := DefaultOptions()
+		o.ErrorSummaryInterval = time.Hour
+		_ = Configure(o)
+
+		DisableAll()
+		s.Errorf("failed %s", "once")
+		s.Errorw("failed", "key")
+		s.NewEntry(ErrorLevel).Msg("failed")
+
+		if got := ErrorCounts(); len(got) != 0 {
+			t.Errorf("Got %v, expected no error counted while disabled", got)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	_ = Configure(DefaultOptions())
+
+	if len(misuses) != 0 {
+		t.Errorf("Got %v, expected no misuse reported while disabled", misuses)
+	}
+}
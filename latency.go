@@ -0,0 +1,138 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// maxLatencySamples bounds the number of write latencies kept by a LatencyWriter, the
+// percentiles cover the most recent writes.
+const maxLatencySamples = 4096
+
+// LatencyWriter measures how long the writes to an output take, to reveal outputs that
+// stall the goroutines logging through them. Wrap the output under test and hand the
+// result to Scope.SetOutput or Options.Outputs, then look at Latencies:
+//
+//	w := log.NewLatencyWriter(out, 10*time.Millisecond, func(d time.Duration) {
+//		fmt.Fprintf(os.Stderr, "log write blocked for %v\n", d)
+//	})
+//
+// The logtest package wraps outputs so that blocking writes fail tests.
+type LatencyWriter struct {
+	ws        zapcore.WriteSyncer
+	threshold time.Duration
+	onBlock   func(d time.Duration)
+	now       func() time.Time
+
+	mu      sync.Mutex
+	samples []time.Duration
+	next    int
+	count   uint64
+	blocked uint64
+	max     time.Duration
+}
+
+// LatencyReport summarizes the write latencies measured by a LatencyWriter. The
+// percentiles cover the most recent writes.
+type LatencyReport struct {
+	// Count is the number of writes measured.
+	Count uint64
+	// Blocked is the number of writes which took longer than the threshold.
+	Blocked uint64
+	// P50, P90 and P99 are the latency percentiles.
+	P50, P90, P99 time.Duration
+	// Max is the longest write.
+	Max time.Duration
+}
+
+// NewLatencyWriter returns a writer measuring the latency of the writes to the given
+// output. The onBlock function, if any, is called with the latency of every write taking
+// longer than the threshold; a threshold of 0 disables it.
+func NewLatencyWriter(ws zapcore.WriteSyncer, threshold time.Duration, onBlock func(d time.Duration)) *LatencyWriter {
+	return &LatencyWriter{
+		ws:        ws,
+		threshold: threshold,
+		onBlock:   onBlock,
+		now:       time.Now,
+	}
+}
+
+// Write writes to the wrapped output, measuring how long it takes.
+func (w *LatencyWriter) Write(p []byte) (int, error) {
+	start := w.now()
+	n, err := w.ws.Write(p)
+	d := w.now().Sub(start)
+
+	blocked := w.threshold > 0 && d > w.threshold
+
+	w.mu.Lock()
+	if len(w.samples) < maxLatencySamples {
+		w.samples = append(w.samples, d)
+	} else {
+		w.samples[w.next] = d
+		w.next = (w.next + 1) % maxLatencySamples
+	}
+	w.count++
+	if blocked {
+		w.blocked++
+	}
+	if d > w.max {
+		w.max = d
+	}
+	w.mu.Unlock()
+
+	if blocked && w.onBlock != nil {
+		w.onBlock(d)
+	}
+
+	return n, err
+}
+
+// Sync syncs the wrapped output.
+func (w *LatencyWriter) Sync() error {
+	return w.ws.Sync()
+}
+
+// Latencies returns a summary of the write latencies measured so far.
+func (w *LatencyWriter) Latencies() LatencyReport {
+	w.mu.Lock()
+	sorted := append([]time.Duration(nil), w.samples...)
+	r := LatencyReport{Count: w.count, Blocked: w.blocked, Max: w.max}
+	w.mu.Unlock()
+
+	if len(sorted) == 0 {
+		return r
+	}
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	r.P50 = percentile(sorted, 50)
+	r.P90 = percentile(sorted, 90)
+	r.P99 = percentile(sorted, 99)
+	return r
+}
+
+// percentile returns the nearest-rank percentile of sorted latencies.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	i := (len(sorted)*p+99)/100 - 1
+	if i < 0 {
+		i = 0
+	}
+	return sorted[i]
+}
@@ -0,0 +1,70 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"testing"
+	"time"
+)
+
+type clockWriter struct {
+	now    time.Time
+	delays []time.Duration
+}
+
+func (w *clockWriter) Write(p []byte) (int, error) {
+	w.now = w.now.Add(w.delays[0])
+	w.delays = w.delays[1:]
+	return len(p), nil
+}
+
+func (w *clockWriter) Sync() error { return nil }
+
+func TestLatencyWriter(t *testing.T) {
+	cw := &clockWriter{}
+	for i := 1; i <= 100; i++ {
+		cw.delays = append(cw.delays, time.Duration(i)*time.Millisecond)
+	}
+
+	var blocked []time.Duration
+	w := NewLatencyWriter(cw, 95*time.Millisecond, func(d time.Duration) { blocked = append(blocked, d) })
+	w.now = func() time.Time { return cw.now }
+
+	if r := w.Latencies(); r != (LatencyReport{}) {
+		t.Errorf("Got %v, expected an empty report", r)
+	}
+
+	for i := 0; i < 100; i++ {
+		if _, err := w.Write([]byte("entry\n")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := LatencyReport{
+		Count:   100,
+		Blocked: 5,
+		P50:     50 * time.Millisecond,
+		P90:     90 * time.Millisecond,
+		P99:     99 * time.Millisecond,
+		Max:     100 * time.Millisecond,
+	}
+	if r := w.Latencies(); r != expected {
+		t.Errorf("Got %+v, expected %+v", r, expected)
+	}
+
+	if len(blocked) != 5 || blocked[0] != 96*time.Millisecond {
+		t.Errorf("Got %v, expected the 5 writes longer than 95ms", blocked)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"sort"
+)
+
+// LevelSetter manages the level of a named logger through the names of the levels, see
+// ParseLevel. Its methods only involve standard types, so that control planes managing
+// the loggers of several modules, such as those speaking the interfaces of the
+// tetratelabs/telemetry module, can adapt it without importing this package.
+type LevelSetter interface {
+	// Name is the name of the logger.
+	Name() string
+	// Description describes what the logger reports.
+	Description() string
+	// Level returns the name of the output level of the logger.
+	Level() string
+	// SetLevel sets the output level of the logger from the name of a level.
+	SetLevel(level string) error
+}
+
+// LevelManager lists and finds the LevelSetters of a set of loggers.
+type LevelManager interface {
+	// List returns the LevelSetters of all the loggers, sorted by name.
+	List() []LevelSetter
+	// Find returns the LevelSetter of the logger of the given name, if there's one.
+	Find(name string) (LevelSetter, bool)
+}
+
+// LevelManager returns a LevelManager over the scopes of the registry. The level changes
+// it makes are reported as coming from LevelSourceRemote.
+func (r *Registry) LevelManager() LevelManager {
+	return levelManager{r}
+}
+
+// ScopeLevelManager returns a LevelManager over the scopes registered in the process.
+func ScopeLevelManager() LevelManager {
+	return defaultRegistry.LevelManager()
+}
+
+// levelManager manages the levels of the scopes of a registry.
+type levelManager struct {
+	r *Registry
+}
+
+func (lm levelManager) List() []LevelSetter {
+	all := lm.r.Scopes()
+
+	setters := make([]LevelSetter, 0, len(all))
+	for _, s := range all {
+		setters = append(setters, scopeLevelSetter{s})
+	}
+
+	sort.Slice(setters, func(i, j int) bool { return setters[i].Name() < setters[j].Name() })
+	return setters
+}
+
+func (lm levelManager) Find(name string) (LevelSetter, bool) {
+	s := lm.r.lookup(name)
+	if s == nil {
+		return nil, false
+	}
+
+	return scopeLevelSetter{s}, true
+}
+
+// scopeLevelSetter manages the output level of a scope.
+type scopeLevelSetter struct {
+	s *Scope
+}
+
+func (ls scopeLevelSetter) Name() string {
+	return ls.s.Name()
+}
+
+func (ls scopeLevelSetter) Description() string {
+	return ls.s.Description()
+}
+
+func (ls scopeLevelSetter) Level() string {
+	return levelToString[ls.s.GetOutputLevel()]
+}
+
+func (ls scopeLevelSetter) SetLevel(level string) error {
+	l, err := ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	ls.s.SetOutputLevelFrom(l, LevelSourceRemote, "")
+	return nil
+}
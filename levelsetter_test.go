@@ -0,0 +1,62 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"testing"
+)
+
+func TestLevelManager(t *testing.T) {
+	r := NewRegistry()
+	b := r.RegisterScope("b", "second", 0)
+	r.RegisterScope("a", "first", 0)
+
+	lm := r.LevelManager()
+
+	var names []string
+	for _, ls := range lm.List() {
+		names = append(names, ls.Name()+":"+ls.Description()+":"+ls.Level())
+	}
+	if len(names) != 2 || names[0] != "a:first:info" || names[1] != "b:second:info" {
+		t.Errorf("Got %v, expected a and b at the info level", names)
+	}
+
+	if _, ok := lm.Find("missing"); ok {
+		t.Error("Got a LevelSetter, expected none for a missing scope")
+	}
+
+	ls, ok := lm.Find("b")
+	if !ok {
+		t.Fatal("Got no LevelSetter, expected one for b")
+	}
+
+	if err := ls.SetLevel("warning"); err != nil {
+		t.Errorf("Got error '%v', expected success", err)
+	}
+	if b.GetOutputLevel() != WarnLevel || ls.Level() != "warn" {
+		t.Errorf("Got %v, expected %v", ls.Level(), WarnLevel)
+	}
+
+	if err := ls.SetLevel("verbose"); err == nil {
+		t.Error("Got success, expected an invalid level to be rejected")
+	}
+	if b.GetOutputLevel() != WarnLevel {
+		t.Errorf("Got %v, expected the level to be left unchanged", b.GetOutputLevel())
+	}
+
+	if _, ok := ScopeLevelManager().Find(DefaultScopeName); !ok {
+		t.Error("Got no LevelSetter, expected one for the default scope")
+	}
+}
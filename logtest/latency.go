@@ -0,0 +1,47 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logtest
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/tetratelabs/log"
+)
+
+// AssertNoBlocking wraps an output in a log.LatencyWriter which fails the test, without
+// stopping it, for every write taking longer than the threshold. The latency percentiles
+// are reported in the test log once the test is over:
+//
+//	func TestHandlerLogging(t *testing.T) {
+//		scope.SetOutput(logtest.AssertNoBlocking(t, sink, time.Millisecond))
+//		serve()
+//	}
+func AssertNoBlocking(tb testing.TB, ws zapcore.WriteSyncer, threshold time.Duration) *log.LatencyWriter {
+	tb.Helper()
+
+	w := log.NewLatencyWriter(ws, threshold, func(d time.Duration) {
+		tb.Errorf("log write blocked for %v, longer than %v", d, threshold)
+	})
+
+	tb.Cleanup(func() {
+		r := w.Latencies()
+		tb.Logf("%d log writes, p50 %v, p90 %v, p99 %v, max %v", r.Count, r.P50, r.P90, r.P99, r.Max)
+	})
+
+	return w
+}
@@ -0,0 +1,62 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logtest
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, format)
+}
+
+func (r *recordingTB) Cleanup(func()) {}
+
+type sleepingWriter struct {
+	delay time.Duration
+}
+
+func (w sleepingWriter) Write(p []byte) (int, error) {
+	time.Sleep(w.delay)
+	return len(p), nil
+}
+
+func (w sleepingWriter) Sync() error { return nil }
+
+func TestAssertNoBlocking(t *testing.T) {
+	tb := &recordingTB{}
+	w := AssertNoBlocking(tb, sleepingWriter{}, time.Second)
+	_, _ = w.Write([]byte("fast\n"))
+
+	if len(tb.errors) != 0 {
+		t.Errorf("Got %v, expected no errors", tb.errors)
+	}
+
+	w = AssertNoBlocking(tb, sleepingWriter{delay: 5 * time.Millisecond}, time.Millisecond)
+	_, _ = w.Write([]byte("slow\n"))
+
+	if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], "blocked") {
+		t.Errorf("Got %v, expected a blocking error", tb.errors)
+	}
+}
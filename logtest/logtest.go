@@ -0,0 +1,195 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logtest helps locking down the log output of a program with golden files.
+//
+// Entries captured by a Recorder are rendered deterministically: they all carry the same
+// time and their fields are sorted by key. Comparing the output against a golden file
+// then catches any unintended change to the log format:
+//
+//	func TestStartupLogs(t *testing.T) {
+//		r := logtest.Capture(t, scope, log.JSONFormat)
+//		start()
+//		logtest.AssertGolden(t, "testdata/startup.golden", r.String())
+//	}
+//
+// Golden files are created or updated by running the tests with LOGTEST_UPDATE=1.
+//
+// Tests asserting on specific entries rather than on the whole output can parse lines
+// back into entries with Parse.
+package logtest
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/tetratelabs/log"
+)
+
+// UpdateEnvVar is the environment variable which, when set to 1, makes AssertGolden
+// write the golden files instead of comparing against them.
+const UpdateEnvVar = "LOGTEST_UPDATE"
+
+// FixedTime is the time given to all the entries captured by a Recorder.
+var FixedTime = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Recorder holds the rendered output of a scope.
+type Recorder struct {
+	format log.Format
+
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// Capture redirects the entries of a scope to a new recorder until the end of the test.
+// The entries are rendered in the given format, which is typically log.ConsoleFormat or
+// log.JSONFormat so that the output doesn't depend on the configuration of the process.
+//
+// The levels of the scope still apply. Once the test is over, the scope hands its entries
+// to where it did before again.
+func Capture(tb testing.TB, s *log.Scope, format log.Format) *Recorder {
+	tb.Helper()
+
+	r := &Recorder{format: format}
+	prev := s.GetEmitFunc()
+	s.SetEmitFunc(r.emit)
+	tb.Cleanup(func() { s.SetEmitFunc(prev) })
+
+	return r
+}
+
+func (r *Recorder) emit(e zapcore.Entry, fields []zapcore.Field) error {
+	e.Time = FixedTime
+
+	sorted := make([]zapcore.Field, len(fields))
+	copy(sorted, fields)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Key < sorted[j].Key })
+
+	b, err := log.Encode(r.format, e, sorted)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, _ = r.buf.Write(b)
+	return nil
+}
+
+// String returns the output captured so far.
+func (r *Recorder) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.buf.String()
+}
+
+// Reset discards the output captured so far.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.buf.Reset()
+}
+
+// AssertGolden fails the test if the given output differs from the content of the golden
+// file, reporting the differing lines. When LOGTEST_UPDATE=1 is set in the environment,
+// the golden file is written instead.
+func AssertGolden(tb testing.TB, path string, got string) {
+	tb.Helper()
+
+	if os.Getenv(UpdateEnvVar) == "1" {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			tb.Fatalf("Unable to create the directory of golden file %s: %v", path, err)
+		}
+
+		if err := ioutil.WriteFile(path, []byte(got), 0644); err != nil {
+			tb.Fatalf("Unable to write golden file %s: %v", path, err)
+		}
+
+		return
+	}
+
+	expected, err := ioutil.ReadFile(path)
+	if err != nil {
+		tb.Fatalf("Unable to read golden file %s: %v (run with %s=1 to create it)", path, err, UpdateEnvVar)
+	}
+
+	if d := Diff(string(expected), got); d != "" {
+		tb.Errorf("Output differs from golden file %s (run with %s=1 to update it):\n%s", path, UpdateEnvVar, d)
+	}
+}
+
+// Diff returns a line by line comparison of two outputs, with lines only found in the
+// expected output prefixed by "-" and lines only found in the actual output prefixed by
+// "+". It returns an empty string when the outputs are equal.
+func Diff(expected, got string) string {
+	if expected == got {
+		return ""
+	}
+
+	a := strings.SplitAfter(expected, "\n")
+	b := strings.SplitAfter(got, "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var out strings.Builder
+	line := func(prefix, s string) {
+		_, _ = fmt.Fprintf(&out, "%s %q\n", prefix, strings.TrimSuffix(s, "\n"))
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			line(" ", a[i])
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			line("+", b[j])
+			j++
+		default:
+			line("-", a[i])
+			i++
+		}
+	}
+
+	return out.String()
+}
@@ -0,0 +1,108 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logtest
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/tetratelabs/log"
+)
+
+func TestCapture(t *testing.T) {
+	s := log.RegisterScope("TestCapture", "", 0)
+
+	t.Run("capture", func(t *testing.T) {
+		r := Capture(t, s, log.JSONFormat)
+		s.Debug("hidden")
+		s.Info("Hello", zap.String("zeta", "z"), zap.Int("alpha", 1))
+		s.Warn("Careful")
+
+		AssertGolden(t, "testdata/capture.golden", r.String())
+
+		r.Reset()
+		if got := r.String(); got != "" {
+			t.Errorf("Got '%v', expected nothing after a reset", got)
+		}
+	})
+
+	// the scope is released at the end of the test
+	r := &Recorder{format: log.JSONFormat}
+	log.NewWithEmit(s.Name(), "", 0, r.emit)
+	log.NewWithEmit(s.Name(), "", 0, nil)
+	s.Info("not captured")
+	if got := r.String(); got != "" {
+		t.Errorf("Got '%v', expected nothing once released", got)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	cases := []struct {
+		expected string
+		got      string
+		result   string
+	}{
+		{"a\nb\n", "a\nb\n", ""},
+		{"a\nb\nc\n", "a\nc\n", "  \"a\"\n- \"b\"\n  \"c\"\n  \"\"\n"},
+		{"a\n", "a\nb\n", "  \"a\"\n+ \"b\"\n  \"\"\n"},
+		{"a\tb\n", "a b\n", "+ \"a b\"\n- \"a\\tb\"\n  \"\"\n"},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if got := Diff(c.expected, c.got); got != c.result {
+				t.Errorf("Got %q, expected %q", got, c.result)
+			}
+		})
+	}
+}
+
+func TestCaptureScopes(t *testing.T) {
+	parent := log.RegisterScope("TestCaptureScopes", "", 0)
+	isolated := log.NewRegistry().RegisterScope("TestCaptureIsolated", "", 0)
+
+	for i, s := range []*log.Scope{parent.WithName("child"), isolated} {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var previous int
+			s.SetEmitFunc(func(zapcore.Entry, []zapcore.Field) error {
+				previous++
+				return nil
+			})
+			defer s.SetEmitFunc(nil)
+
+			t.Run("capture", func(t *testing.T) {
+				r := Capture(t, s, log.ConsoleFormat)
+				s.Info("captured")
+
+				if got := r.String(); !strings.Contains(got, "captured") {
+					t.Errorf("Got '%v', expected the entry to be captured", got)
+				}
+			})
+
+			s.Info("released")
+			if previous != 1 {
+				t.Errorf("Got %d entries, expected the previous emit function to be restored", previous)
+			}
+		})
+	}
+
+	if _, ok := log.ReadOnlyRegistry().Scope("TestCaptureIsolated"); ok {
+		t.Error("Got the isolated scope in the registry of the process, expected it to be left out")
+	}
+}
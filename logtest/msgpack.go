@@ -0,0 +1,268 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logtest
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ParseMsgpack parses the entries output by the log package in log.MsgpackFormat. The
+// fields are decoded as follows: integers are int64 values, or uint64 values if they
+// overflow int64, floats are float64 values, binary values are byte slices, timestamps
+// are UTC time.Time values, and maps and arrays are map[string]interface{} and
+// []interface{} values.
+func ParseMsgpack(b []byte) ([]Entry, error) {
+	d := &msgpackDecoder{b: b}
+
+	var entries []Entry
+	for d.pos < len(d.b) {
+		v, err := d.decode()
+		if err != nil {
+			return entries, fmt.Errorf("invalid MessagePack entry at offset %d: %v", d.pos, err)
+		}
+
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return entries, fmt.Errorf("invalid MessagePack entry at offset %d: not a map", d.pos)
+		}
+
+		e, err := entryFromMsgpack(m)
+		if err != nil {
+			return entries, err
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
+
+func entryFromMsgpack(m map[string]interface{}) (Entry, error) {
+	e := Entry{Fields: make(map[string]interface{})}
+	for k, v := range m {
+		s, _ := v.(string)
+		switch k {
+		case "time":
+			t, ok := v.(time.Time)
+			if !ok {
+				return Entry{}, errors.New("invalid time in MessagePack entry")
+			}
+			e.Time = t
+		case "level":
+			e.Level = s
+		case "scope":
+			e.Scope = s
+		case "caller":
+			e.Caller = s
+		case "msg":
+			e.Message = s
+		case "stack":
+			e.Stack = s
+		default:
+			e.Fields[k] = v
+		}
+	}
+
+	if !levels[e.Level] {
+		return Entry{}, fmt.Errorf("invalid level '%s' in MessagePack entry", e.Level)
+	}
+
+	return e, nil
+}
+
+type msgpackDecoder struct {
+	b   []byte
+	pos int
+}
+
+var errTruncated = errors.New("truncated value")
+
+func (d *msgpackDecoder) next(n int) ([]byte, error) {
+	if n < 0 || len(d.b)-d.pos < n {
+		return nil, errTruncated
+	}
+
+	b := d.b[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+// uint reads a big-endian unsigned integer of the given size.
+func (d *msgpackDecoder) uint(size int) (uint64, error) {
+	b, err := d.next(size)
+	if err != nil {
+		return 0, err
+	}
+
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v, nil
+}
+
+func (d *msgpackDecoder) decode() (interface{}, error) {
+	b, err := d.next(1)
+	if err != nil {
+		return nil, err
+	}
+
+	c := b[0]
+	switch {
+	case c <= 0x7f:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c&0xf0 == 0x80:
+		return d.decodeMap(int(c & 0x0f))
+	case c&0xf0 == 0x90:
+		return d.decodeArray(int(c & 0x0f))
+	case c&0xe0 == 0xa0:
+		return d.decodeString(int(c & 0x1f))
+	}
+
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := d.uint(1 << (c - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		v, err := d.next(int(n))
+		return append([]byte(nil), v...), err
+	case 0xc7, 0xc8, 0xc9:
+		n, err := d.uint(1 << (c - 0xc7))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeExt(int(n))
+	case 0xca:
+		v, err := d.uint(4)
+		return float64(math.Float32frombits(uint32(v))), err
+	case 0xcb:
+		v, err := d.uint(8)
+		return math.Float64frombits(v), err
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		v, err := d.uint(1 << (c - 0xcc))
+		if v > math.MaxInt64 {
+			return v, err
+		}
+		return int64(v), err
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (c - 0xd0)
+		v, err := d.uint(size)
+		// sign-extend
+		shift := uint(64 - 8*size)
+		return int64(v<<shift) >> shift, err
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return d.decodeExt(1 << (c - 0xd4))
+	case 0xd9, 0xda, 0xdb:
+		n, err := d.uint(1 << (c - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeString(int(n))
+	case 0xdc, 0xdd:
+		n, err := d.uint(2 << (c - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeArray(int(n))
+	case 0xde, 0xdf:
+		n, err := d.uint(2 << (c - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeMap(int(n))
+	}
+
+	return nil, fmt.Errorf("unsupported type 0x%x", c)
+}
+
+func (d *msgpackDecoder) decodeString(n int) (interface{}, error) {
+	b, err := d.next(n)
+	return string(b), err
+}
+
+func (d *msgpackDecoder) decodeArray(n int) (interface{}, error) {
+	a := make([]interface{}, 0, n)
+	for i := 0; i < n; i++ {
+		v, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		a = append(a, v)
+	}
+
+	return a, nil
+}
+
+func (d *msgpackDecoder) decodeMap(n int) (interface{}, error) {
+	m := make(map[string]interface{}, n)
+	for i := 0; i < n; i++ {
+		k, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+
+		key, ok := k.(string)
+		if !ok {
+			return nil, fmt.Errorf("map key %v is not a string", k)
+		}
+
+		if m[key], err = d.decode(); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// decodeExt decodes an extension of n bytes, of which only timestamps are supported.
+func (d *msgpackDecoder) decodeExt(n int) (interface{}, error) {
+	t, err := d.next(1)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := d.next(n)
+	if err != nil {
+		return nil, err
+	}
+
+	if int8(t[0]) != -1 {
+		return nil, fmt.Errorf("unsupported extension type %d", int8(t[0]))
+	}
+
+	switch n {
+	case 4:
+		return time.Unix(int64(binary.BigEndian.Uint32(b)), 0).UTC(), nil
+	case 8:
+		v := binary.BigEndian.Uint64(b)
+		return time.Unix(int64(v&(1<<34-1)), int64(v>>34)).UTC(), nil
+	case 12:
+		return time.Unix(int64(binary.BigEndian.Uint64(b[4:])), int64(binary.BigEndian.Uint32(b))).UTC(), nil
+	}
+
+	return nil, fmt.Errorf("invalid timestamp of %d bytes", n)
+}
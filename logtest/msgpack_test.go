@@ -0,0 +1,121 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logtest
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/tetratelabs/log"
+)
+
+type point struct {
+	X int     `json:"x"`
+	Y float64 `json:"y"`
+}
+
+func TestParseMsgpack(t *testing.T) {
+	_ = log.Configure(log.DefaultOptions())
+
+	entry := zapcore.Entry{
+		Level:      zapcore.ErrorLevel,
+		Time:       FixedTime.Add(123456 * time.Microsecond),
+		LoggerName: "scope",
+		Message:    strings.Repeat("long message ", 30),
+		Caller:     zapcore.NewEntryCaller(0, "/src/github.com/tetratelabs/log/scope.go", 42, true),
+		Stack:      "goroutine 1",
+	}
+	fields := []zapcore.Field{
+		zap.String("k", "v"),
+		zap.Int("small", -3),
+		zap.Int64("min", math.MinInt64),
+		zap.Uint64("max", math.MaxUint64),
+		zap.Int("medium", 70000),
+		zap.Float64("f", 1.5),
+		zap.Bool("b", true),
+		zap.Binary("bin", []byte{1, 2}),
+		zap.Duration("d", time.Second),
+		zap.Time("t", FixedTime),
+		zap.Error(errors.New("failed")),
+		zap.Ints("ints", []int{1, -200}),
+		zap.Any("point", point{1, 2.5}),
+		zap.Any("nil", nil),
+	}
+
+	b, err := log.Encode(log.MsgpackFormat, entry, fields)
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	// entries follow one another
+	got, err := ParseMsgpack(append(b, b...))
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	expected := Entry{
+		Time:    entry.Time,
+		Level:   "error",
+		Scope:   "scope",
+		Caller:  "log/scope.go:42",
+		Message: entry.Message,
+		Stack:   "goroutine 1",
+		Fields: map[string]interface{}{
+			"k":      "v",
+			"small":  int64(-3),
+			"min":    int64(math.MinInt64),
+			"max":    uint64(math.MaxUint64),
+			"medium": int64(70000),
+			"f":      1.5,
+			"b":      true,
+			"bin":    []byte{1, 2},
+			"d":      int64(time.Second),
+			"t":      FixedTime,
+			"error":  "failed",
+			"ints":   []interface{}{int64(1), int64(-200)},
+			"point":  map[string]interface{}{"x": int64(1), "y": 2.5},
+			"nil":    nil,
+		},
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("Got %d entries, expected 2", len(got))
+	}
+
+	for _, e := range got {
+		if !reflect.DeepEqual(e, expected) {
+			t.Errorf("Got %v, expected %v", e, expected)
+		}
+	}
+}
+
+func TestParseMsgpackErrors(t *testing.T) {
+	_ = log.Configure(log.DefaultOptions())
+
+	b, _ := log.Encode(log.MsgpackFormat, zapcore.Entry{Level: zapcore.InfoLevel, Time: FixedTime}, nil)
+
+	for _, data := range [][]byte{b[:len(b)-1], {0x01}, {0x81, 0x01, 0x01}, {0xc1}} {
+		if _, err := ParseMsgpack(data); err == nil {
+			t.Errorf("Got success, expected an error for %x", data)
+		}
+	}
+}
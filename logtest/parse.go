@@ -0,0 +1,152 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logtest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeLayout is the layout of the times output by the log package.
+const TimeLayout = "2006-01-02T15:04:05.000000Z"
+
+// Entry is a log entry parsed back from the output of the log package.
+type Entry struct {
+	Time    time.Time
+	Level   string
+	Scope   string
+	Caller  string
+	Message string
+	Stack   string
+	// Fields holds the fields of the entry, decoded as by encoding/json: numbers are
+	// float64 values, and objects are maps.
+	Fields map[string]interface{}
+}
+
+var levels = map[string]bool{
+	"debug": true,
+	"info":  true,
+	"warn":  true,
+	"error": true,
+	"fatal": true,
+}
+
+// Parse parses a line output by the log package in the JSON or the console format, so
+// that tests can assert on the attributes and fields of entries rather than on their
+// rendering.
+//
+// In the console format, the scope and caller are optional, and told apart by the
+// caller holding a .go file name. Messages are expected not to hold tabs, and stack
+// traces, which span several lines, are not parsed.
+func Parse(line string) (Entry, error) {
+	line = strings.TrimRight(line, "\r\n")
+	if strings.HasPrefix(line, "{") {
+		return parseJSON(line)
+	}
+
+	return parseConsole(line)
+}
+
+func parseJSON(line string) (Entry, error) {
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &m); err != nil {
+		return Entry{}, fmt.Errorf("invalid JSON entry: %v", err)
+	}
+
+	e := Entry{Fields: make(map[string]interface{})}
+	var err error
+	for k, v := range m {
+		s, isString := v.(string)
+		switch k {
+		case "time":
+			if !isString {
+				return Entry{}, errors.New("invalid time in JSON entry")
+			}
+			if e.Time, err = time.Parse(TimeLayout, s); err != nil {
+				return Entry{}, fmt.Errorf("invalid time in JSON entry: %v", err)
+			}
+		case "level":
+			e.Level = s
+		case "scope":
+			e.Scope = s
+		case "caller":
+			e.Caller = s
+		case "msg":
+			e.Message = s
+		case "stack":
+			e.Stack = s
+		default:
+			e.Fields[k] = v
+		}
+	}
+
+	if !levels[e.Level] {
+		return Entry{}, fmt.Errorf("invalid level '%s' in JSON entry", e.Level)
+	}
+
+	return e, nil
+}
+
+func parseConsole(line string) (Entry, error) {
+	parts := strings.Split(line, "\t")
+	if len(parts) < 3 {
+		return Entry{}, fmt.Errorf("invalid console entry '%s'", line)
+	}
+
+	t, err := time.Parse(TimeLayout, parts[0])
+	if err != nil {
+		return Entry{}, fmt.Errorf("invalid time in console entry: %v", err)
+	}
+
+	if !levels[parts[1]] {
+		return Entry{}, fmt.Errorf("invalid level '%s' in console entry", parts[1])
+	}
+
+	e := Entry{Time: t, Level: parts[1], Fields: make(map[string]interface{})}
+	rest := parts[2:]
+
+	if last := rest[len(rest)-1]; len(rest) > 1 && strings.HasPrefix(last, "{") {
+		if err := json.Unmarshal([]byte(last), &e.Fields); err != nil {
+			return Entry{}, fmt.Errorf("invalid fields in console entry: %v", err)
+		}
+		rest = rest[:len(rest)-1]
+	}
+
+	switch len(rest) {
+	case 1:
+		e.Message = rest[0]
+	case 2:
+		if isCaller(rest[0]) {
+			e.Caller = rest[0]
+		} else {
+			e.Scope = rest[0]
+		}
+		e.Message = rest[1]
+	case 3:
+		e.Scope, e.Caller, e.Message = rest[0], rest[1], rest[2]
+	default:
+		return Entry{}, fmt.Errorf("unexpected number of parts in console entry '%s'", line)
+	}
+
+	return e, nil
+}
+
+func isCaller(s string) bool {
+	i := strings.LastIndex(s, ".go:")
+	return i > 0 && !strings.ContainsAny(s, " ")
+}
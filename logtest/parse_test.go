@@ -0,0 +1,105 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logtest
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/tetratelabs/log"
+)
+
+func TestParse(t *testing.T) {
+	_ = log.Configure(log.DefaultOptions())
+
+	entry := zapcore.Entry{
+		Level:      zapcore.WarnLevel,
+		Time:       FixedTime,
+		LoggerName: "scope",
+		Message:    "Hello world",
+		Caller:     zapcore.NewEntryCaller(0, "/src/github.com/tetratelabs/log/scope.go", 42, true),
+	}
+	fields := []zapcore.Field{zap.String("k", "v"), zap.Int("n", 3)}
+
+	noScope := entry
+	noScope.LoggerName = ""
+	noCaller := entry
+	noCaller.Caller = zapcore.EntryCaller{}
+
+	full := Entry{
+		Time:    FixedTime,
+		Level:   "warn",
+		Scope:   "scope",
+		Caller:  "log/scope.go:42",
+		Message: "Hello world",
+		Fields:  map[string]interface{}{"k": "v", "n": float64(3)},
+	}
+
+	cases := []struct {
+		format   log.Format
+		entry    zapcore.Entry
+		fields   []zapcore.Field
+		expected Entry
+	}{
+		{log.JSONFormat, entry, fields, full},
+		{log.ConsoleFormat, entry, fields, full},
+		{log.ConsoleFormat, noScope, fields, Entry{FixedTime, "warn", "", "log/scope.go:42", "Hello world", "", full.Fields}},
+		{log.ConsoleFormat, noCaller, nil, Entry{FixedTime, "warn", "scope", "", "Hello world", "", map[string]interface{}{}}},
+		{log.JSONFormat, noCaller, nil, Entry{FixedTime, "warn", "scope", "", "Hello world", "", map[string]interface{}{}}},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			b, err := log.Encode(c.format, c.entry, c.fields)
+			if err != nil {
+				t.Fatalf("Got error '%v', expected success", err)
+			}
+
+			got, err := Parse(string(b))
+			if err != nil {
+				t.Fatalf("Got error '%v', expected success", err)
+			}
+
+			if !reflect.DeepEqual(got, c.expected) {
+				t.Errorf("Got %+v, expected %+v", got, c.expected)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []string{
+		"",
+		"not a log line",
+		"{not json",
+		`{"level":"loud","msg":"x"}`,
+		`{"level":"info","time":"yesterday"}`,
+		"2000-01-01T00:00:00.000000Z\tloud\tHello",
+		"2000-01-01T00:00:00.000000Z\tinfo\tscope\tHello\t{broken",
+		"2000-01-01T00:00:00.000000Z\tinfo\ta\tb\tc\td",
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if _, err := Parse(c); err == nil {
+				t.Errorf("Got success, expected an error for %q", c)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Metric counts the entries logged through a scope. It is typically an adapter
+// around a counter of the metrics library used by the application.
+type Metric interface {
+	// Record adds the given value to the metric.
+	Record(value float64)
+}
+
+// LevelMetric is a Metric which records the level of the entries as a label or dimension,
+// which gives the rate of each level from a single metric. The metrics attached with
+// SetMetric which implement it have RecordLevel called instead of Record.
+type LevelMetric interface {
+	Metric
+
+	// RecordLevel adds the given value to the metric, for an entry at the given level.
+	RecordLevel(level zapcore.Level, value float64)
+}
+
+// MetricPolicy determines which entries are recorded by the metric attached to a scope.
+type MetricPolicy int
+
+const (
+	// RecordWhenEmitted only records entries that are actually written out.
+	RecordWhenEmitted MetricPolicy = iota
+	// RecordAlways records every logging call, including those suppressed by the output level.
+	RecordAlways
+	// RecordNever disables the metric without detaching it.
+	RecordNever
+)
+
+var metricPolicyToString = map[MetricPolicy]string{
+	RecordWhenEmitted: "when-emitted",
+	RecordAlways:      "always",
+	RecordNever:       "never",
+}
+
+// String returns the name of the policy
+func (p MetricPolicy) String() string {
+	return metricPolicyToString[p]
+}
+
+type metricHolder struct {
+	metric Metric
+	policy MetricPolicy
+}
+
+// SetMetric attaches a metric to the scope, recording entries according to the given
+// policy. A LevelMetric records the level of the entries as well. Use a nil metric to
+// detach it.
+func (s *Scope) SetMetric(m Metric, policy MetricPolicy) {
+	s.metric.Store(metricHolder{metric: m, policy: policy})
+}
+
+// GetMetric returns the metric attached to the scope, if any, and its policy.
+func (s *Scope) GetMetric() (Metric, MetricPolicy) {
+	h := s.metric.Load().(metricHolder)
+	return h.metric, h.policy
+}
+
+// enabled returns whether output at the given level is enabled, recording the entry as
+// requested by the RecordAlways policy if it's not. It also returns true for the entries
+// recorded for crash reports only, see Options.CrashDumpSuppressed, and false for all the
+// entries while logging is silenced by DisableAll.
+func (s *Scope) enabled(l Level) bool {
+	if atomic.LoadInt32(&disabled) != 0 {
+		return false
+	}
+
+	if s.GetOutputLevel() >= l {
+		return true
+	}
+
+	if atomic.LoadInt32(&crashDumpSuppressed) != 0 {
+		// emit records the entry for crash reports only
+		return true
+	}
+
+	if h := s.metric.Load().(metricHolder); h.metric != nil && h.policy == RecordAlways {
+		recordEntry(h.metric, levelToZap[l])
+	}
+
+	return false
+}
+
+// recordEntry records an entry at the given level, as a LevelMetric if the metric is one.
+func recordEntry(m Metric, level zapcore.Level) {
+	if lm, ok := m.(LevelMetric); ok {
+		lm.RecordLevel(level, 1)
+		return
+	}
+
+	m.Record(1)
+}
+
+// serializes the updates of the duration metrics of all scopes
+var durationMetricsLock sync.Mutex
+
+// SetDurationMetric makes the scope record the value, in seconds, of the duration fields
+// with the given key of the entries it emits, for example into a histogram. Combined with
+// the DurationKey of Start, it records the latency of timed operations. Use a nil metric
+// to stop recording the key.
+func (s *Scope) SetDurationMetric(key string, m Metric) {
+	durationMetricsLock.Lock()
+	defer durationMetricsLock.Unlock()
+
+	existing := s.durationMetrics.Load().(map[string]Metric)
+	metrics := make(map[string]Metric, len(existing)+1)
+	for k, v := range existing {
+		metrics[k] = v
+	}
+
+	if m == nil {
+		delete(metrics, key)
+	} else {
+		metrics[key] = m
+	}
+
+	s.durationMetrics.Store(metrics)
+}
+
+// GetDurationMetric returns the metric recording the duration fields with the given key, if any.
+func (s *Scope) GetDurationMetric(key string) Metric {
+	return s.durationMetrics.Load().(map[string]Metric)[key]
+}
+
+// recordDurations records the duration fields which have a metric attached.
+func (s *Scope) recordDurations(fields []zapcore.Field) {
+	metrics := s.durationMetrics.Load().(map[string]Metric)
+	if len(metrics) == 0 {
+		return
+	}
+
+	for _, f := range fields {
+		if f.Type != zapcore.DurationType {
+			continue
+		}
+
+		if m, ok := metrics[f.Key]; ok {
+			m.Record(time.Duration(f.Integer).Seconds())
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type testMetric struct {
+	total float64
+}
+
+func (m *testMetric) Record(value float64) {
+	m.total += value
+}
+
+func TestMetricPolicies(t *testing.T) {
+	cases := []struct {
+		policy MetricPolicy
+		want   float64
+	}{
+		{RecordWhenEmitted, 2},
+		{RecordAlways, 4},
+		{RecordNever, 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.policy.String(), func(t *testing.T) {
+			s := NewWithEmit("TestMetricPolicies", "", 0, func(zapcore.Entry, []zapcore.Field) error { return nil })
+			s.SetOutputLevel(InfoLevel)
+
+			m := &testMetric{}
+			s.SetMetric(m, c.policy)
+			defer s.SetMetric(nil, RecordWhenEmitted)
+
+			s.Debug("suppressed by level")
+			s.Info("emitted")
+			s.Once("k").Info("emitted")
+			s.Once("k").Info("suppressed by once")
+
+			if m.total != c.want {
+				t.Errorf("Got %v, expected %v", m.total, c.want)
+			}
+
+			if got, p := s.GetMetric(); got != m || p != c.policy {
+				t.Errorf("Got (%v, %v), expected (%v, %v)", got, p, m, c.policy)
+			}
+		})
+	}
+}
+
+type testLevelMetric struct {
+	testMetric
+	levels map[zapcore.Level]float64
+}
+
+func (m *testLevelMetric) RecordLevel(level zapcore.Level, value float64) {
+	m.levels[level] += value
+}
+
+func TestLevelMetric(t *testing.T) {
+	s := NewWithEmit("TestLevelMetric", "", 0, func(zapcore.Entry, []zapcore.Field) error { return nil })
+	s.SetOutputLevel(InfoLevel)
+	defer s.SetMetric(nil, RecordWhenEmitted)
+
+	cases := []struct {
+		policy MetricPolicy
+		want   map[zapcore.Level]float64
+	}{
+		{RecordWhenEmitted, map[zapcore.Level]float64{zapcore.InfoLevel: 2, zapcore.ErrorLevel: 1}},
+		{RecordAlways, map[zapcore.Level]float64{zapcore.DebugLevel: 1, zapcore.InfoLevel: 2, zapcore.ErrorLevel: 1}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.policy.String(), func(t *testing.T) {
+			m := &testLevelMetric{levels: map[zapcore.Level]float64{}}
+			s.SetMetric(m, c.policy)
+
+			s.Debug("suppressed by level")
+			s.Info("emitted")
+			s.Info("emitted")
+			s.Error("emitted")
+
+			if !reflect.DeepEqual(m.levels, c.want) {
+				t.Errorf("Got %v, expected %v", m.levels, c.want)
+			}
+
+			if m.total != 0 {
+				t.Errorf("Got %v, expected Record not to be called", m.total)
+			}
+		})
+	}
+}
+
+func TestDurationMetric(t *testing.T) {
+	s := NewWithEmit("TestDurationMetric", "", 0, func(zapcore.Entry, []zapcore.Field) error { return nil })
+	s.SetOutputLevel(DebugLevel)
+	defer s.SetOutputLevel(InfoLevel)
+
+	m := &latencyMetric{}
+	s.SetDurationMetric("took", m)
+	if s.GetDurationMetric("took") != m {
+		t.Errorf("Got %v, expected %v", s.GetDurationMetric("took"), m)
+	}
+
+	s.Info("one", zap.Duration("took", 1500*time.Millisecond), zap.Duration("other", time.Second))
+	s.Info("two", zap.String("took", "not a duration"))
+
+	timed := &latencyMetric{}
+	s.SetDurationMetric(DurationKey, timed)
+	s.Start("op")(nil)
+
+	s.SetDurationMetric("took", nil)
+	s.Info("three", zap.Duration("took", time.Second))
+
+	if len(m.values) != 1 || m.values[0] != 1.5 {
+		t.Errorf("Got %v, expected [1.5]", m.values)
+	}
+
+	if len(timed.values) != 1 {
+		t.Errorf("Got %v, expected the duration of the timed operation", timed.values)
+	}
+
+	if s.GetDurationMetric("took") != nil {
+		t.Errorf("Got %v, expected nil", s.GetDurationMetric("took"))
+	}
+}
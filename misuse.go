@@ -0,0 +1,97 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// MisuseKind is a kind of malformed logging call.
+type MisuseKind int
+
+const (
+	// MissingValue is a trailing key given without a value to a key/value method, such as Infow.
+	MissingValue MisuseKind = iota
+	// NonStringKey is a key which isn't a string given to a key/value method.
+	NonStringKey
+	// DuplicateKey is a key given more than once to a key/value method.
+	DuplicateKey
+	// FormatMismatch is a number of arguments not matching the verbs of the template given
+	// to a printf-style method, such as Infof.
+	FormatMismatch
+	// InvalidKey is a key rejected by the KeyNormalizer of the scope.
+	InvalidKey
+)
+
+var misuseKindToString = map[MisuseKind]string{
+	MissingValue:   "missing value",
+	NonStringKey:   "non-string key",
+	DuplicateKey:   "duplicate key",
+	FormatMismatch: "format mismatch",
+	InvalidKey:     "invalid key",
+}
+
+func (k MisuseKind) String() string {
+	return misuseKindToString[k]
+}
+
+// Misuse describes a malformed logging call.
+type Misuse struct {
+	// Kind is the kind of mistake.
+	Kind MisuseKind
+	// Message is the message, or the template, given to the call.
+	Message string
+	// Detail locates the mistake, e.g. the key given without a value.
+	Detail string
+}
+
+func (m Misuse) Error() string {
+	return fmt.Sprintf("log misuse: %v for message '%s': %s", m.Kind, m.Message, m.Detail)
+}
+
+// MisuseHandler is called with the malformed logging calls, see SetMisuseHandler.
+type MisuseHandler func(Misuse)
+
+// holds the MisuseHandler set by SetMisuseHandler
+var misuseHandler atomic.Value
+
+// set by the Configure method, 1 when malformed logging calls panic
+var strictMode int32
+
+// SetMisuseHandler sets a function called with the malformed logging calls, such as key/value
+// lists of odd length, which lets tests fail on them. The calls are still logged, as they
+// would be otherwise. It takes precedence over Options.Strict. Use nil to remove the handler.
+func SetMisuseHandler(h MisuseHandler) {
+	misuseHandler.Store(h)
+}
+
+// checkMisuse returns whether the logging calls must be checked for mistakes.
+func checkMisuse() bool {
+	h, _ := misuseHandler.Load().(MisuseHandler)
+	return h != nil || atomic.LoadInt32(&strictMode) != 0
+}
+
+// reportMisuse hands a malformed logging call to the misuse handler, or panics in strict mode.
+func reportMisuse(m Misuse) {
+	if h, _ := misuseHandler.Load().(MisuseHandler); h != nil {
+		h(m)
+		return
+	}
+
+	if atomic.LoadInt32(&strictMode) != 0 {
+		panic(m)
+	}
+}
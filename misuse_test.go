@@ -0,0 +1,81 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"strconv"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestMisuseHandler(t *testing.T) {
+	s := NewWithEmit("TestMisuseHandler", "", 0, func(zapcore.Entry, []zapcore.Field) error { return nil })
+
+	cases := []struct {
+		log      func()
+		expected []Misuse
+	}{
+		{func() { s.Infow("ok", "a", 1, zap.Int("b", 2)) }, nil},
+		{func() { s.Infow("odd", "a", 1, "b") }, []Misuse{{MissingValue, "odd", "key 'b'"}}},
+		{func() { s.Infow("key", 3, 1) }, []Misuse{{NonStringKey, "key", "3 at position 0"}}},
+		{func() { s.Infow("dup", "a", 1, zap.Int("a", 2)) }, []Misuse{{DuplicateKey, "dup", "key 'a'"}}},
+		{func() { s.Infof("%d and %d", 1) }, []Misuse{{FormatMismatch, "%d and %d", "expects 2 arguments, got 1"}}},
+		{func() { s.Infof("%d", 1, KV, "a", 1, "a", 2) }, []Misuse{{DuplicateKey, "1", "key 'a'"}}},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var got []Misuse
+			SetMisuseHandler(func(m Misuse) { got = append(got, m) })
+			defer SetMisuseHandler(nil)
+
+			c.log()
+
+			if len(got) != len(c.expected) {
+				t.Fatalf("Got %v, expected %v", got, c.expected)
+			}
+			for j := range got {
+				if got[j] != c.expected[j] {
+					t.Errorf("Got %v, expected %v", got[j], c.expected[j])
+				}
+			}
+		})
+	}
+}
+
+func TestStrict(t *testing.T) {
+	s := NewWithEmit("TestStrict", "", 0, func(zapcore.Entry, []zapcore.Field) error { return nil })
+
+	o := DefaultOptions()
+	o.Strict = true
+	if err := Configure(o); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = Configure(DefaultOptions()) }()
+
+	s.Infow("fine", "a", 1)
+
+	defer func() {
+		r := recover()
+		if m, ok := r.(Misuse); !ok || m.Kind != MissingValue {
+			t.Errorf("Got %v, expected a missing value panic", r)
+		}
+	}()
+
+	s.Infow("odd", "a")
+	t.Error("Got no panic, expected one")
+}
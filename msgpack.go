@@ -0,0 +1,317 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// msgpackTimestamp is the MessagePack extension type of timestamps, -1.
+const msgpackTimestamp = 0xff
+
+// msgpackEncoder outputs each entry as a MessagePack map, see MsgpackFormat.
+type msgpackEncoder struct {
+	*zapcore.MapObjectEncoder
+
+	cfg zapcore.EncoderConfig
+}
+
+var msgpackBuffers = buffer.NewPool()
+
+func newMsgpackEncoder(encCfg zapcore.EncoderConfig) zapcore.Encoder {
+	return &msgpackEncoder{
+		MapObjectEncoder: zapcore.NewMapObjectEncoder(),
+		cfg:              encCfg,
+	}
+}
+
+func (enc *msgpackEncoder) Clone() zapcore.Encoder {
+	clone := &msgpackEncoder{
+		MapObjectEncoder: zapcore.NewMapObjectEncoder(),
+		cfg:              enc.cfg,
+	}
+
+	for k, v := range enc.Fields {
+		clone.Fields[k] = v
+	}
+
+	return clone
+}
+
+func (enc *msgpackEncoder) EncodeEntry(e zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	// the elements of the entry, then the fields added through With in a stable order,
+	// then those of the entry
+	keys := []string{enc.cfg.LevelKey, enc.cfg.TimeKey}
+	values := []interface{}{e.Level.String(), e.Time}
+
+	if e.LoggerName != "" {
+		keys = append(keys, enc.cfg.NameKey)
+		values = append(values, e.LoggerName)
+	}
+
+	if e.Caller.Defined {
+		keys = append(keys, enc.cfg.CallerKey)
+		values = append(values, e.Caller.TrimmedPath())
+	}
+
+	keys = append(keys, enc.cfg.MessageKey)
+	values = append(values, e.Message)
+
+	for _, k := range sortedKeys(enc.Fields) {
+		keys = append(keys, k)
+		values = append(values, enc.Fields[k])
+	}
+
+	for _, f := range fields {
+		m := zapcore.NewMapObjectEncoder()
+		f.AddTo(m)
+		for _, k := range sortedKeys(m.Fields) {
+			keys = append(keys, k)
+			values = append(values, m.Fields[k])
+		}
+	}
+
+	if e.Stack != "" {
+		keys = append(keys, enc.cfg.StacktraceKey)
+		values = append(values, e.Stack)
+	}
+
+	buf := msgpackBuffers.Get()
+	appendMsgpackMapHeader(buf, len(keys))
+	for i := range keys {
+		appendMsgpackString(buf, keys[i])
+		if err := appendMsgpack(buf, values[i]); err != nil {
+			buf.Free()
+			return nil, err
+		}
+	}
+
+	return buf, nil
+}
+
+// appendMsgpack appends a value held by a zapcore.MapObjectEncoder. Values of other
+// types, as added by AddReflected, are encoded as they would be in JSON.
+func appendMsgpack(buf *buffer.Buffer, v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		buf.AppendByte(0xc0)
+	case bool:
+		if v {
+			buf.AppendByte(0xc3)
+		} else {
+			buf.AppendByte(0xc2)
+		}
+	case string:
+		appendMsgpackString(buf, v)
+	case []byte:
+		appendMsgpackBinary(buf, v)
+	case int:
+		appendMsgpackInt(buf, int64(v))
+	case int64:
+		appendMsgpackInt(buf, v)
+	case int32:
+		appendMsgpackInt(buf, int64(v))
+	case int16:
+		appendMsgpackInt(buf, int64(v))
+	case int8:
+		appendMsgpackInt(buf, int64(v))
+	case uint:
+		appendMsgpackUint(buf, uint64(v))
+	case uint64:
+		appendMsgpackUint(buf, v)
+	case uint32:
+		appendMsgpackUint(buf, uint64(v))
+	case uint16:
+		appendMsgpackUint(buf, uint64(v))
+	case uint8:
+		appendMsgpackUint(buf, uint64(v))
+	case uintptr:
+		appendMsgpackUint(buf, uint64(v))
+	case float64:
+		buf.AppendByte(0xcb)
+		appendBigEndian(buf, math.Float64bits(v), 8)
+	case float32:
+		buf.AppendByte(0xca)
+		appendBigEndian(buf, uint64(math.Float32bits(v)), 4)
+	case complex128, complex64:
+		appendMsgpackString(buf, fmt.Sprint(v))
+	case time.Duration:
+		appendMsgpackInt(buf, int64(v))
+	case time.Time:
+		appendMsgpackTime(buf, v)
+	case map[string]interface{}:
+		appendMsgpackMapHeader(buf, len(v))
+		for _, k := range sortedKeys(v) {
+			appendMsgpackString(buf, k)
+			if err := appendMsgpack(buf, v[k]); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		appendMsgpackArrayHeader(buf, len(v))
+		for _, e := range v {
+			if err := appendMsgpack(buf, e); err != nil {
+				return err
+			}
+		}
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			appendMsgpackInt(buf, i)
+		} else if f, err := v.Float64(); err == nil {
+			return appendMsgpack(buf, f)
+		} else {
+			appendMsgpackString(buf, v.String())
+		}
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		var decoded interface{}
+		d := json.NewDecoder(bytes.NewReader(b))
+		d.UseNumber()
+		if err := d.Decode(&decoded); err != nil {
+			return err
+		}
+
+		return appendMsgpack(buf, decoded)
+	}
+
+	return nil
+}
+
+func appendMsgpackInt(buf *buffer.Buffer, v int64) {
+	switch {
+	case v >= 0:
+		appendMsgpackUint(buf, uint64(v))
+	case v >= -32:
+		buf.AppendByte(byte(v))
+	case v >= math.MinInt8:
+		buf.AppendByte(0xd0)
+		buf.AppendByte(byte(v))
+	case v >= math.MinInt16:
+		buf.AppendByte(0xd1)
+		appendBigEndian(buf, uint64(v), 2)
+	case v >= math.MinInt32:
+		buf.AppendByte(0xd2)
+		appendBigEndian(buf, uint64(v), 4)
+	default:
+		buf.AppendByte(0xd3)
+		appendBigEndian(buf, uint64(v), 8)
+	}
+}
+
+func appendMsgpackUint(buf *buffer.Buffer, v uint64) {
+	switch {
+	case v < 128:
+		buf.AppendByte(byte(v))
+	case v <= math.MaxUint8:
+		buf.AppendByte(0xcc)
+		buf.AppendByte(byte(v))
+	case v <= math.MaxUint16:
+		buf.AppendByte(0xcd)
+		appendBigEndian(buf, v, 2)
+	case v <= math.MaxUint32:
+		buf.AppendByte(0xce)
+		appendBigEndian(buf, v, 4)
+	default:
+		buf.AppendByte(0xcf)
+		appendBigEndian(buf, v, 8)
+	}
+}
+
+func appendMsgpackString(buf *buffer.Buffer, s string) {
+	n := len(s)
+	switch {
+	case n < 32:
+		buf.AppendByte(0xa0 | byte(n))
+	case n <= math.MaxUint8:
+		buf.AppendByte(0xd9)
+		buf.AppendByte(byte(n))
+	case n <= math.MaxUint16:
+		buf.AppendByte(0xda)
+		appendBigEndian(buf, uint64(n), 2)
+	default:
+		buf.AppendByte(0xdb)
+		appendBigEndian(buf, uint64(n), 4)
+	}
+	buf.AppendString(s)
+}
+
+func appendMsgpackBinary(buf *buffer.Buffer, b []byte) {
+	n := len(b)
+	switch {
+	case n <= math.MaxUint8:
+		buf.AppendByte(0xc4)
+		buf.AppendByte(byte(n))
+	case n <= math.MaxUint16:
+		buf.AppendByte(0xc5)
+		appendBigEndian(buf, uint64(n), 2)
+	default:
+		buf.AppendByte(0xc6)
+		appendBigEndian(buf, uint64(n), 4)
+	}
+	_, _ = buf.Write(b)
+}
+
+func appendMsgpackMapHeader(buf *buffer.Buffer, n int) {
+	switch {
+	case n < 16:
+		buf.AppendByte(0x80 | byte(n))
+	case n <= math.MaxUint16:
+		buf.AppendByte(0xde)
+		appendBigEndian(buf, uint64(n), 2)
+	default:
+		buf.AppendByte(0xdf)
+		appendBigEndian(buf, uint64(n), 4)
+	}
+}
+
+func appendMsgpackArrayHeader(buf *buffer.Buffer, n int) {
+	switch {
+	case n < 16:
+		buf.AppendByte(0x90 | byte(n))
+	case n <= math.MaxUint16:
+		buf.AppendByte(0xdc)
+		appendBigEndian(buf, uint64(n), 2)
+	default:
+		buf.AppendByte(0xdd)
+		appendBigEndian(buf, uint64(n), 4)
+	}
+}
+
+// appendMsgpackTime appends a time as a 96-bit timestamp extension.
+func appendMsgpackTime(buf *buffer.Buffer, t time.Time) {
+	buf.AppendByte(0xc7)
+	buf.AppendByte(12)
+	buf.AppendByte(msgpackTimestamp)
+	appendBigEndian(buf, uint64(t.Nanosecond()), 4)
+	appendBigEndian(buf, uint64(t.Unix()), 8)
+}
+
+func appendBigEndian(buf *buffer.Buffer, v uint64, size int) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	_, _ = buf.Write(b[8-size:])
+}
@@ -0,0 +1,87 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestMsgpackEncoder(t *testing.T) {
+	_ = Configure(DefaultOptions())
+
+	e := zapcore.Entry{
+		Level:   zapcore.InfoLevel,
+		Time:    time.Unix(1, 2),
+		Message: "hi",
+	}
+
+	got, err := Encode(MsgpackFormat, e, []zapcore.Field{zap.Int("n", -1)})
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	expected := []byte{
+		0x84, // map of 4
+		0xa5, 'l', 'e', 'v', 'e', 'l', 0xa4, 'i', 'n', 'f', 'o',
+		0xa4, 't', 'i', 'm', 'e', 0xc7, 12, 0xff, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 1,
+		0xa3, 'm', 's', 'g', 0xa2, 'h', 'i',
+		0xa1, 'n', 0xff,
+	}
+	if !bytes.Equal(got, expected) {
+		t.Errorf("Got %x, expected %x", got, expected)
+	}
+}
+
+func TestAppendMsgpack(t *testing.T) {
+	cases := []struct {
+		value    interface{}
+		expected []byte
+	}{
+		{nil, []byte{0xc0}},
+		{false, []byte{0xc2}},
+		{127, []byte{0x7f}},
+		{128, []byte{0xcc, 0x80}},
+		{-33, []byte{0xd0, 0xdf}},
+		{int64(-129), []byte{0xd1, 0xff, 0x7f}},
+		{uint32(65536), []byte{0xce, 0, 1, 0, 0}},
+		{float32(1), []byte{0xca, 0x3f, 0x80, 0, 0}},
+		{time.Millisecond, []byte{0xce, 0, 0x0f, 0x42, 0x40}},
+		{[]byte{7}, []byte{0xc4, 1, 7}},
+		{[]interface{}{"a"}, []byte{0x91, 0xa1, 'a'}},
+		{map[string]interface{}{"b": 1, "a": true}, []byte{0x82, 0xa1, 'a', 0xc3, 0xa1, 'b', 1}},
+		{struct{ A int }{1}, []byte{0x81, 0xa1, 'A', 1}},
+		{string(make([]byte, 32)), append([]byte{0xd9, 32}, make([]byte, 32)...)},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			buf := &buffer.Buffer{}
+			if err := appendMsgpack(buf, c.value); err != nil {
+				t.Fatalf("Got error '%v', expected success", err)
+			}
+
+			if got := buf.Bytes(); !bytes.Equal(got, c.expected) {
+				t.Errorf("Got %x, expected %x", got, c.expected)
+			}
+		})
+	}
+}
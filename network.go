@@ -0,0 +1,374 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultNetworkDialTimeout = 5 * time.Second
+	defaultNetworkMinBackoff  = 100 * time.Millisecond
+	defaultNetworkMaxBackoff  = 30 * time.Second
+	defaultNetworkSpillSize   = 1024 * 1024
+)
+
+func init() {
+	// make tcp:// and udp:// usable as log targets
+	_ = zap.RegisterSink("tcp", newNetworkSink)
+	_ = zap.RegisterSink("udp", newNetworkSink)
+}
+
+// NetworkOptions controls the behavior of a NetworkWriter.
+type NetworkOptions struct {
+	// DialTimeout is the maximum amount of time a connection attempt may take, and a write
+	// to the collector, after which the connection is considered broken.
+	DialTimeout time.Duration
+
+	// MinBackoff is the delay before the first reconnection attempt after a TCP
+	// connection failure. The delay doubles on each consecutive failure.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the delay between TCP reconnection attempts.
+	MaxBackoff time.Duration
+
+	// SpillBufferSize is the maximum number of bytes held in memory while the
+	// TCP collector is unreachable. When the buffer is full, the oldest entries
+	// are discarded. A value of 0 disables spilling.
+	SpillBufferSize int
+
+	// SpillDir, if set, is a directory where the entries are spilled while the TCP
+	// collector is unreachable, instead of memory, up to SpillBufferSize bytes. Entries
+	// spilled to disk survive a restart of the process, and are delivered in order once
+	// the collector is reachable again.
+	SpillDir string
+}
+
+// DefaultNetworkOptions returns a new set of network options, initialized to the defaults
+func DefaultNetworkOptions() *NetworkOptions {
+	return &NetworkOptions{
+		DialTimeout:     defaultNetworkDialTimeout,
+		MinBackoff:      defaultNetworkMinBackoff,
+		MaxBackoff:      defaultNetworkMaxBackoff,
+		SpillBufferSize: defaultNetworkSpillSize,
+	}
+}
+
+// NetworkWriter ships log entries to a remote collector.
+//
+// Over TCP, the connection is established lazily and re-established with an
+// exponential backoff whenever it breaks. Entries written while the collector is
+// unreachable are kept in a bounded spill buffer and delivered, in order, once
+// the connection is back. Over UDP, each entry is sent as a single datagram on a
+// fire-and-forget basis.
+type NetworkWriter struct {
+	network string
+	address string
+	options NetworkOptions
+
+	mu        sync.Mutex
+	conn      net.Conn
+	backoff   time.Duration
+	nextDial  time.Time
+	spill     [][]byte
+	spillSize int
+	disk      *spillFile
+	diskSent  int // bytes of the oldest entry of the spill file already delivered
+	dropped   uint64
+}
+
+// NewNetworkWriter returns a writer for the given address URL, such as
+// tcp://collector:5170 or udp://collector:5170.
+func NewNetworkWriter(address string, options *NetworkOptions) (*NetworkWriter, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid network address '%s': %v", address, err)
+	}
+
+	return newNetworkWriter(u, options)
+}
+
+func newNetworkWriter(u *url.URL, options *NetworkOptions) (*NetworkWriter, error) {
+	if u.Scheme != "tcp" && u.Scheme != "udp" {
+		return nil, fmt.Errorf("unsupported network '%s', expecting tcp or udp", u.Scheme)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("missing host in network address '%s'", u.String())
+	}
+
+	if options == nil {
+		options = DefaultNetworkOptions()
+	}
+
+	w := &NetworkWriter{
+		network: u.Scheme,
+		address: u.Host,
+		options: *options,
+	}
+
+	if options.SpillDir != "" && u.Scheme == "tcp" {
+		disk, err := openSpillFile(filepath.Join(options.SpillDir, spillFileName("network", u.String())), options.SpillBufferSize)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open the spill file of '%s': %v", u.String(), err)
+		}
+		w.disk = disk
+	}
+
+	return w, nil
+}
+
+func newNetworkSink(u *url.URL) (zap.Sink, error) {
+	return newNetworkWriter(u, nil)
+}
+
+// Write sends an entry to the collector. It never reports collector outages to the
+// caller; entries that cannot be delivered are spilled or dropped instead.
+func (w *NetworkWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.network == "udp" {
+		if w.conn == nil && w.dial() != nil {
+			w.drop()
+			return len(p), nil
+		}
+
+		if _, err := w.send(p); err != nil {
+			w.drop()
+		}
+		return len(p), nil
+	}
+
+	if w.flushSpill() {
+		n, err := w.send(p)
+		if err == nil {
+			return len(p), nil
+		}
+		w.disconnect()
+
+		// only the bytes not delivered are sent again
+		p = p[n:]
+	}
+
+	w.addToSpill(p)
+	return len(p), nil
+}
+
+// Sync attempts to deliver any spilled entries.
+func (w *NetworkWriter) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.network == "tcp" && w.spilled() && !w.flushSpill() {
+		return fmt.Errorf("unable to reach log collector at %s, %d bytes pending", w.address, w.pending())
+	}
+
+	return nil
+}
+
+// Close closes the connection to the collector. Spilled entries that could not be
+// delivered are discarded, unless they were spilled to disk.
+func (w *NetworkWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.spill = nil
+	w.spillSize = 0
+	w.diskSent = 0
+
+	if w.disk != nil {
+		_ = w.disk.close()
+		w.disk = nil
+	}
+
+	if w.conn == nil {
+		return nil
+	}
+
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
+
+// Dropped returns the number of entries that were lost because the collector was
+// unreachable and the spill buffer was full, or because a datagram could not be sent.
+func (w *NetworkWriter) Dropped() uint64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.dropped
+}
+
+// flushSpill makes sure there is a live connection and that all spilled entries
+// have been delivered through it. Must be called with the lock held.
+func (w *NetworkWriter) flushSpill() bool {
+	if w.conn == nil {
+		if time.Now().Before(w.nextDial) || w.dial() != nil {
+			return false
+		}
+	}
+
+	for w.disk != nil {
+		p, err := w.disk.peek()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// the spill file was discarded
+			w.diskSent = 0
+			w.drop()
+			break
+		}
+
+		n, err := w.send(p[w.diskSent:])
+		if err != nil {
+			w.diskSent += n
+			w.disconnect()
+			return false
+		}
+
+		w.diskSent = 0
+		if err := w.disk.pop(); err != nil {
+			w.drop()
+			break
+		}
+	}
+
+	for len(w.spill) > 0 {
+		n, err := w.send(w.spill[0])
+		if err != nil {
+			// only the bytes not delivered are sent again
+			w.spill[0] = w.spill[0][n:]
+			w.spillSize -= n
+			w.disconnect()
+			return false
+		}
+
+		w.spillSize -= len(w.spill[0])
+		w.spill[0] = nil
+		w.spill = w.spill[1:]
+	}
+
+	return true
+}
+
+// send writes to the connection, giving up after the dial timeout so that a collector which
+// stops reading doesn't block the callers forever. Must be called with the lock held.
+func (w *NetworkWriter) send(p []byte) (int, error) {
+	if w.options.DialTimeout > 0 {
+		if err := w.conn.SetWriteDeadline(time.Now().Add(w.options.DialTimeout)); err != nil {
+			return 0, err
+		}
+	}
+
+	return w.conn.Write(p)
+}
+
+func (w *NetworkWriter) dial() error {
+	conn, err := net.DialTimeout(w.network, w.address, w.options.DialTimeout)
+	if err != nil {
+		w.scheduleRedial()
+		return err
+	}
+
+	w.conn = conn
+	w.backoff = 0
+	return nil
+}
+
+func (w *NetworkWriter) disconnect() {
+	_ = w.conn.Close()
+	w.conn = nil
+	w.scheduleRedial()
+}
+
+func (w *NetworkWriter) scheduleRedial() {
+	if w.backoff == 0 {
+		w.backoff = w.options.MinBackoff
+	} else {
+		w.backoff *= 2
+	}
+
+	if w.backoff > w.options.MaxBackoff {
+		w.backoff = w.options.MaxBackoff
+	}
+
+	w.nextDial = time.Now().Add(w.backoff)
+}
+
+// spilled returns whether entries are waiting for the collector.
+func (w *NetworkWriter) spilled() bool {
+	return len(w.spill) > 0 || (w.disk != nil && !w.disk.empty())
+}
+
+// pending returns the number of bytes waiting for the collector.
+func (w *NetworkWriter) pending() int64 {
+	n := int64(w.spillSize)
+	if w.disk != nil {
+		n += w.disk.size - w.disk.offset
+	}
+
+	return n
+}
+
+func (w *NetworkWriter) addToSpill(p []byte) {
+	if w.disk != nil {
+		dropped, err := w.disk.push(p)
+		if dropped > 0 {
+			// the oldest entry, partly delivered, may be amongst the dropped ones
+			w.diskSent = 0
+		}
+		if err != nil {
+			dropped++
+		}
+		for i := 0; i < dropped; i++ {
+			w.drop()
+		}
+		return
+	}
+
+	if len(p) > w.options.SpillBufferSize {
+		w.drop()
+		return
+	}
+
+	for w.spillSize+len(p) > w.options.SpillBufferSize {
+		w.spillSize -= len(w.spill[0])
+		w.spill[0] = nil
+		w.spill = w.spill[1:]
+		w.drop()
+	}
+
+	// the caller owns p and is free to reuse it once we return
+	b := make([]byte, len(p))
+	copy(b, p)
+
+	w.spill = append(w.spill, b)
+	w.spillSize += len(b)
+}
+
+func (w *NetworkWriter) drop() {
+	w.dropped++
+	recordDropped(DroppedByNetworkSink, 1)
+}
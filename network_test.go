@@ -0,0 +1,215 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestNetworkWriterTCP(t *testing.T) {
+	// grab a free port, then release it so the collector starts out unreachable
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unable to listen: %v", err)
+	}
+	addr := l.Addr().String()
+	_ = l.Close()
+
+	o := DefaultNetworkOptions()
+	o.MinBackoff = time.Millisecond
+	o.MaxBackoff = time.Millisecond
+
+	w, err := NewNetworkWriter("tcp://"+addr, o)
+	if err != nil {
+		t.Fatalf("Got err '%v', expecting success", err)
+	}
+	defer w.Close()
+
+	if _, err = w.Write([]byte("first\n")); err != nil {
+		t.Errorf("Got err '%v', expecting success", err)
+	}
+
+	if err = w.Sync(); err == nil {
+		t.Error("Got success, expecting an error while the collector is down")
+	}
+
+	l, err = net.Listen("tcp", addr)
+	if err != nil {
+		t.Skipf("Unable to listen again on %s: %v", addr, err)
+	}
+	defer l.Close()
+
+	time.Sleep(5 * time.Millisecond)
+	if _, err = w.Write([]byte("second\n")); err != nil {
+		t.Errorf("Got err '%v', expecting success", err)
+	}
+
+	conn, err := l.Accept()
+	if err != nil {
+		t.Fatalf("Unable to accept: %v", err)
+	}
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	for _, want := range []string{"first\n", "second\n"} {
+		if got, _ := r.ReadString('\n'); got != want {
+			t.Errorf("Got %q, expected %q", got, want)
+		}
+	}
+
+	if d := w.Dropped(); d != 0 {
+		t.Errorf("Got %d dropped entries, expected 0", d)
+	}
+}
+
+func TestNetworkWriterSpillOverflow(t *testing.T) {
+	o := DefaultNetworkOptions()
+	o.SpillBufferSize = 10
+
+	// nothing listens on port 1, so everything ends up in the spill buffer
+	w, err := NewNetworkWriter("tcp://127.0.0.1:1", o)
+	if err != nil {
+		t.Fatalf("Got err '%v', expecting success", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		_, _ = w.Write([]byte("1234\n"))
+	}
+
+	if d := w.Dropped(); d != 3 {
+		t.Errorf("Got %d dropped entries, expected 3", d)
+	}
+
+	if len(w.spill) != 2 || w.spillSize != 10 {
+		t.Errorf("Got %d spilled entries totaling %d bytes, expected 2 and 10", len(w.spill), w.spillSize)
+	}
+}
+
+func TestNetworkWriterUDP(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unable to listen: %v", err)
+	}
+	defer pc.Close()
+
+	w, err := NewNetworkWriter("udp://"+pc.LocalAddr().String(), nil)
+	if err != nil {
+		t.Fatalf("Got err '%v', expecting success", err)
+	}
+	defer w.Close()
+
+	if _, err = w.Write([]byte("hello\n")); err != nil {
+		t.Errorf("Got err '%v', expecting success", err)
+	}
+
+	buf := make([]byte, 64)
+	_ = pc.SetReadDeadline(time.Now().Add(time.Second))
+	n, _, err := pc.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("Unable to read datagram: %v", err)
+	}
+
+	if got := string(buf[:n]); got != "hello\n" {
+		t.Errorf("Got %q, expected %q", got, "hello\n")
+	}
+}
+
+func TestNetworkWriterBadAddress(t *testing.T) {
+	cases := []string{
+		"http://collector:5170",
+		"tcp://",
+		"%zz",
+	}
+
+	for _, c := range cases {
+		if _, err := NewNetworkWriter(c, nil); err == nil {
+			t.Errorf("Got success for '%s', expecting an error", c)
+		}
+	}
+}
+
+// partialConn accepts a limited number of bytes, then fails like a broken connection.
+type partialConn struct {
+	net.Conn
+	written   []byte
+	limit     int
+	deadlines int
+}
+
+func (c *partialConn) Write(p []byte) (int, error) {
+	n := len(p)
+	if n > c.limit-len(c.written) {
+		n = c.limit - len(c.written)
+	}
+	c.written = append(c.written, p[:n]...)
+	if n < len(p) {
+		return n, errors.New("broken pipe")
+	}
+	return n, nil
+}
+
+func (c *partialConn) SetWriteDeadline(time.Time) error {
+	c.deadlines++
+	return nil
+}
+
+func (c *partialConn) Close() error { return nil }
+
+func TestNetworkWriterPartialWrites(t *testing.T) {
+	o := DefaultNetworkOptions()
+	o.MinBackoff = time.Hour
+	o.MaxBackoff = time.Hour
+
+	w, err := NewNetworkWriter("tcp://127.0.0.1:1", o)
+	if err != nil {
+		t.Fatalf("Got err '%v', expecting success", err)
+	}
+	defer w.Close()
+
+	// a spilled entry partly delivered
+	conn := &partialConn{limit: 3}
+	w.conn = conn
+	w.spill = [][]byte{[]byte("hello\n")}
+	w.spillSize = 6
+
+	if err = w.Sync(); err == nil {
+		t.Error("Got success, expecting an error for the partial write")
+	}
+
+	if len(w.spill) != 1 || string(w.spill[0]) != "lo\n" || w.spillSize != 3 {
+		t.Errorf("Got %q (%d bytes), expected only the bytes not delivered", w.spill, w.spillSize)
+	}
+
+	// an entry written live and partly delivered
+	w.spill, w.spillSize = nil, 0
+	conn = &partialConn{limit: 2}
+	w.conn = conn
+
+	if _, err = w.Write([]byte("world\n")); err != nil {
+		t.Errorf("Got err '%v', expecting success", err)
+	}
+
+	if string(conn.written) != "wo" || len(w.spill) != 1 || string(w.spill[0]) != "rld\n" {
+		t.Errorf("Got %q sent and %q spilled, expected the remainder spilled", conn.written, w.spill)
+	}
+
+	if conn.deadlines != 1 {
+		t.Errorf("Got %d write deadlines, expected 1", conn.deadlines)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// KeyNormalizer rewrites the key of a field, or rejects the field by returning false for
+// ok, see SetKeyNormalizer.
+type KeyNormalizer func(key string) (normalized string, ok bool)
+
+// NormalizeKey is a KeyNormalizer turning keys to snake case, so that RequestID, requestId
+// and "request id" all become request_id. Upper case letters are lowered, words and
+// acronyms are separated by underscores, and so are the words separated by spaces or dots.
+// Keys holding control characters are rejected.
+func NormalizeKey(key string) (string, bool) {
+	if normalizedKey(key) {
+		return key, true
+	}
+
+	var b strings.Builder
+	b.Grow(len(key) + 4)
+
+	prev := rune(-1) // before lowering
+	for i, r := range key {
+		c := r
+		switch {
+		case unicode.IsControl(r):
+			return "", false
+
+		case r == ' ' || r == '.':
+			c = '_'
+
+		case unicode.IsUpper(r):
+			next, _ := utf8.DecodeRuneInString(key[i+utf8.RuneLen(r):])
+			// starts a word after a lower case letter or a digit, or ends an acronym
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && unicode.IsLower(next)) {
+				b.WriteByte('_')
+			}
+			c = unicode.ToLower(r)
+		}
+
+		b.WriteRune(c)
+		prev = r
+	}
+
+	return b.String(), true
+}
+
+// normalizedKey reports whether NormalizeKey would leave the key unchanged.
+func normalizedKey(key string) bool {
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '_' && c != '-' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SetKeyNormalizer sets a function rewriting the keys of the fields of the entries of the
+// scope, including the static fields and the ones derived from contexts, before the field
+// processors configured through Options run. Inconsistent keys, such as RequestID and
+// request_id, otherwise fragment the indices built downstream; NormalizeKey addresses
+// the common cases. Rejected fields are left out of the entries, and reported as InvalidKey
+// misuses. Use nil to leave the keys untouched, which is the default.
+func (s *Scope) SetKeyNormalizer(n KeyNormalizer) {
+	s.keyNormalizer.Store(n)
+}
+
+// GetKeyNormalizer returns the function rewriting the keys of the fields of the scope, if any.
+func (s *Scope) GetKeyNormalizer() KeyNormalizer {
+	return s.keyNormalizer.Load().(KeyNormalizer)
+}
+
+// normalizeKeys rewrites the keys of the fields with n, copying the slice only if needed.
+func normalizeKeys(fields []zapcore.Field, n KeyNormalizer, msg string) []zapcore.Field {
+	var out []zapcore.Field
+	for i := range fields {
+		key, ok := n(fields[i].Key)
+		if ok && key == fields[i].Key {
+			if out != nil {
+				out = append(out, fields[i])
+			}
+			continue
+		}
+
+		if out == nil {
+			out = make([]zapcore.Field, i, len(fields))
+			copy(out, fields)
+		}
+
+		if !ok {
+			if checkMisuse() {
+				reportMisuse(Misuse{Kind: InvalidKey, Message: msg, Detail: fmt.Sprintf("key %q", fields[i].Key)})
+			}
+			continue
+		}
+
+		f := fields[i]
+		f.Key = key
+		out = append(out, f)
+	}
+
+	if out == nil {
+		return fields
+	}
+
+	return out
+}
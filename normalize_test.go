@@ -0,0 +1,91 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"strconv"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNormalizeKey(t *testing.T) {
+	cases := []struct {
+		key      string
+		expected string
+		ok       bool
+	}{
+		{"request_id", "request_id", true},
+		{"RequestID", "request_id", true},
+		{"requestId", "request_id", true},
+		{"request id", "request_id", true},
+		{"http.status_code", "http_status_code", true},
+		{"HTTPServer", "http_server", true},
+		{"ipv4Addr", "ipv4_addr", true},
+		{"k8s-pod", "k8s-pod", true},
+		{"Ünïcode", "ünïcode", true},
+		{"bad\nkey", "", false},
+		{"bad\x00", "", false},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			got, ok := NormalizeKey(c.key)
+			if got != c.expected || ok != c.ok {
+				t.Errorf("Got %v, %v, expected %v, %v", got, ok, c.expected, c.ok)
+			}
+		})
+	}
+}
+
+func TestKeyNormalizer(t *testing.T) {
+	var got []zapcore.Field
+	s := NewWithEmit("TestKeyNormalizer", "", 0, func(_ zapcore.Entry, fields []zapcore.Field) error {
+		got = fields
+		return nil
+	})
+
+	var misuses []Misuse
+	SetMisuseHandler(func(m Misuse) { misuses = append(misuses, m) })
+	defer SetMisuseHandler(nil)
+
+	fields := []zapcore.Field{zap.String("RequestID", "a"), zap.Int("bad\tkey", 1), zap.Bool("ok", true)}
+
+	s.Info("untouched", fields...)
+	if len(got) != 3 || got[0].Key != "RequestID" {
+		t.Errorf("Got %v, expected the keys untouched by default", got)
+	}
+
+	s.SetKeyNormalizer(NormalizeKey)
+	defer s.SetKeyNormalizer(nil)
+
+	s.AddFields(zap.String("Component.Name", "x"))
+	defer s.ResetFields()
+
+	s.Info("normalized", fields...)
+	if len(got) != 3 || got[0].Key != "component_name" || got[1].Key != "request_id" || got[2].Key != "ok" {
+		t.Errorf("Got %v, expected the normalized fields", got)
+	}
+
+	if fields[0].Key != "RequestID" {
+		t.Errorf("Got %v, expected the fields of the caller left untouched", fields[0].Key)
+	}
+
+	expected := []Misuse{{InvalidKey, "normalized", `key "bad\tkey"`}}
+	if len(misuses) != 1 || misuses[0] != expected[0] {
+		t.Errorf("Got %v, expected %v", misuses, expected)
+	}
+}
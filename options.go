@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -46,10 +47,53 @@ func (l Level) String() string {
 	return levelToString[l]
 }
 
-// LevelFrom returns the level for the given name
+// LevelFrom returns the level for the given name. See ParseLevel for the accepted names.
 func LevelFrom(name string) (Level, bool) {
-	l, ok := stringToLevel[name]
-	return l, ok
+	l, err := ParseLevel(name)
+	return l, err == nil
+}
+
+// ParseLevel returns the level for the given name. Names are case-insensitive, and
+// common aliases such as warning, err, trace, off and disabled are accepted.
+func ParseLevel(name string) (Level, error) {
+	n := strings.ToLower(strings.TrimSpace(name))
+	if l, ok := stringToLevel[n]; ok {
+		return l, nil
+	}
+
+	if l, ok := levelAliases[n]; ok {
+		return l, nil
+	}
+
+	return NoneLevel, fmt.Errorf("invalid level '%s', must be one of [%s, %s, %s, %s, %s]", name,
+		levelToString[DebugLevel],
+		levelToString[InfoLevel],
+		levelToString[WarnLevel],
+		levelToString[ErrorLevel],
+		levelToString[NoneLevel])
+}
+
+// MarshalText implements encoding.TextMarshaler, which also makes levels render as
+// their name when encoded as JSON.
+func (l Level) MarshalText() ([]byte, error) {
+	s, ok := levelToString[l]
+	if !ok {
+		return nil, fmt.Errorf("invalid level %d", int(l))
+	}
+
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, which also makes levels decode
+// from their name when decoding JSON.
+func (l *Level) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+
+	*l = level
+	return nil
 }
 
 const (
@@ -81,11 +125,20 @@ var stringToLevel = map[string]Level{
 	"none":  NoneLevel,
 }
 
+var levelAliases = map[string]Level{
+	"trace":    DebugLevel,
+	"warning":  WarnLevel,
+	"err":      ErrorLevel,
+	"off":      NoneLevel,
+	"disabled": NoneLevel,
+}
+
 // Options defines the set of options supported by Istio's component logging package.
 type Options struct {
 	// OutputPaths is a list of file system paths to write the log data to.
 	// The special values stdout and stderr can be used to output to the
-	// standard I/O streams. This defaults to stdout.
+	// standard I/O streams. This defaults to stdout. Remote collectors can be
+	// targeted using tcp://host:port or udp://host:port URLs.
 	OutputPaths []string
 
 	// ErrorOutputPaths is a list of file system paths to write logger errors to.
@@ -93,6 +146,20 @@ type Options struct {
 	// standard I/O streams. This defaults to stderr.
 	ErrorOutputPaths []string
 
+	// JSONOutputPaths is a list of paths where every entry is also written as JSON,
+	// whatever the format of the OutputPaths, which accept the same values. This lets a
+	// process output pretty or console text for the developers, and JSON for a log
+	// pipeline at the same time. The fields of each entry are processed once, redacted
+	// for instance, and then encoded in each format, or once for both when they're both
+	// JSON. The default is to output a single format.
+	JSONOutputPaths []string
+
+	// Resource holds the attributes of the OpenTelemetry resource producing the entries,
+	// such as service.name, service.version or k8s.pod.name, output under ResourceKey by
+	// the JSON entries. They're encoded once, rather than with the fields of every entry.
+	// See ResourceFromEnv. The default is to leave the resource out.
+	Resource map[string]string
+
 	// RotateOutputPath is the path to a rotating log file. This file should
 	// be automatically rotated over time, based on the rotation parameters such
 	// as RotationMaxSize and RotationMaxAge. The default is to not rotate.
@@ -119,14 +186,244 @@ type Options struct {
 	// is to retain at most 1000 logs.
 	RotationMaxBackups int
 
+	// ReopenOnSIGHUP controls whether the files listed in OutputPaths are closed and
+	// reopened whenever the process receives SIGHUP, which lets logrotate rename them
+	// without resorting to copytruncate. The default is to leave SIGHUP alone.
+	ReopenOnSIGHUP bool
+
+	// LockFiles controls whether an advisory lock (flock) is held on the files listed in
+	// OutputPaths while each entry is appended, for files shared by several processes.
+	// Entries are always appended with a single write, which is enough to keep them from
+	// interleaving on local file systems. The default is to not lock files.
+	LockFiles bool
+
+	// TimedOutputMaxAge is the age beyond which the files of the OutputPaths holding time
+	// directives, such as app-%Y-%m-%d.log, are removed. See TimedFile. The default is to
+	// keep the files whatever their age.
+	TimedOutputMaxAge time.Duration
+
+	// TimedOutputMaxFiles is the number of files kept for each of the OutputPaths holding
+	// time directives. The default is to keep all the files.
+	TimedOutputMaxFiles int
+
+	// EncryptArchives controls whether the log files rotated out of RotateOutputPath, and the
+	// files of the OutputPaths holding time directives other than the current one, are
+	// encrypted with AES-GCM, for environments requiring encryption at rest. The key is read
+	// from LOG_ARCHIVE_KEY unless SetArchiveKeyFunc is called. See NewArchiveReader. The
+	// default is to leave the files as they are.
+	EncryptArchives bool
+
 	// JSONEncoding controls whether the log is formatted as JSON.
 	JSONEncoding bool
 
+	// Pretty controls whether the log is formatted for developers, with the fields of
+	// each entry on their own lines, indented and colored. It takes precedence over
+	// JSONEncoding, and can also be turned on by setting LOG_PRETTY=1 in the environment.
+	Pretty bool
+
+	// TextTemplate lays out each entry on a line, such as "{time} [{LEVEL}] {scope} {msg} {fields}",
+	// see TemplateFormat. It's compiled once by Configure, and takes precedence over JSONEncoding,
+	// but not over Pretty. The default is to leave the layout to the other options.
+	TextTemplate string
+
+	// CSVEncoding controls whether the log is formatted as comma-separated values, see
+	// CSVFormat. It takes precedence over TSVEncoding and JSONEncoding, but not over Pretty
+	// and TextTemplate.
+	CSVEncoding bool
+
+	// TSVEncoding controls whether the log is formatted as tab-separated values, see TSVFormat.
+	// It takes precedence over JSONEncoding.
+	TSVEncoding bool
+
+	// CSVColumns are the columns output by CSVFormat and TSVFormat, such as time, level,
+	// scope, msg or the key of a field. They default to time, level, scope and msg.
+	CSVColumns []string
+
+	// ConsoleFields is where ConsoleFormat outputs the fields of the entries: after the
+	// message, before it, or nowhere for terse output. The other formats are unaffected.
+	// The default is to output the fields after the message.
+	ConsoleFields FieldsPlacement
+
+	// MsgpackEncoding controls whether the log is formatted as MessagePack, see MsgpackFormat.
+	// It takes precedence over JSONEncoding.
+	MsgpackEncoding bool
+
+	// LinePrefix is written at the start of every line of the configured outputs, and of the
+	// destinations set with SetOutput or chosen by the router, once the entries are encoded,
+	// such as a marker expected by a sidecar or a legacy parser. It's meant for the text
+	// formats. Chained audit entries are verified by giving it to VerifyChain. The default is
+	// to write no prefix.
+	LinePrefix string
+
+	// LineSuffix is written at the end of every line of the configured outputs, before the
+	// newline, like LinePrefix. The default is to write no suffix.
+	LineSuffix string
+
+	// ErrorKey is the key under which errors added with zap.Error or Err are output.
+	// It defaults to "error".
+	ErrorKey string
+
+	// ScopeKey is the key under which the name of the scope of each entry is output, for
+	// the scopes other than the default one. MessageFormat leaves it out. It defaults to
+	// "scope", which the parsers of this module, such as logtest, rely on.
+	ScopeKey string
+
+	// NilErrorValue is the value output for nil errors added with Err. The default is
+	// to omit the field altogether.
+	NilErrorValue string
+
+	// BinaryEncoding controls how binary values, such as those added with zap.Binary,
+	// are output. The default is base64.
+	BinaryEncoding BinaryEncoding
+
+	// BinaryMaxBytes is the number of bytes of a binary value beyond which it is
+	// truncated. The default is to not truncate binary values.
+	BinaryMaxBytes int
+
+	// MaxFields is the maximum number of fields output for a single entry. Extra fields
+	// are replaced by a fields_omitted field holding their count. The default is to not
+	// limit the number of fields.
+	MaxFields int
+
+	// MaxValueSize is the length in bytes beyond which string, binary and error values
+	// are summarized by their length and digest rather than output verbatim. The default
+	// is to output values of any size.
+	MaxValueSize int
+
+	// RedactionRules select additional values to summarize rather than output verbatim,
+	// based on their size or content.
+	RedactionRules []RedactionRule
+
+	// PseudonymizedFields lists the keys of the fields holding personal identifiers, such
+	// as user_id or email, whose values are replaced by their HMAC-SHA256 digest keyed with
+	// PseudonymizationKey. Entries about the same person remain correlatable, but the
+	// identifiers themselves are not output.
+	PseudonymizedFields []string
+
+	// PseudonymizationKey is the secret key used to compute the digests of the fields
+	// listed in PseudonymizedFields. It should be kept out of the logs and rotated according
+	// to the retention policy of the logs.
+	PseudonymizationKey []byte
+
 	// LogGrpc indicates that Grpc logs should be captured. The default is true.
 	// This is not exposed through the command-line flags, as this flag is mainly useful for testing: Grpc
 	// stack will hold on to the logger even though it gets closed. This causes data races.
 	LogGrpc bool
 
+	// DroppedSummaryInterval controls how often a summary of the entries dropped by
+	// sinks, sampling or rate limiting is logged. The default is to not log summaries.
+	DroppedSummaryInterval time.Duration
+
+	// SampleRates is a comma-separated list of <scope>:<level>:<rate> settings, setting
+	// the fraction of the entries of a level which are emitted by a scope, as with
+	// Scope.SetSampleRate. The scope can be omitted for the default scope, and be replaced
+	// by the name of a scope group, or by "all" for all the scopes. The default is to
+	// emit all the entries.
+	SampleRates string
+
+	// TraceSampledOnly makes the scopes obtained through WithContext emit debug and info
+	// entries only when the trace of the context is sampled, or its sampling decision is
+	// unknown, aligning the verbose output with the traces which can be inspected. See
+	// SetTraceSampledFunc for how the decision is found.
+	TraceSampledOnly bool
+
+	// ContextErrorFields makes the error entries of the scopes obtained through WithContext
+	// describe the state of their context: the error of the context once it's canceled or
+	// expired, under ContextErrKey, or the time left before its deadline when it's below
+	// ContextDeadlineThreshold, under ContextDeadlineRemainingKey. This helps debugging
+	// cascades of timeouts. It applies to the scopes obtained while it's set.
+	ContextErrorFields bool
+
+	// ContextDeadlineThreshold is the time left before the deadline of a context below which
+	// it's reported by the error entries, when ContextErrorFields is set.
+	ContextDeadlineThreshold time.Duration
+
+	// GoroutineID adds the identifier of the logging goroutine to every entry, which helps
+	// debugging the interleaving of concurrent work. Finding the identifier is costly, so
+	// this is disabled by default. See ContextWithWorkerID for a cheaper alternative.
+	GoroutineID bool
+
+	// ScopeDescriptions adds the description of the scope of every entry, as given to
+	// RegisterScope, under the ScopeDescriptionKey field, for pipelines filtering entries by
+	// subsystem. The name of the scope is always part of the entries of the scopes other
+	// than the default one. The default is to leave the descriptions out.
+	ScopeDescriptions bool
+
+	// MessageTemplates renders the {key} placeholders of the messages with the values of the
+	// fields of the same keys, which are still emitted as fields, e.g.
+	//
+	//	s.Infow("user {user} logged in", "user", id)
+	//
+	// Placeholders without a matching field are left as they are.
+	MessageTemplates bool
+
+	// StrictFormat reports the printf-style calls, such as Infof, whose number of arguments
+	// doesn't match the verbs of their template to the error output, which helps catching
+	// them during development. Such messages are logged either way, with the extra
+	// arguments appended and the verbs missing an argument left as they are.
+	StrictFormat bool
+
+	// AuditLevelChanges logs an entry whenever the output level of a scope changes at
+	// runtime, recording the scope, the old and new levels, what changed it and who, if
+	// known. See Scope.SetOutputLevelFrom. The entries are logged through the default
+	// scope, whatever its output level.
+	AuditLevelChanges bool
+
+	// AuditChain adds a field to every audit entry logged because of AuditLevelChanges,
+	// chaining it to the previous one with a digest of the previous entry, so that altered,
+	// inserted or removed audit entries are detected when reviewing the log with VerifyChain.
+	// The chain starts anew with every call to Configure.
+	AuditChain bool
+
+	// AuditChainKey is the secret key of the HMAC-SHA256 digests chaining the audit entries
+	// when AuditChain is set. Without it, the digests are plain SHA-256 ones, which anyone
+	// can recompute after altering the log.
+	AuditChainKey []byte
+
+	// AutoRegisterScopes makes FindScope register the scopes it doesn't find rather than
+	// returning nil, so that entries aren't lost to a lookup made before the registration
+	// or to a typo, which ScopeMisses still reports.
+	AutoRegisterScopes bool
+
+	// StormRate is the number of entries per second above which a scope is considered in a
+	// log storm. During a storm, the debug and info entries of the scope are dropped, with
+	// a notice logged when the storm starts and when it ends, which protects the disks and
+	// collectors from runaway loops. The default is to not limit the rate of entries.
+	StormRate int
+
+	// Strict makes the malformed logging calls panic, such as key/value lists of odd length,
+	// keys which aren't strings, duplicate keys or printf-style calls whose arguments don't
+	// match their template, so that they're caught by tests rather than in production logs.
+	// It is meant for development. See SetMisuseHandler for reacting to them otherwise.
+	Strict bool
+
+	// ErrorSummaryInterval turns on the aggregation of error entries. Error entries are
+	// fingerprinted by scope, message template and error type, counted over a sliding
+	// window of this duration, and a summary of the repeated errors is logged at the end
+	// of each window. The counts are also available through ErrorCounts. The default is
+	// to not aggregate error entries.
+	ErrorSummaryInterval time.Duration
+
+	// CrashDumpDir is the directory where a crash report is written when Fatal is called
+	// or a panic is handled by RecoverAndCrash. A report holds the most recent log entries,
+	// the stacks of all goroutines and build information. The default is to not write
+	// crash reports.
+	CrashDumpDir string
+
+	// CrashDumpSuppressed keeps the entries below the output level of their scope in the
+	// crash reports, without writing them anywhere else, so that lowering the level of a
+	// scope, even to none, doesn't lose the recent history reported when the process
+	// crashes. These entries are then built, but neither written nor counted, nor reported
+	// as misuse. Every call below the output level then pays for formatting its message and
+	// fields, including the field processors such as pseudonymization, rather than returning
+	// right away. It has no effect unless CrashDumpDir is set.
+	CrashDumpSuppressed bool
+
+	// RecentEntries is the number of recent entries kept in memory for Query, and for the
+	// crash reports, which keep 100 entries when it's lower. The default is to keep entries
+	// only for crash reports.
+	RecentEntries int
+
 	outputLevels     string
 	logCallers       string
 	stackTraceLevels string
@@ -309,9 +606,9 @@ func convertScopedLevel(sl string) (string, Level, error) {
 		return "", NoneLevel, fmt.Errorf("invalid output level format '%s'", sl)
 	}
 
-	level, ok := stringToLevel[l]
-	if !ok {
-		return "", NoneLevel, fmt.Errorf("invalid output level '%s'", sl)
+	level, err := ParseLevel(l)
+	if err != nil {
+		return "", NoneLevel, fmt.Errorf("invalid output level '%s': %v", sl, err)
 	}
 
 	return s, level, nil
@@ -331,7 +628,14 @@ func (o *Options) AttachFlags(cmd *cobra.Command) {
 // FlagSet should be provided explicitly, failure to do so will result in a panic.
 func (o *Options) AttachToFlagSet(fs *pflag.FlagSet) *pflag.FlagSet {
 	fs.StringArrayVar(&o.OutputPaths, "log-target", o.OutputPaths,
-		"The set of paths where to output the log. This can be any path as well as the special values stdout and stderr")
+		"The set of paths where to output the log. This can be any path as well as the special values stdout and stderr, "+
+			"or a tcp:// or udp:// URL of a remote collector")
+
+	fs.StringArrayVar(&o.JSONOutputPaths, "log-json-target", o.JSONOutputPaths,
+		"The set of paths where to also output the log as JSON, whatever the format of the other paths")
+
+	fs.StringToStringVar(&o.Resource, "log-resource", o.Resource,
+		"The attributes of the resource added to the JSON entries, such as service.name=api,service.version=1.2")
 
 	fs.StringVar(&o.RotateOutputPath, "log-rotate", o.RotateOutputPath,
 		"The path for the optional rotating log file")
@@ -345,9 +649,103 @@ func (o *Options) AttachToFlagSet(fs *pflag.FlagSet) *pflag.FlagSet {
 	fs.IntVar(&o.RotationMaxBackups, "log-rotate-max-backups", o.RotationMaxBackups,
 		"The maximum number of log file backups to keep before older files are deleted (0 indicates no limit)")
 
+	fs.BoolVar(&o.ReopenOnSIGHUP, "log-reopen-on-sighup", o.ReopenOnSIGHUP,
+		"Whether to reopen the log files when receiving SIGHUP, as expected by logrotate")
+
+	fs.BoolVar(&o.LockFiles, "log-lock-files", o.LockFiles,
+		"Whether to lock the log files while writing to them, for files shared by several processes")
+
+	fs.DurationVar(&o.TimedOutputMaxAge, "log-timed-output-max-age", o.TimedOutputMaxAge,
+		"The age beyond which the log files named after the time are deleted (0 indicates no limit)")
+
+	fs.IntVar(&o.TimedOutputMaxFiles, "log-timed-output-max-files", o.TimedOutputMaxFiles,
+		"The maximum number of log files named after the time to keep (0 indicates no limit)")
+
+	fs.BoolVar(&o.EncryptArchives, "log-encrypt-archives", o.EncryptArchives,
+		"Whether to encrypt the rotated log files, with the key in "+ArchiveKeyEnvVar)
+
 	fs.BoolVar(&o.JSONEncoding, "log-as-json", o.JSONEncoding,
 		"Whether to format output as JSON or in plain console-friendly format")
 
+	fs.BoolVar(&o.Pretty, "log-pretty", o.Pretty,
+		"Whether to format output for developers, with each field on its own line")
+
+	fs.StringVar(&o.TextTemplate, "log-template", o.TextTemplate,
+		"The layout of each line, such as '{time} [{LEVEL}] {scope} {msg} {fields}'")
+
+	fs.BoolVar(&o.CSVEncoding, "log-as-csv", o.CSVEncoding,
+		"Whether to format output as comma-separated values")
+
+	fs.BoolVar(&o.TSVEncoding, "log-as-tsv", o.TSVEncoding,
+		"Whether to format output as tab-separated values")
+
+	fs.StringSliceVar(&o.CSVColumns, "log-csv-columns", o.CSVColumns,
+		"The columns output as comma or tab-separated values, such as time,level,scope,msg,request_id")
+
+	fs.BoolVar(&o.MsgpackEncoding, "log-as-msgpack", o.MsgpackEncoding,
+		"Whether to format output as MessagePack, a compact binary format")
+
+	fs.StringVar(&o.LinePrefix, "log-line-prefix", o.LinePrefix,
+		"The text written at the start of every line of the outputs")
+
+	fs.StringVar(&o.LineSuffix, "log-line-suffix", o.LineSuffix,
+		"The text written at the end of every line of the outputs")
+
+	fs.DurationVar(&o.DroppedSummaryInterval, "log-dropped-summary-interval", o.DroppedSummaryInterval,
+		"How often to log a summary of dropped log entries (0 disables the summary)")
+
+	fs.StringVar(&o.SampleRates, "log-sample-rate", o.SampleRates,
+		"Comma-separated list of fractions of the entries to emit, per scope and level, in the form of "+
+			"<scope>:<level>:<rate>, e.g. \"default:debug:0.01,default:info:0.1\"")
+
+	fs.BoolVar(&o.TraceSampledOnly, "log-trace-sampled-only", o.TraceSampledOnly,
+		"Whether to only emit the debug and info entries bound to sampled traces")
+
+	fs.BoolVar(&o.ContextErrorFields, "log-context-error-fields", o.ContextErrorFields,
+		"Whether error entries bound to a context report its cancellation or the time left before its deadline")
+
+	fs.DurationVar(&o.ContextDeadlineThreshold, "log-context-deadline-threshold", o.ContextDeadlineThreshold,
+		"The time left before the deadline of a context below which error entries report it")
+
+	fs.BoolVar(&o.GoroutineID, "log-goroutine-id", o.GoroutineID,
+		"Whether to add the identifier of the logging goroutine to every entry")
+
+	fs.BoolVar(&o.ScopeDescriptions, "log-scope-descriptions", o.ScopeDescriptions,
+		"Whether to add the description of their scope to every entry")
+
+	fs.BoolVar(&o.MessageTemplates, "log-message-templates", o.MessageTemplates,
+		"Whether to render the {key} placeholders of messages with the values of the fields of the same keys")
+
+	fs.BoolVar(&o.StrictFormat, "log-strict-format", o.StrictFormat,
+		"Whether to report printf-style calls whose arguments don't match their format to the error output")
+
+	fs.BoolVar(&o.AuditLevelChanges, "log-audit-level-changes", o.AuditLevelChanges,
+		"Whether to log an entry whenever the output level of a scope changes")
+
+	fs.BoolVar(&o.AuditChain, "log-audit-chain", o.AuditChain,
+		"Whether to chain the audit entries with digests, so that tampering is detected")
+
+	fs.BoolVar(&o.AutoRegisterScopes, "log-auto-register-scopes", o.AutoRegisterScopes,
+		"Whether to register the scopes looked up before being registered")
+
+	fs.IntVar(&o.StormRate, "log-storm-rate", o.StormRate,
+		"The number of entries per second and scope above which debug and info entries are dropped (0 disables the limit)")
+
+	fs.BoolVar(&o.Strict, "log-strict", o.Strict,
+		"Whether to panic on malformed logging calls, such as key/value lists of odd length (for development)")
+
+	fs.DurationVar(&o.ErrorSummaryInterval, "log-error-summary-interval", o.ErrorSummaryInterval,
+		"How often to log a summary of repeated errors (0 disables the aggregation of errors)")
+
+	fs.StringVar(&o.CrashDumpDir, "log-crash-dump-dir", o.CrashDumpDir,
+		"The directory where to write a crash report when the process terminates on a fatal error")
+
+	fs.BoolVar(&o.CrashDumpSuppressed, "log-crash-dump-suppressed", o.CrashDumpSuppressed,
+		"Whether crash reports include the recent entries below the output level of their scope")
+
+	fs.IntVar(&o.RecentEntries, "log-recent-entries", o.RecentEntries,
+		"The number of recent entries kept in memory for queries, such as those of the debug endpoint")
+
 	allScopes := Scopes()
 	if len(allScopes) > 1 {
 		keys := make([]string, 0, len(allScopes))
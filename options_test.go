@@ -17,10 +17,12 @@
 package log
 
 import (
+	"encoding/json"
 	"reflect"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -44,6 +46,358 @@ func TestOpts(t *testing.T) {
 			LogGrpc:            true,
 		}},
 
+		{"--log-json-target /tmp/app.json", Options{
+			OutputPaths:        []string{defaultOutputPath},
+			ErrorOutputPaths:   []string{defaultErrorOutputPath},
+			JSONOutputPaths:    []string{"/tmp/app.json"},
+			outputLevels:       DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:   DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			RotationMaxAge:     defaultRotationMaxAge,
+			RotationMaxSize:    defaultRotationMaxSize,
+			RotationMaxBackups: defaultRotationMaxBackups,
+			LogGrpc:            true,
+		}},
+
+		{"--log-resource service.name=api", Options{
+			OutputPaths:        []string{defaultOutputPath},
+			ErrorOutputPaths:   []string{defaultErrorOutputPath},
+			Resource:           map[string]string{"service.name": "api"},
+			outputLevels:       DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:   DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			RotationMaxAge:     defaultRotationMaxAge,
+			RotationMaxSize:    defaultRotationMaxSize,
+			RotationMaxBackups: defaultRotationMaxBackups,
+			LogGrpc:            true,
+		}},
+
+		{"--log-reopen-on-sighup", Options{
+			OutputPaths:        []string{defaultOutputPath},
+			ErrorOutputPaths:   []string{defaultErrorOutputPath},
+			outputLevels:       DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:   DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			RotationMaxAge:     defaultRotationMaxAge,
+			RotationMaxSize:    defaultRotationMaxSize,
+			RotationMaxBackups: defaultRotationMaxBackups,
+			ReopenOnSIGHUP:     true,
+			LogGrpc:            true,
+		}},
+
+		{"--log-lock-files", Options{
+			OutputPaths:        []string{defaultOutputPath},
+			ErrorOutputPaths:   []string{defaultErrorOutputPath},
+			outputLevels:       DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:   DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			RotationMaxAge:     defaultRotationMaxAge,
+			RotationMaxSize:    defaultRotationMaxSize,
+			RotationMaxBackups: defaultRotationMaxBackups,
+			LockFiles:          true,
+			LogGrpc:            true,
+		}},
+
+		{"--log-timed-output-max-age 168h --log-timed-output-max-files 7", Options{
+			OutputPaths:         []string{defaultOutputPath},
+			ErrorOutputPaths:    []string{defaultErrorOutputPath},
+			outputLevels:        DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:    DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			RotationMaxAge:      defaultRotationMaxAge,
+			RotationMaxSize:     defaultRotationMaxSize,
+			RotationMaxBackups:  defaultRotationMaxBackups,
+			TimedOutputMaxAge:   7 * 24 * time.Hour,
+			TimedOutputMaxFiles: 7,
+			LogGrpc:             true,
+		}},
+
+		{"--log-encrypt-archives", Options{
+			OutputPaths:        []string{defaultOutputPath},
+			ErrorOutputPaths:   []string{defaultErrorOutputPath},
+			outputLevels:       DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:   DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			RotationMaxAge:     defaultRotationMaxAge,
+			RotationMaxSize:    defaultRotationMaxSize,
+			RotationMaxBackups: defaultRotationMaxBackups,
+			EncryptArchives:    true,
+			LogGrpc:            true,
+		}},
+
+		{"--log-pretty", Options{
+			OutputPaths:        []string{defaultOutputPath},
+			ErrorOutputPaths:   []string{defaultErrorOutputPath},
+			outputLevels:       DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:   DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			Pretty:             true,
+			RotationMaxAge:     defaultRotationMaxAge,
+			RotationMaxSize:    defaultRotationMaxSize,
+			RotationMaxBackups: defaultRotationMaxBackups,
+			LogGrpc:            true,
+		}},
+
+		{"--log-template [{LEVEL}]{msg}", Options{
+			OutputPaths:        []string{defaultOutputPath},
+			ErrorOutputPaths:   []string{defaultErrorOutputPath},
+			outputLevels:       DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:   DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			TextTemplate:       "[{LEVEL}]{msg}",
+			RotationMaxAge:     defaultRotationMaxAge,
+			RotationMaxSize:    defaultRotationMaxSize,
+			RotationMaxBackups: defaultRotationMaxBackups,
+			LogGrpc:            true,
+		}},
+
+		{"--log-as-csv --log-csv-columns time,msg,request_id", Options{
+			OutputPaths:        []string{defaultOutputPath},
+			ErrorOutputPaths:   []string{defaultErrorOutputPath},
+			outputLevels:       DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:   DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			CSVEncoding:        true,
+			CSVColumns:         []string{"time", "msg", "request_id"},
+			RotationMaxAge:     defaultRotationMaxAge,
+			RotationMaxSize:    defaultRotationMaxSize,
+			RotationMaxBackups: defaultRotationMaxBackups,
+			LogGrpc:            true,
+		}},
+
+		{"--log-as-tsv", Options{
+			OutputPaths:        []string{defaultOutputPath},
+			ErrorOutputPaths:   []string{defaultErrorOutputPath},
+			outputLevels:       DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:   DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			TSVEncoding:        true,
+			RotationMaxAge:     defaultRotationMaxAge,
+			RotationMaxSize:    defaultRotationMaxSize,
+			RotationMaxBackups: defaultRotationMaxBackups,
+			LogGrpc:            true,
+		}},
+
+		{"--log-as-msgpack", Options{
+			OutputPaths:        []string{defaultOutputPath},
+			ErrorOutputPaths:   []string{defaultErrorOutputPath},
+			outputLevels:       DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:   DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			MsgpackEncoding:    true,
+			RotationMaxAge:     defaultRotationMaxAge,
+			RotationMaxSize:    defaultRotationMaxSize,
+			RotationMaxBackups: defaultRotationMaxBackups,
+			LogGrpc:            true,
+		}},
+
+		{"--log-line-prefix [app] --log-line-suffix ;", Options{
+			OutputPaths:        []string{defaultOutputPath},
+			ErrorOutputPaths:   []string{defaultErrorOutputPath},
+			outputLevels:       DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:   DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			LinePrefix:         "[app]",
+			LineSuffix:         ";",
+			RotationMaxAge:     defaultRotationMaxAge,
+			RotationMaxSize:    defaultRotationMaxSize,
+			RotationMaxBackups: defaultRotationMaxBackups,
+			LogGrpc:            true,
+		}},
+
+		{"--log-recent-entries 500", Options{
+			OutputPaths:        []string{defaultOutputPath},
+			ErrorOutputPaths:   []string{defaultErrorOutputPath},
+			outputLevels:       DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:   DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			RecentEntries:      500,
+			RotationMaxAge:     defaultRotationMaxAge,
+			RotationMaxSize:    defaultRotationMaxSize,
+			RotationMaxBackups: defaultRotationMaxBackups,
+			LogGrpc:            true,
+		}},
+
+		{"--log-audit-chain", Options{
+			OutputPaths:        []string{defaultOutputPath},
+			ErrorOutputPaths:   []string{defaultErrorOutputPath},
+			outputLevels:       DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:   DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			AuditChain:         true,
+			RotationMaxAge:     defaultRotationMaxAge,
+			RotationMaxSize:    defaultRotationMaxSize,
+			RotationMaxBackups: defaultRotationMaxBackups,
+			LogGrpc:            true,
+		}},
+
+		{"--log-dropped-summary-interval 1m", Options{
+			OutputPaths:            []string{defaultOutputPath},
+			ErrorOutputPaths:       []string{defaultErrorOutputPath},
+			outputLevels:           DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:       DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			RotationMaxAge:         defaultRotationMaxAge,
+			RotationMaxSize:        defaultRotationMaxSize,
+			RotationMaxBackups:     defaultRotationMaxBackups,
+			LogGrpc:                true,
+			DroppedSummaryInterval: time.Minute,
+		}},
+
+		{"--log-sample-rate default:debug:0.01", Options{
+			OutputPaths:        []string{defaultOutputPath},
+			ErrorOutputPaths:   []string{defaultErrorOutputPath},
+			outputLevels:       DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:   DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			RotationMaxAge:     defaultRotationMaxAge,
+			RotationMaxSize:    defaultRotationMaxSize,
+			RotationMaxBackups: defaultRotationMaxBackups,
+			LogGrpc:            true,
+			SampleRates:        "default:debug:0.01",
+		}},
+
+		{"--log-trace-sampled-only", Options{
+			OutputPaths:        []string{defaultOutputPath},
+			ErrorOutputPaths:   []string{defaultErrorOutputPath},
+			outputLevels:       DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:   DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			RotationMaxAge:     defaultRotationMaxAge,
+			RotationMaxSize:    defaultRotationMaxSize,
+			RotationMaxBackups: defaultRotationMaxBackups,
+			LogGrpc:            true,
+			TraceSampledOnly:   true,
+		}},
+
+		{"--log-context-error-fields --log-context-deadline-threshold 50ms", Options{
+			OutputPaths:              []string{defaultOutputPath},
+			ErrorOutputPaths:         []string{defaultErrorOutputPath},
+			outputLevels:             DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:         DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			RotationMaxAge:           defaultRotationMaxAge,
+			RotationMaxSize:          defaultRotationMaxSize,
+			RotationMaxBackups:       defaultRotationMaxBackups,
+			LogGrpc:                  true,
+			ContextErrorFields:       true,
+			ContextDeadlineThreshold: 50 * time.Millisecond,
+		}},
+
+		{"--log-goroutine-id", Options{
+			OutputPaths:        []string{defaultOutputPath},
+			ErrorOutputPaths:   []string{defaultErrorOutputPath},
+			outputLevels:       DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:   DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			RotationMaxAge:     defaultRotationMaxAge,
+			RotationMaxSize:    defaultRotationMaxSize,
+			RotationMaxBackups: defaultRotationMaxBackups,
+			LogGrpc:            true,
+			GoroutineID:        true,
+		}},
+
+		{"--log-scope-descriptions", Options{
+			OutputPaths:        []string{defaultOutputPath},
+			ErrorOutputPaths:   []string{defaultErrorOutputPath},
+			outputLevels:       DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:   DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			RotationMaxAge:     defaultRotationMaxAge,
+			RotationMaxSize:    defaultRotationMaxSize,
+			RotationMaxBackups: defaultRotationMaxBackups,
+			LogGrpc:            true,
+			ScopeDescriptions:  true,
+		}},
+
+		{"--log-message-templates", Options{
+			OutputPaths:        []string{defaultOutputPath},
+			ErrorOutputPaths:   []string{defaultErrorOutputPath},
+			outputLevels:       DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:   DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			RotationMaxAge:     defaultRotationMaxAge,
+			RotationMaxSize:    defaultRotationMaxSize,
+			RotationMaxBackups: defaultRotationMaxBackups,
+			LogGrpc:            true,
+			MessageTemplates:   true,
+		}},
+
+		{"--log-strict-format", Options{
+			OutputPaths:        []string{defaultOutputPath},
+			ErrorOutputPaths:   []string{defaultErrorOutputPath},
+			outputLevels:       DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:   DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			RotationMaxAge:     defaultRotationMaxAge,
+			RotationMaxSize:    defaultRotationMaxSize,
+			RotationMaxBackups: defaultRotationMaxBackups,
+			LogGrpc:            true,
+			StrictFormat:       true,
+		}},
+
+		{"--log-audit-level-changes", Options{
+			OutputPaths:        []string{defaultOutputPath},
+			ErrorOutputPaths:   []string{defaultErrorOutputPath},
+			outputLevels:       DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:   DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			RotationMaxAge:     defaultRotationMaxAge,
+			RotationMaxSize:    defaultRotationMaxSize,
+			RotationMaxBackups: defaultRotationMaxBackups,
+			LogGrpc:            true,
+			AuditLevelChanges:  true,
+		}},
+
+		{"--log-auto-register-scopes", Options{
+			OutputPaths:        []string{defaultOutputPath},
+			ErrorOutputPaths:   []string{defaultErrorOutputPath},
+			outputLevels:       DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:   DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			RotationMaxAge:     defaultRotationMaxAge,
+			RotationMaxSize:    defaultRotationMaxSize,
+			RotationMaxBackups: defaultRotationMaxBackups,
+			LogGrpc:            true,
+			AutoRegisterScopes: true,
+		}},
+
+		{"--log-storm-rate 1000", Options{
+			OutputPaths:        []string{defaultOutputPath},
+			ErrorOutputPaths:   []string{defaultErrorOutputPath},
+			outputLevels:       DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:   DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			RotationMaxAge:     defaultRotationMaxAge,
+			RotationMaxSize:    defaultRotationMaxSize,
+			RotationMaxBackups: defaultRotationMaxBackups,
+			LogGrpc:            true,
+			StormRate:          1000,
+		}},
+
+		{"--log-strict", Options{
+			OutputPaths:        []string{defaultOutputPath},
+			ErrorOutputPaths:   []string{defaultErrorOutputPath},
+			outputLevels:       DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:   DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			RotationMaxAge:     defaultRotationMaxAge,
+			RotationMaxSize:    defaultRotationMaxSize,
+			RotationMaxBackups: defaultRotationMaxBackups,
+			LogGrpc:            true,
+			Strict:             true,
+		}},
+
+		{"--log-error-summary-interval 1m", Options{
+			OutputPaths:          []string{defaultOutputPath},
+			ErrorOutputPaths:     []string{defaultErrorOutputPath},
+			outputLevels:         DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:     DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			RotationMaxAge:       defaultRotationMaxAge,
+			RotationMaxSize:      defaultRotationMaxSize,
+			RotationMaxBackups:   defaultRotationMaxBackups,
+			LogGrpc:              true,
+			ErrorSummaryInterval: time.Minute,
+		}},
+
+		{"--log-crash-dump-dir /tmp/crashes", Options{
+			OutputPaths:        []string{defaultOutputPath},
+			ErrorOutputPaths:   []string{defaultErrorOutputPath},
+			outputLevels:       DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:   DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			RotationMaxAge:     defaultRotationMaxAge,
+			RotationMaxSize:    defaultRotationMaxSize,
+			RotationMaxBackups: defaultRotationMaxBackups,
+			LogGrpc:            true,
+			CrashDumpDir:       "/tmp/crashes",
+		}},
+
+		{"--log-crash-dump-suppressed", Options{
+			OutputPaths:         []string{defaultOutputPath},
+			ErrorOutputPaths:    []string{defaultErrorOutputPath},
+			outputLevels:        DefaultScopeName + ":" + levelToString[defaultOutputLevel],
+			stackTraceLevels:    DefaultScopeName + ":" + levelToString[defaultStackTraceLevel],
+			RotationMaxAge:      defaultRotationMaxAge,
+			RotationMaxSize:     defaultRotationMaxSize,
+			RotationMaxBackups:  defaultRotationMaxBackups,
+			LogGrpc:             true,
+			CrashDumpSuppressed: true,
+		}},
+
 		{"--log-target stdout --log-target stderr", Options{
 			OutputPaths:        []string{"stdout", "stderr"},
 			ErrorOutputPaths:   []string{defaultErrorOutputPath},
@@ -453,3 +807,98 @@ func TestLogCallers(t *testing.T) {
 		t.Error("Expecting false")
 	}
 }
+
+func TestLevelText(t *testing.T) {
+	for l, name := range levelToString {
+		b, err := l.MarshalText()
+		if err != nil || string(b) != name {
+			t.Errorf("Got (%s, %v), expected (%s, nil)", b, err, name)
+		}
+
+		var got Level
+		if err = got.UnmarshalText(b); err != nil || got != l {
+			t.Errorf("Got (%v, %v), expected (%v, nil)", got, err, l)
+		}
+	}
+
+	if _, err := Level(42).MarshalText(); err == nil {
+		t.Error("Got success, expecting an error")
+	}
+
+	var l Level
+	if err := l.UnmarshalText([]byte("verbose")); err == nil {
+		t.Error("Got success, expecting an error")
+	}
+}
+
+func TestLevelJSON(t *testing.T) {
+	type config struct {
+		Levels map[string]Level `json:"levels"`
+	}
+
+	in := config{Levels: map[string]Level{"default": InfoLevel, "cache": DebugLevel}}
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Got err '%v', expecting success", err)
+	}
+
+	if want := `{"levels":{"cache":"debug","default":"info"}}`; string(b) != want {
+		t.Errorf("Got %s, expected %s", b, want)
+	}
+
+	var out config
+	if err = json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("Got err '%v', expecting success", err)
+	}
+
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("Got %v, expected %v", out, in)
+	}
+
+	if err = json.Unmarshal([]byte(`{"levels":{"default":"loud"}}`), &out); err == nil {
+		t.Error("Got success, expecting an error")
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	cases := []struct {
+		name  string
+		level Level
+		ok    bool
+	}{
+		{"debug", DebugLevel, true},
+		{"DEBUG", DebugLevel, true},
+		{" Info ", InfoLevel, true},
+		{"trace", DebugLevel, true},
+		{"warning", WarnLevel, true},
+		{"Warn", WarnLevel, true},
+		{"err", ErrorLevel, true},
+		{"off", NoneLevel, true},
+		{"disabled", NoneLevel, true},
+		{"verbose", NoneLevel, false},
+		{"", NoneLevel, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			l, err := ParseLevel(c.name)
+			if (err == nil) != c.ok || l != c.level {
+				t.Errorf("Got (%v, %v), expected (%v, ok=%v)", l, err, c.level, c.ok)
+			}
+
+			if err != nil && !strings.Contains(err.Error(), "debug, info, warn, error, none") {
+				t.Errorf("Got '%v', expected the valid levels to be listed", err)
+			}
+
+			if l2, ok := LevelFrom(c.name); ok != c.ok || l2 != c.level {
+				t.Errorf("Got (%v, %v), expected (%v, %v)", l2, ok, c.level, c.ok)
+			}
+		})
+	}
+
+	o := DefaultOptions()
+	o.outputLevels = "default:WARNING"
+	if l, err := o.GetOutputLevel(DefaultScopeName); err != nil || l != WarnLevel {
+		t.Errorf("Got (%v, %v), expected (%v, nil)", l, err, WarnLevel)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// outputs holds what's needed to encode and write entries as configured for the process.
+type outputs struct {
+	format   Format
+	encoders map[Format]zapcore.Encoder
+	sink     zapcore.WriteSyncer
+	jsonSink zapcore.WriteSyncer
+	files    []*ReopenableFile
+
+	// the shared and the JSON outputs together, for the entries of the scopes whose format
+	// is JSON, which are encoded once for both
+	jsonTee zapcore.WriteSyncer
+
+	// the line prefix and suffix of the options, without a destination
+	affix affixSink
+}
+
+// scopeOutput wraps a destination set with SetOutput or chosen by the router like the
+// shared outputs, with the line prefix and suffix, and counts the bytes written to it.
+func (out *outputs) scopeOutput(ws zapcore.WriteSyncer) zapcore.WriteSyncer {
+	if len(out.affix.prefix) != 0 || len(out.affix.suffix) != 0 {
+		a := out.affix
+		a.WriteSyncer = ws
+		ws = a
+	}
+	return countingSink{ws}
+}
+
+// set by the Configure method
+var currentOutputs atomic.Value
+
+// outputHolder lets an atomic.Value hold any zapcore.WriteSyncer, including none.
+type outputHolder struct {
+	ws zapcore.WriteSyncer
+}
+
+// SetOutput makes the scope write its entries to the given destination instead of the
+// outputs shared by all scopes, for example to send the entries of an access scope to
+// a dedicated file. Use nil to revert to the shared outputs.
+//
+// The destination is flushed by Sync, but it is never closed by this package.
+func (s *Scope) SetOutput(ws zapcore.WriteSyncer) {
+	s.output.Store(outputHolder{ws})
+}
+
+// GetOutput returns the destination set for the scope, or nil if it writes to the shared outputs.
+func (s *Scope) GetOutput() zapcore.WriteSyncer {
+	return s.output.Load().(outputHolder).ws
+}
+
+// write encodes an entry in the format of the scope and writes it to the destination
+// chosen by the router, or to the scope's output.
+func (s *Scope) write(e zapcore.Entry, fields []zapcore.Field) error {
+	out, _ := currentOutputs.Load().(*outputs)
+	if out == nil {
+		return nil
+	}
+
+	f := s.GetFormat()
+	if f == DefaultFormat {
+		f = out.format
+	}
+
+	ws := route(s.ctx, e, fields)
+	if ws == nil {
+		ws = s.GetOutput()
+	}
+	if ws != nil {
+		ws = out.scopeOutput(ws)
+	} else {
+		ws = out.sink
+
+		// the shared outputs include the JSON ones
+		if out.jsonTee != nil && f == JSONFormat {
+			ws = out.jsonTee
+		} else if out.jsonSink != nil {
+			if err := writeEntry(out.encoders[JSONFormat], out.jsonSink, e, fields); err != nil || ws == nil {
+				return err
+			}
+		}
+	}
+
+	if s.chain != nil {
+		return s.chain.write(out.encoders[f], ws, e, fields)
+	}
+
+	return writeEntry(out.encoders[f], ws, e, fields)
+}
+
+func writeEntry(enc zapcore.Encoder, ws zapcore.WriteSyncer, e zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := enc.EncodeEntry(e, fields)
+	if err != nil {
+		return err
+	}
+
+	_, err = ws.Write(buf.Bytes())
+	buf.Free()
+
+	return err
+}
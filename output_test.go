@@ -0,0 +1,72 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestScopeOutput(t *testing.T) {
+	access := RegisterScope("TestScopeOutput", "", 0)
+	defer access.SetOutput(nil)
+	defer access.SetFormat(DefaultFormat)
+
+	other := RegisterScope("TestScopeOutputOther", "", 0)
+
+	var buf bytes.Buffer
+	lines, err := captureStdout(func() {
+		_ = Configure(DefaultOptions())
+
+		access.SetOutput(zapcore.AddSync(&buf))
+		access.Info("to the buffer")
+		other.Info("to stdout")
+
+		access.SetFormat(JSONFormat)
+		access.Info("as json")
+
+		access.SetOutput(nil)
+		access.Info("back to stdout")
+		_ = Sync()
+	})
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	got := buf.String()
+	for _, pat := range []string{
+		timePattern + "\tinfo\tTestScopeOutput\tto the buffer\n",
+		`{"level":"info","time":"` + timePattern + `","scope":"TestScopeOutput","msg":"as json"}`,
+	} {
+		if match, _ := regexp.MatchString(pat, got); !match {
+			t.Errorf("Got '%v', expected a match with '%v'", got, pat)
+		}
+	}
+
+	if len(lines) != 3 {
+		t.Fatalf("Got %d lines on stdout, expected 2", len(lines)-1)
+	}
+
+	if match, _ := regexp.MatchString("TestScopeOutputOther\tto stdout", lines[0]); !match {
+		t.Errorf("Got '%v', expected the entry of the other scope", lines[0])
+	}
+
+	if match, _ := regexp.MatchString(`"msg":"back to stdout"`, lines[1]); !match {
+		t.Errorf("Got '%v', expected the entry logged after reverting", lines[1])
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// PanicKey is the key of the field produced by PanicValue.
+const PanicKey = "panic"
+
+// maxPanicCauses bounds the number of wrapped errors output by PanicValue.
+const maxPanicCauses = 10
+
+// PanicValue constructs a field describing a value recovered from a panic, as logged by
+// Recover. Strings and fmt.Stringer values are output as strings, and numbers and booleans
+// as they are. Errors are output as objects holding their type and message, the messages
+// of the errors they wrap, and whether they are runtime errors, such as nil dereferences.
+// Other values are output as objects holding their type and their %+v rendering. Methods
+// panicking themselves are reported rather than propagated.
+func PanicValue(v interface{}) zapcore.Field {
+	switch v := v.(type) {
+	case nil:
+		return zap.String(PanicKey, "nil")
+	case string:
+		return zap.String(PanicKey, v)
+	case error:
+		return zap.Object(PanicKey, panicError{v})
+	case fmt.Stringer:
+		return zap.String(PanicKey, safeString(v.String))
+	}
+
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return zap.Any(PanicKey, v)
+	}
+
+	return zap.Object(PanicKey, panicOther{v})
+}
+
+// safeString returns the result of fn, or a description of the panic it raised.
+func safeString(fn func() string) (s string) {
+	defer func() {
+		if r := recover(); r != nil {
+			s = fmt.Sprintf("(panic while rendering: %v)", r)
+		}
+	}()
+
+	return fn()
+}
+
+type panicError struct {
+	err error
+}
+
+func (p panicError) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("type", fmt.Sprintf("%T", p.err))
+	enc.AddString("message", safeString(p.err.Error))
+
+	var re runtime.Error
+	if errors.As(p.err, &re) {
+		enc.AddBool("runtime", true)
+	}
+
+	var causes []string
+	for cause := errors.Unwrap(p.err); cause != nil && len(causes) < maxPanicCauses; cause = errors.Unwrap(cause) {
+		causes = append(causes, safeString(cause.Error))
+	}
+	if len(causes) > 0 {
+		return enc.AddArray("causes", zapcore.ArrayMarshalerFunc(func(ae zapcore.ArrayEncoder) error {
+			for _, c := range causes {
+				ae.AppendString(c)
+			}
+			return nil
+		}))
+	}
+
+	return nil
+}
+
+type panicOther struct {
+	v interface{}
+}
+
+func (p panicOther) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("type", fmt.Sprintf("%T", p.v))
+	enc.AddString("value", safeString(func() string { return fmt.Sprintf("%+v", p.v) }))
+
+	return nil
+}
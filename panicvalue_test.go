@@ -0,0 +1,81 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+type panicStringer struct{}
+
+func (panicStringer) String() string { return "stringer" }
+
+type panicStruct struct {
+	A int
+}
+
+func runtimeError() (err error) {
+	defer func() { err = recover().(error) }()
+	var m map[string]int
+	m["a"] = 1
+	return nil
+}
+
+func TestPanicValue(t *testing.T) {
+	wrapped := fmt.Errorf("outer: %w", fmt.Errorf("middle: %w", errors.New("inner")))
+
+	cases := []struct {
+		value    interface{}
+		expected interface{}
+	}{
+		{"boom", "boom"},
+		{nil, "nil"},
+		{42, 42},
+		{panicStringer{}, "stringer"},
+		{errors.New("failed"), map[string]interface{}{"type": "*errors.errorString", "message": "failed"}},
+		{wrapped, map[string]interface{}{
+			"type":    "*fmt.wrapError",
+			"message": "outer: middle: inner",
+			"causes":  []interface{}{"middle: inner", "inner"},
+		}},
+		{runtimeError(), map[string]interface{}{
+			"type":    "runtime.plainError",
+			"message": "assignment to entry in nil map",
+			"runtime": true,
+		}},
+		{panicStruct{1}, map[string]interface{}{"type": "log.panicStruct", "value": "{A:1}"}},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			f := PanicValue(c.value)
+			if f.Key != PanicKey {
+				t.Errorf("Got key %v, expected %v", f.Key, PanicKey)
+			}
+
+			enc := zapcore.NewMapObjectEncoder()
+			f.AddTo(enc)
+
+			if got := fmt.Sprint(enc.Fields[PanicKey]); got != fmt.Sprint(c.expected) {
+				t.Errorf("Got %v, expected %v", got, c.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !tinygo
+// +build !tinygo
+
+package log // nolint: golint
+
+import (
+	"bytes"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zapgrpc"
+	"google.golang.org/grpc/grpclog"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// This file holds the features relying on OS facilities or heavy dependencies which are
+// not available under TinyGo. See platform_tinygo.go for their TinyGo counterparts.
+
+// reset by the Configure method
+var sighupHandler struct {
+	sync.Mutex
+	stop chan struct{}
+}
+
+// startSighupHandler replaces any running handler with one that reopens the log files
+// whenever the process receives SIGHUP, if enabled.
+func startSighupHandler(enabled bool) {
+	sighupHandler.Lock()
+	defer sighupHandler.Unlock()
+
+	if sighupHandler.stop != nil {
+		close(sighupHandler.stop)
+		sighupHandler.stop = nil
+	}
+
+	if !enabled {
+		return
+	}
+
+	stop := make(chan struct{})
+	sighupHandler.stop = stop
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(c)
+
+		for {
+			select {
+			case <-c:
+				if err := Reopen(); err != nil {
+					Errorf("unable to reopen log files: %v", err)
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// newRotaterSink returns a sink writing to the rotating log file of the options, if any.
+func newRotaterSink(options *Options) (zapcore.WriteSyncer, error) {
+	if options.RotateOutputPath == "" {
+		return nil, nil
+	}
+
+	l := &lumberjack.Logger{
+		Filename:   options.RotateOutputPath,
+		MaxSize:    options.RotationMaxSize,
+		MaxBackups: options.RotationMaxAge,
+		MaxAge:     options.RotationMaxBackups,
+	}
+
+	if !options.EncryptArchives {
+		return zapcore.AddSync(l), nil
+	}
+
+	r := &encryptingRotater{Logger: l}
+	if info, err := os.Stat(l.Filename); err == nil {
+		r.size = info.Size()
+	}
+
+	// backups left unencrypted by a previous run
+	encryptArchivesAsync(r.backups)
+	return zapcore.AddSync(r), nil
+}
+
+// encryptingRotater encrypts the backups of a rotating log file as they are rotated out.
+type encryptingRotater struct {
+	*lumberjack.Logger
+
+	mu   sync.Mutex
+	size int64
+}
+
+// Write appends an entry, mirroring the size tracking of lumberjack to tell when it rotates.
+func (r *encryptingRotater) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	maxSize := int64(r.MaxSize) * 1024 * 1024
+	if maxSize == 0 {
+		// the lumberjack default
+		maxSize = 100 * 1024 * 1024
+	}
+	rotating := r.size+int64(len(p)) > maxSize
+
+	n, err := r.Logger.Write(p)
+	if rotating {
+		r.size = 0
+	}
+	r.size += int64(n)
+	r.mu.Unlock()
+
+	if rotating {
+		encryptArchivesAsync(r.backups)
+	}
+	return n, err
+}
+
+// the format of the timestamps of the backups named by lumberjack
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// backups returns the backups of the log file, named after it by lumberjack as
+// name-<timestamp>.ext. Other files matching name-*.ext, such as the live name-audit.ext
+// output, are left out.
+func (r *encryptingRotater) backups() []string {
+	ext := filepath.Ext(r.Filename)
+	prefix := strings.TrimSuffix(r.Filename, ext) + "-"
+
+	matches, _ := filepath.Glob(prefix + "*" + ext)
+
+	backups := matches[:0]
+	for _, m := range matches {
+		stamp := strings.TrimSuffix(strings.TrimPrefix(m, prefix), ext)
+		if _, err := time.Parse(backupTimeFormat, stamp); err == nil {
+			backups = append(backups, m)
+		}
+	}
+
+	return backups
+}
+
+// captureGrpc forces gRPC logging through the given logger.
+func captureGrpc(l *zap.Logger) {
+	// TODO(https://github.com/uber-go/zap/issues/534): remove the nolint directive
+	grpclog.SetLogger(zapgrpc.NewLogger(l.WithOptions(zap.AddCallerSkip(2)))) //nolint: megacheck
+}
+
+// allStacks returns the stacks of all goroutines.
+func allStacks() []byte {
+	buf := make([]byte, 64*1024)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// goroutineID returns the identifier of the calling goroutine, parsed from the header of
+// its stack trace, as the runtime doesn't expose it otherwise.
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+
+	// the header reads "goroutine <id> [<state>]:"
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	var id uint64
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			break
+		}
+		id = id*10 + uint64(c-'0')
+	}
+
+	return id
+}
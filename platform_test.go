@@ -0,0 +1,53 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !tinygo
+// +build !tinygo
+
+package log
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+func TestRotaterBackups(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"app.log",
+		"app-2020-01-01T10-20-30.000.log",
+		"app-2020-01-02T10-20-30.123.log",
+		"app-audit.log",
+		"app-2020-01-01.log",
+		"other-2020-01-01T10-20-30.000.log",
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := &encryptingRotater{Logger: &lumberjack.Logger{Filename: filepath.Join(dir, "app.log")}}
+
+	expected := []string{
+		filepath.Join(dir, "app-2020-01-01T10-20-30.000.log"),
+		filepath.Join(dir, "app-2020-01-02T10-20-30.123.log"),
+	}
+	if got := r.backups(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Got %v, expected %v", got, expected)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build tinygo
+// +build tinygo
+
+package log // nolint: golint
+
+import (
+	"errors"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// TinyGo, and proxy-wasm filters in particular, have neither signals nor goroutine stack
+// dumps, and can't afford the gRPC and rotation dependencies. The features relying on them
+// are left out, the rest of the logging API and its output formats are unchanged.
+
+// SIGHUP is not available, log files can still be reopened by calling Reopen.
+func startSighupHandler(bool) {}
+
+func newRotaterSink(options *Options) (zapcore.WriteSyncer, error) {
+	if options.RotateOutputPath == "" {
+		return nil, nil
+	}
+
+	return nil, errors.New("log rotation is not supported in TinyGo builds")
+}
+
+func captureGrpc(*zap.Logger) {}
+
+func allStacks() []byte {
+	return []byte("goroutine stacks are not available in TinyGo builds\n")
+}
+
+// goroutines have no identifier in TinyGo
+func goroutineID() uint64 {
+	return 0
+}
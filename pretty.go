@@ -0,0 +1,123 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// prettyEnvVar turns on PrettyFormat for the whole process when set to 1, regardless of the options.
+	prettyEnvVar = "LOG_PRETTY"
+
+	prettyKeyColor   = "\x1b[36m"
+	prettyResetColor = "\x1b[0m"
+)
+
+// prettyEncoder is meant for developers reading the output in a terminal. It prints each
+// entry on a line, like the console encoder, with the fields on the following lines, one
+// per line, indented and with their keys colored.
+type prettyEncoder struct {
+	*zapcore.MapObjectEncoder
+
+	header zapcore.Encoder
+}
+
+func newPrettyEncoder(encCfg zapcore.EncoderConfig) zapcore.Encoder {
+	encCfg.EncodeLevel = zapcore.LowercaseColorLevelEncoder
+
+	return &prettyEncoder{
+		MapObjectEncoder: zapcore.NewMapObjectEncoder(),
+		header:           zapcore.NewConsoleEncoder(encCfg),
+	}
+}
+
+func (enc *prettyEncoder) Clone() zapcore.Encoder {
+	clone := &prettyEncoder{
+		MapObjectEncoder: zapcore.NewMapObjectEncoder(),
+		header:           enc.header,
+	}
+
+	for k, v := range enc.Fields {
+		clone.Fields[k] = v
+	}
+
+	return clone
+}
+
+func (enc *prettyEncoder) EncodeEntry(e zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	stack := e.Stack
+	e.Stack = ""
+
+	buf, err := enc.header.EncodeEntry(e, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// fields added through With come first, in a stable order
+	appendPrettyFields(buf, enc.Fields)
+
+	for _, f := range fields {
+		m := zapcore.NewMapObjectEncoder()
+		f.AddTo(m)
+		appendPrettyFields(buf, m.Fields)
+	}
+
+	if stack != "" {
+		buf.AppendString(stack)
+		buf.AppendString(zapcore.DefaultLineEnding)
+	}
+
+	return buf, nil
+}
+
+func appendPrettyFields(buf *buffer.Buffer, fields map[string]interface{}) {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		buf.AppendString("    ")
+		buf.AppendString(prettyKeyColor)
+		buf.AppendString(k)
+		buf.AppendString(prettyResetColor)
+		buf.AppendString(": ")
+		buf.AppendString(prettyValue(fields[k]))
+		buf.AppendString(zapcore.DefaultLineEnding)
+	}
+}
+
+func prettyValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case time.Duration, time.Time, error, fmt.Stringer:
+		return fmt.Sprint(v)
+	}
+
+	if b, err := json.Marshal(v); err == nil {
+		return string(b)
+	}
+
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,87 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"errors"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestPretty(t *testing.T) {
+	s := RegisterScope("TestPretty", "", 0)
+
+	lines, err := captureStdout(func() {
+		o := DefaultOptions()
+		o.Pretty = true
+		o.JSONEncoding = true
+		_ = Configure(o)
+
+		s.Info("Hello",
+			zap.String("name", "world"),
+			zap.Int("count", 2),
+			zap.Duration("took", time.Second),
+			zap.Error(errors.New("boom")),
+			zap.Strings("tags", []string{"a", "b"}))
+		_ = Sync()
+	})
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	_ = Configure(DefaultOptions())
+
+	expected := []string{
+		timePattern + "\t\x1b\\[34minfo\x1b\\[0m\tTestPretty\tHello$",
+		"^    \x1b\\[36mname\x1b\\[0m: world$",
+		"^    \x1b\\[36mcount\x1b\\[0m: 2$",
+		"^    \x1b\\[36mtook\x1b\\[0m: 1s$",
+		"^    \x1b\\[36merror\x1b\\[0m: boom$",
+		`^    ` + "\x1b\\[36mtags\x1b\\[0m" + `: \["a","b"\]$`,
+		"^$",
+	}
+
+	if len(lines) != len(expected) {
+		t.Fatalf("Got %d lines, expected %d: %q", len(lines), len(expected), lines)
+	}
+
+	for i, pat := range expected {
+		if match, _ := regexp.MatchString(pat, lines[i]); !match {
+			t.Errorf("Got %q, expected a match with %q", lines[i], pat)
+		}
+	}
+}
+
+func TestPrettyFromEnvironment(t *testing.T) {
+	_ = os.Setenv(prettyEnvVar, "1")
+	defer func() { _ = os.Unsetenv(prettyEnvVar) }()
+
+	lines, err := captureStdout(func() {
+		_ = Configure(DefaultOptions())
+		Info("Hello", zap.String("k", "v"))
+		_ = Sync()
+	})
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	_ = Configure(DefaultOptions())
+
+	if len(lines) != 3 || lines[1] != "    \x1b[36mk\x1b[0m: v" {
+		t.Errorf("Got %q, expected the field on its own line", lines)
+	}
+}
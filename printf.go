@@ -0,0 +1,124 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// set by the Configure method, 1 when mismatches between format verbs and arguments are reported
+var strictFormat int32
+
+// formatMessage formats the message of the printf-style methods. Unlike fmt.Sprintf, it
+// doesn't corrupt the message when the number of arguments doesn't match the verbs of the
+// template: missing arguments leave the remaining verbs as they are, and extra arguments
+// are appended to the message. Templates are used literally when there are no arguments.
+// Mismatches are only reported when report is set.
+func formatMessage(template string, args []interface{}, report bool) string {
+	if len(args) == 0 {
+		return template
+	}
+
+	ends, ok := verbEnds(template)
+	if !ok || len(ends) == len(args) {
+		return fmt.Sprintf(template, args...)
+	}
+
+	if report && atomic.LoadInt32(&strictFormat) != 0 {
+		reportFormatMismatch(template, len(ends), len(args))
+	}
+	if report && checkMisuse() {
+		reportMisuse(Misuse{Kind: FormatMismatch, Message: template, Detail: fmt.Sprintf("expects %d arguments, got %d", len(ends), len(args))})
+	}
+
+	// format as many arguments as possible, without splitting a verb taking several
+	k := len(args)
+	if k > len(ends) {
+		k = len(ends)
+	}
+	for k > 0 && k < len(ends) && ends[k-1] == ends[k] {
+		k--
+	}
+
+	end := len(template)
+	if k < len(ends) {
+		end = 0
+		if k > 0 {
+			end = ends[k-1]
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(template[:end], args[:k]...))
+	b.WriteString(template[end:])
+	for _, a := range args[k:] {
+		b.WriteByte(' ')
+		_, _ = fmt.Fprint(&b, a)
+	}
+
+	return b.String()
+}
+
+// verbEnds returns the offset of the end of each verb of the template consuming an
+// argument, an offset appearing once per argument consumed. It returns false when the
+// template uses explicit argument indexes, which aren't checked.
+func verbEnds(template string) ([]int, bool) {
+	var ends []int
+	for i := 0; i < len(template); i++ {
+		if template[i] != '%' {
+			continue
+		}
+
+		consumed := 0
+		i++
+		for ; i < len(template); i++ {
+			c := template[i]
+			if c == '[' {
+				return nil, false
+			}
+			if c == '*' {
+				consumed++
+				continue
+			}
+			if strings.IndexByte("+-# 0.", c) >= 0 || (c >= '0' && c <= '9') {
+				continue
+			}
+			break
+		}
+
+		if i == len(template) || template[i] == '%' {
+			continue
+		}
+
+		consumed++
+		for ; consumed > 0; consumed-- {
+			ends = append(ends, i+1)
+		}
+	}
+
+	return ends, true
+}
+
+func reportFormatMismatch(template string, verbs int, args int) {
+	if es, _ := errorSink.Load().(zapcore.WriteSyncer); es != nil {
+		_, _ = fmt.Fprintf(es, "%v log message '%s' expects %d arguments, got %d\n", time.Now(), template, verbs, args)
+		_ = es.Sync()
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestFormatMessage(t *testing.T) {
+	cases := []struct {
+		template string
+		args     []interface{}
+		expected string
+	}{
+		{"100% done", nil, "100% done"},
+		{"hello %s", []interface{}{"world"}, "hello world"},
+		{"%d%% of %s", []interface{}{50, "rows"}, "50% of rows"},
+		{"failed %s: %v", []interface{}{"read"}, "failed read: %v"},
+		{"failed %s: %v", nil, "failed %s: %v"},
+		{"failed", []interface{}{"read", 42}, "failed read 42"},
+		{"failed %s", []interface{}{"read", 42}, "failed read 42"},
+		{"%*d|%s", []interface{}{3}, "%*d|%s 3"},
+		{"%*d|%s", []interface{}{3, 7}, "  7|%s"},
+		{"%[2]s %[1]s", []interface{}{"a", "b"}, "b a"},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if got := formatMessage(c.template, c.args, true); got != c.expected {
+				t.Errorf("Got %q, expected %q", got, c.expected)
+			}
+		})
+	}
+}
+
+func TestStrictFormat(t *testing.T) {
+	errPath := filepath.Join(t.TempDir(), "errors.log")
+
+	o := DefaultOptions()
+	o.ErrorOutputPaths = []string{errPath}
+	o.StrictFormat = true
+	if err := Configure(o); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = Configure(DefaultOptions()) }()
+
+	if got := formatMessage("hello %s", []interface{}{"world"}, true); got != "hello world" {
+		t.Errorf("Got %q, expected %q", got, "hello world")
+	}
+
+	if got := formatMessage("failed %s: %v", []interface{}{"read"}, true); got != "failed read: %v" {
+		t.Errorf("Got %q, expected %q", got, "failed read: %v")
+	}
+
+	b, _ := ioutil.ReadFile(errPath)
+	if lines := strings.Split(strings.TrimSpace(string(b)), "\n"); len(lines) != 1 ||
+		!strings.Contains(lines[0], "log message 'failed %s: %v' expects 2 arguments, got 1") {
+		t.Errorf("Got %q, expected a single report", b)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DefaultProgressInterval is how often progress entries are logged by default.
+const DefaultProgressInterval = 10 * time.Second
+
+// ProgressLogger logs the progress of a long-running job at info level, at most once per
+// interval however often progress is reported. Entries carry the number of items done,
+// the total, the percentage done, the rate in items per second, and the estimated time
+// left. It is safe for concurrent use.
+type ProgressLogger struct {
+	scope *Scope
+	msg   string
+	total int64
+	count int64
+
+	mu       sync.Mutex
+	interval time.Duration
+	start    time.Time
+	next     time.Time
+	now      func() time.Time
+}
+
+// Progress returns a logger for the progress of a job processing the given total number
+// of items, or an unknown number of items if total is 0.
+//
+//	p := log.Progress(s, "migrating rows", total)
+//	for _, row := range rows {
+//		migrate(row)
+//		p.Add(1)
+//	}
+//	p.Done()
+func Progress(s *Scope, msg string, total int64) *ProgressLogger {
+	p := &ProgressLogger{
+		scope:    s,
+		msg:      msg,
+		total:    total,
+		interval: DefaultProgressInterval,
+		now:      now,
+	}
+	p.start = p.now()
+	p.next = p.start.Add(p.interval)
+
+	return p
+}
+
+// SetInterval sets how often progress entries are logged.
+func (p *ProgressLogger) SetInterval(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.interval = d
+	p.next = p.now().Add(d)
+}
+
+// Add reports n more items as done, logging the progress if the interval elapsed.
+func (p *ProgressLogger) Add(n int64) {
+	count := atomic.AddInt64(&p.count, n)
+	if !p.scope.InfoEnabled() {
+		return
+	}
+
+	p.mu.Lock()
+	now := p.now()
+	if now.Before(p.next) {
+		p.mu.Unlock()
+		return
+	}
+	p.next = now.Add(p.interval)
+	p.mu.Unlock()
+
+	p.scope.emit(zapcore.InfoLevel, p.scope.GetStackTraceLevel() >= InfoLevel, p.msg, p.fields(count, now))
+}
+
+// Done logs the final progress, regardless of the interval.
+func (p *ProgressLogger) Done() {
+	if p.scope.enabled(InfoLevel) {
+		p.scope.emit(zapcore.InfoLevel, p.scope.GetStackTraceLevel() >= InfoLevel, p.msg+" done",
+			p.fields(atomic.LoadInt64(&p.count), p.now()))
+	}
+}
+
+func (p *ProgressLogger) fields(count int64, now time.Time) []zapcore.Field {
+	elapsed := now.Sub(p.start)
+
+	var rate float64
+	if elapsed > 0 {
+		rate = float64(count) / elapsed.Seconds()
+	}
+
+	fields := []zapcore.Field{zap.Int64("count", count)}
+	if p.total > 0 {
+		fields = append(fields,
+			zap.Int64("total", p.total),
+			zap.Float64("percent", float64(count)*100/float64(p.total)))
+	}
+	fields = append(fields, zap.Float64("rate", rate))
+
+	if p.total > 0 && rate > 0 && count < p.total {
+		eta := time.Duration(float64(p.total-count) / rate * float64(time.Second))
+		fields = append(fields, zap.Duration("eta", eta.Round(time.Second)))
+	}
+
+	return fields
+}
@@ -0,0 +1,97 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestProgress(t *testing.T) {
+	var entries []map[string]interface{}
+	var messages []string
+	s := NewWithEmit("TestProgress", "", 0, func(e zapcore.Entry, fields []zapcore.Field) error {
+		enc := zapcore.NewMapObjectEncoder()
+		for _, f := range fields {
+			f.AddTo(enc)
+		}
+		entries = append(entries, enc.Fields)
+		messages = append(messages, e.Message)
+		return nil
+	})
+
+	clock := time.Unix(1000, 0)
+	p := Progress(s, "migrating rows", 100)
+	p.now = func() time.Time { return clock }
+	p.start = clock
+	p.SetInterval(10 * time.Second)
+
+	p.Add(10)
+	clock = clock.Add(5 * time.Second)
+	p.Add(10)
+	clock = clock.Add(5 * time.Second)
+	p.Add(5)
+	clock = clock.Add(time.Second)
+	p.Add(5)
+	clock = clock.Add(9 * time.Second)
+	p.Add(20)
+	p.Done()
+
+	if len(entries) != 3 {
+		t.Fatalf("Got %v, expected 3 entries", entries)
+	}
+
+	first := entries[0]
+	if first["count"] != int64(25) || first["total"] != int64(100) || first["percent"] != 25.0 ||
+		first["rate"] != 2.5 || first["eta"] != 30*time.Second {
+		t.Errorf("Got %v, expected 25 items done at 2.5 per second", first)
+	}
+
+	if entries[1]["count"] != int64(50) || messages[1] != "migrating rows" {
+		t.Errorf("Got %v, expected 50 items done", entries[1])
+	}
+
+	if entries[2]["count"] != int64(50) || messages[2] != "migrating rows done" {
+		t.Errorf("Got %s %v, expected the final progress", messages[2], entries[2])
+	}
+}
+
+func TestProgressUnknownTotal(t *testing.T) {
+	var fields map[string]interface{}
+	s := NewWithEmit("TestProgressUnknownTotal", "", 0, func(e zapcore.Entry, fs []zapcore.Field) error {
+		enc := zapcore.NewMapObjectEncoder()
+		for _, f := range fs {
+			f.AddTo(enc)
+		}
+		fields = enc.Fields
+		return nil
+	})
+
+	p := Progress(s, "scanning", 0)
+	p.Add(3)
+	p.Done()
+
+	if fields["count"] != int64(3) {
+		t.Errorf("Got %v, expected the count", fields)
+	}
+
+	for _, k := range []string{"total", "percent", "eta"} {
+		if _, ok := fields[k]; ok {
+			t.Errorf("Got %v, expected no %s field without a total", fields, k)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// pseudonymizationProcessor replaces the values of the given fields with their keyed
+// HMAC-SHA256 digest. The same value always produces the same digest for a given key,
+// which keeps entries correlatable without revealing the value.
+func pseudonymizationProcessor(keys []string, secret []byte) fieldProcessor {
+	selected := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		selected[k] = struct{}{}
+	}
+
+	match := func(f *zapcore.Field) bool {
+		if f.Type == zapcore.SkipType {
+			return false
+		}
+
+		_, ok := selected[f.Key]
+		return ok
+	}
+
+	return func(fields []zapcore.Field) []zapcore.Field {
+		return rewriteFields(fields, match, func(f zapcore.Field) zapcore.Field {
+			mac := hmac.New(sha256.New, secret)
+			_, _ = mac.Write(pseudonymizableValue(&f))
+			return zap.String(f.Key, hex.EncodeToString(mac.Sum(nil)))
+		})
+	}
+}
+
+// pseudonymizableValue returns the content of a field as it would be output.
+func pseudonymizableValue(f *zapcore.Field) []byte {
+	if v, ok := redactableValue(f); ok {
+		return v
+	}
+
+	m := zapcore.NewMapObjectEncoder()
+	f.AddTo(m)
+	return []byte(fmt.Sprint(m.Fields[f.Key]))
+}
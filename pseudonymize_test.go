@@ -0,0 +1,66 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestPseudonymizationProcessor(t *testing.T) {
+	secret := []byte("secret")
+	digest := func(v string) string {
+		mac := hmac.New(sha256.New, secret)
+		_, _ = mac.Write([]byte(v))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	p := pseudonymizationProcessor([]string{"user_id", "email"}, secret)
+
+	fields := []zapcore.Field{
+		zap.Int("user_id", 42),
+		zap.String("email", "jane@example.com"),
+		zap.String("action", "login"),
+		Err(nil),
+	}
+
+	out := p(fields)
+
+	if !out[0].Equals(zap.String("user_id", digest("42"))) {
+		t.Errorf("Got %v, expected the digest of 42", out[0])
+	}
+
+	if !out[1].Equals(zap.String("email", digest("jane@example.com"))) {
+		t.Errorf("Got %v, expected the digest of the email", out[1])
+	}
+
+	if !out[2].Equals(fields[2]) || out[3].Type != zapcore.SkipType {
+		t.Errorf("Got %v, expected the other fields to be left alone", out[2:])
+	}
+
+	if fields[1].String != "jane@example.com" {
+		t.Errorf("Got %v, expected the caller's slice to be left alone", fields[1])
+	}
+
+	other := pseudonymizationProcessor([]string{"email"}, []byte("other"))([]zapcore.Field{fields[1]})
+	if other[0].String == out[1].String {
+		t.Error("Expecting different keys to produce different digests")
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// RecentEntry is an entry returned by Query.
+type RecentEntry struct {
+	Time    time.Time              `json:"time"`
+	Level   string                 `json:"level"`
+	Scope   string                 `json:"scope"`
+	Message string                 `json:"msg"`
+	Caller  string                 `json:"caller,omitempty"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+}
+
+// Query returns the recent entries emitted through the given scope, or through any scope
+// if it's empty, at the given level or above, since the given time, unless it's zero,
+// and whose message holds the given substring, unless it's empty. The entries are those
+// kept in memory as configured by Options.RecentEntries, oldest first.
+//
+// Query powers endpoints such as /debug/logs?scope=cache&level=error, see QueryHandler.
+func Query(scope string, minLevel Level, since time.Time, substring string) []RecentEntry {
+	c, _ := crashDump.Load().(*crashRecorder)
+	if c == nil {
+		return nil
+	}
+
+	min := levelToZap[minLevel]
+
+	var matches []RecentEntry
+	for _, ce := range c.snapshot() {
+		e := ce.entry
+		name := e.LoggerName
+		if name == "" {
+			name = DefaultScopeName
+		}
+
+		if ce.suppressed || e.Level < min || (scope != "" && name != scope) ||
+			(!since.IsZero() && e.Time.Before(since)) || !strings.Contains(e.Message, substring) {
+			continue
+		}
+
+		re := RecentEntry{
+			Time:    e.Time,
+			Level:   e.Level.String(),
+			Scope:   name,
+			Message: e.Message,
+		}
+
+		if e.Caller.Defined {
+			re.Caller = e.Caller.TrimmedPath()
+		}
+
+		if len(ce.fields) > 0 {
+			enc := zapcore.NewMapObjectEncoder()
+			for _, f := range ce.fields {
+				f.AddTo(enc)
+			}
+			re.Fields = enc.Fields
+		}
+
+		matches = append(matches, re)
+	}
+
+	return matches
+}
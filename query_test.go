@@ -0,0 +1,89 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestQuery(t *testing.T) {
+	if entries := Query("", DebugLevel, time.Time{}, ""); entries != nil {
+		t.Errorf("Got %v, expected no entries without RecentEntries", entries)
+	}
+
+	o := DefaultOptions()
+	o.RecentEntries = 10
+	if err := Configure(o); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = Configure(DefaultOptions()) }()
+
+	s := NewWithEmit("TestQuery", "", 0, func(zapcore.Entry, []zapcore.Field) error { return nil })
+	s.SetOutputLevel(DebugLevel)
+	defer s.SetOutputLevel(InfoLevel)
+
+	start := time.Now()
+	s.Debug("cache miss", zap.String("key", "k1"))
+	s.Error("cache timeout", zap.Int("n", 2))
+	_, _ = captureStdout(func() { Info("serving") })
+
+	cases := []struct {
+		scope     string
+		level     Level
+		since     time.Time
+		substring string
+		expected  []string
+	}{
+		{"", DebugLevel, time.Time{}, "", []string{"cache miss", "cache timeout", "serving"}},
+		{"TestQuery", DebugLevel, time.Time{}, "", []string{"cache miss", "cache timeout"}},
+		{DefaultScopeName, DebugLevel, time.Time{}, "", []string{"serving"}},
+		{"TestQuery", ErrorLevel, time.Time{}, "", []string{"cache timeout"}},
+		{"", DebugLevel, time.Time{}, "miss", []string{"cache miss"}},
+		{"", DebugLevel, start.Add(time.Hour), "", nil},
+		{"missing", DebugLevel, time.Time{}, "", nil},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var got []string
+			for _, e := range Query(c.scope, c.level, c.since, c.substring) {
+				if !strings.HasPrefix(e.Message, "cache") && e.Message != "serving" {
+					continue // logged by other tests
+				}
+				got = append(got, e.Message)
+			}
+
+			if strings.Join(got, ",") != strings.Join(c.expected, ",") {
+				t.Errorf("Got %v, expected %v", got, c.expected)
+			}
+		})
+	}
+
+	entries := Query("TestQuery", ErrorLevel, start, "")
+	if len(entries) != 1 {
+		t.Fatalf("Got %v, expected a single entry", entries)
+	}
+
+	e := entries[0]
+	if e.Level != "error" || e.Scope != "TestQuery" || e.Fields["n"] != int64(2) || e.Time.Before(start) {
+		t.Errorf("Got %+v, expected the timeout entry", e)
+	}
+}
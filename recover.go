@@ -0,0 +1,57 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"runtime/debug"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Recover stops a panicking goroutine and logs the panic value, described by PanicValue,
+// and the goroutine's stack at error level through the given scope. It must be deferred
+// directly:
+//
+//	defer log.Recover(scope)
+func Recover(s *Scope) {
+	if r := recover(); r != nil {
+		logPanic(s, r)
+	}
+}
+
+// RecoverAndRepanic is like Recover, but resumes panicking with the original value
+// once it has been logged. It must be deferred directly:
+//
+//	defer log.RecoverAndRepanic(scope)
+func RecoverAndRepanic(s *Scope) {
+	if r := recover(); r != nil {
+		logPanic(s, r)
+		panic(r)
+	}
+}
+
+func logPanic(s *Scope, r interface{}) {
+	if !s.enabled(ErrorLevel) {
+		return
+	}
+
+	fields := []zapcore.Field{
+		PanicValue(r),
+		zap.ByteString("stack", debug.Stack()),
+	}
+
+	s.emit(zapcore.ErrorLevel, false, "recovered from panic", fields)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestRecover(t *testing.T) {
+	s := RegisterScope("TestRecover", "", 0)
+
+	lines, err := captureStdout(func() {
+		o := DefaultOptions()
+		o.JSONEncoding = true
+		_ = Configure(o)
+
+		func() {
+			defer Recover(s)
+			panic("boom")
+		}()
+		_ = Sync()
+	})
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	pat := `"level":"error",.*"scope":"TestRecover","msg":"recovered from panic","panic":"boom","stack":"goroutine .*recover_test.go.*"}`
+	if match, _ := regexp.MatchString(pat, lines[0]); !match {
+		t.Errorf("Got '%v', expected a match with '%v'", lines[0], pat)
+	}
+}
+
+func TestRecoverAndRepanic(t *testing.T) {
+	s := RegisterScope("TestRecoverAndRepanic", "", 0)
+
+	var repanicked interface{}
+	lines, err := captureStdout(func() {
+		_ = Configure(DefaultOptions())
+
+		func() {
+			defer func() { repanicked = recover() }()
+			defer RecoverAndRepanic(s)
+			panic("boom")
+		}()
+		_ = Sync()
+	})
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	if repanicked != "boom" {
+		t.Errorf("Got %v, expected the original panic value", repanicked)
+	}
+
+	if match, _ := regexp.MatchString("recovered from panic", lines[0]); !match {
+		t.Errorf("Got '%v', expected the panic to be logged", lines[0])
+	}
+}
+
+func TestRecoverNoPanic(t *testing.T) {
+	s := RegisterScope("TestRecoverNoPanic", "", 0)
+
+	lines, _ := captureStdout(func() {
+		_ = Configure(DefaultOptions())
+
+		func() {
+			defer Recover(s)
+		}()
+		_ = Sync()
+	})
+
+	if lines[0] != "" {
+		t.Errorf("Got '%v', expected no output", lines[0])
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var (
+	// CreditCardPattern matches payment card numbers, with or without separators.
+	CreditCardPattern = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+
+	// BearerTokenPattern matches bearer tokens, as found in HTTP Authorization headers.
+	BearerTokenPattern = regexp.MustCompile(`(?i)\bbearer\s+[a-z0-9\-._~+/]+=*`)
+)
+
+// RedactionRule selects values that are summarized rather than output verbatim. A
+// summary only holds the length of the value and its SHA-256 digest, such as
+// "len=4096 sha256=...", which still lets identical values be correlated.
+//
+// Rules apply to string, byte string, binary, error and fmt.Stringer fields.
+type RedactionRule struct {
+	// MaxSize is the length in bytes beyond which values are summarized. A value of 0
+	// disables the check.
+	MaxSize int
+
+	// Pattern selects values to summarize based on their content. A nil pattern disables
+	// the check.
+	Pattern *regexp.Regexp
+}
+
+func (r RedactionRule) matches(v []byte) bool {
+	if r.MaxSize > 0 && len(v) > r.MaxSize {
+		return true
+	}
+
+	return r.Pattern != nil && r.Pattern.Match(v)
+}
+
+// redactionProcessor summarizes the values selected by any of the given rules.
+func redactionProcessor(rules []RedactionRule) fieldProcessor {
+	match := func(f *zapcore.Field) bool {
+		v, ok := redactableValue(f)
+		if !ok {
+			return false
+		}
+
+		for _, r := range rules {
+			if r.matches(v) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return func(fields []zapcore.Field) []zapcore.Field {
+		return rewriteFields(fields, match, func(f zapcore.Field) zapcore.Field {
+			v, _ := redactableValue(&f)
+			return zap.String(f.Key, fmt.Sprintf("len=%d sha256=%x", len(v), sha256.Sum256(v)))
+		})
+	}
+}
+
+// redactableValue returns the content of the fields the redaction rules apply to.
+func redactableValue(f *zapcore.Field) ([]byte, bool) {
+	switch f.Type {
+	case zapcore.StringType:
+		return []byte(f.String), true
+	case zapcore.ByteStringType, zapcore.BinaryType:
+		return f.Interface.([]byte), true
+	case zapcore.ErrorType:
+		return []byte(renderValue(f.Interface, f.Interface.(error).Error)), true
+	case zapcore.StringerType:
+		return []byte(renderValue(f.Interface, f.Interface.(fmt.Stringer).String)), true
+	}
+
+	return nil, false
+}
+
+// renderValue returns the result of the Error or String method of v. Like zap, it gives
+// "<nil>" when the method panics on a nil pointer, and a description of the panic otherwise.
+func renderValue(v interface{}, fn func() string) (s string) {
+	defer func() {
+		if r := recover(); r != nil {
+			if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+				s = "<nil>"
+			} else {
+				s = fmt.Sprintf("PANIC=%v", r)
+			}
+		}
+	}()
+
+	return fn()
+}
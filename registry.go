@@ -0,0 +1,260 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Registry holds a set of scopes, registered by name. The package-level functions, such as
+// RegisterScope, FindScope and Scopes, use the registry of the process, which Configure
+// controls. Multi-tenant hosts and test harnesses can create isolated registries with
+// NewRegistry, whose scopes are neither listed by Scopes nor affected by Configure.
+type Registry struct {
+	mu     sync.Mutex
+	scopes map[string]*Scope
+	// the number of lookups of each name FindScope didn't find
+	misses map[string]uint64
+
+	// set for the registries created by NewRegistry, the level and the destination of the
+	// scopes they register
+	isolated bool
+	level    Level
+	output   zapcore.WriteSyncer
+}
+
+// the registry of the process
+var defaultRegistry = newRegistry()
+
+func newRegistry() *Registry {
+	return &Registry{
+		scopes: make(map[string]*Scope),
+		misses: make(map[string]uint64),
+	}
+}
+
+// NewRegistry returns an isolated registry, whose scopes are output at info level to the
+// standard output until SetOutputLevel and SetOutput are called. Its scopes otherwise
+// behave like those of the process, in the format configured for the process, but Configure
+// doesn't change their levels, and Sync doesn't flush them, see Registry.Sync.
+//
+// Their level changes are audited through the scopes themselves, and reported to the
+// functions given to WatchLevels with the registry, in LevelChange.Registry. Their log storms
+// are handled apart from those of the process. The features of the process which only
+// know scopes by name, namely DroppedCounts, ErrorCounts, the hooks, the crash reports and
+// Query, don't tell them apart from the scopes of the process of the same name.
+func NewRegistry() *Registry {
+	r := newRegistry()
+	r.isolated = true
+	r.level = InfoLevel
+	r.output = zapcore.Lock(os.Stdout)
+
+	return r
+}
+
+// DefaultRegistry returns the registry of the process, used by the package-level functions.
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// RegisterScope registers a new logging scope in the registry, like the package-level
+// RegisterScope does in the registry of the process.
+func (r *Registry) RegisterScope(name string, description string, callerSkip int) *Scope {
+	if strings.ContainsAny(name, ":,.") {
+		return nil
+	}
+
+	return r.register(name, description, callerSkip, nil)
+}
+
+// register registers a scope without validating its name. The init function, if any, is
+// called on the scope if it's created by the call, before it's made available.
+func (r *Registry) register(name string, description string, callerSkip int, init func(*Scope)) *Scope {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.scopes[name]
+	if !ok {
+		s = newScope(r, name, description, callerSkip)
+		if r.isolated {
+			s.outputLevel.Store(r.level)
+			s.SetOutput(r.output)
+		}
+
+		if init != nil {
+			init(s)
+		}
+
+		r.scopes[name] = s
+	}
+
+	return s
+}
+
+// FindScope returns a scope registered in the registry, like the package-level FindScope
+// does in the registry of the process.
+func (r *Registry) FindScope(name string) *Scope {
+	r.mu.Lock()
+	s := r.scopes[name]
+	if s == nil {
+		r.misses[name]++
+	}
+	r.mu.Unlock()
+
+	if s == nil && atomic.LoadInt32(&autoRegisterScopes) != 0 {
+		s = r.RegisterScope(name, AutoRegisteredDescription, 0)
+	}
+
+	return s
+}
+
+// ScopeMisses returns the names FindScope was asked for while they weren't registered in
+// the registry, with the number of such lookups.
+func (r *Registry) ScopeMisses() map[string]uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	misses := make(map[string]uint64, len(r.misses))
+	for k, v := range r.misses {
+		misses[k] = v
+	}
+
+	return misses
+}
+
+// Scopes returns a snapshot of the scopes registered in the registry.
+func (r *Registry) Scopes() map[string]*Scope {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := make(map[string]*Scope, len(r.scopes))
+	for k, v := range r.scopes {
+		s[k] = v
+	}
+
+	return s
+}
+
+// SetOutputLevel sets the output level of all the scopes of the registry, including those
+// registered afterwards if the registry was created by NewRegistry.
+func (r *Registry) SetOutputLevel(l Level) {
+	r.mu.Lock()
+	r.level = l
+	r.mu.Unlock()
+
+	for _, s := range r.Scopes() {
+		s.SetOutputLevel(l)
+	}
+}
+
+// SetOutput makes all the scopes of the registry write their entries to the given
+// destination, including those registered afterwards if the registry was created by
+// NewRegistry. Use nil to write to the outputs configured for the process.
+func (r *Registry) SetOutput(ws zapcore.WriteSyncer) {
+	r.mu.Lock()
+	r.output = ws
+	r.mu.Unlock()
+
+	for _, s := range r.Scopes() {
+		s.SetOutput(ws)
+	}
+}
+
+// Sync flushes the destinations of the scopes of the registry.
+func (r *Registry) Sync() error {
+	var err error
+	for _, s := range r.Scopes() {
+		if ws := s.GetOutput(); ws != nil {
+			if e := ws.Sync(); e != nil && err == nil {
+				err = e
+			}
+		}
+	}
+
+	return err
+}
+
+// ReadOnly returns a RegistryReader over the scopes of the registry.
+func (r *Registry) ReadOnly() RegistryReader {
+	return registryReader{r}
+}
+
+// RegistryReader gives read-only access to the registered scopes and their settings. It
+// can be handed to plugins, admin interfaces or libraries that need to list the scopes
+// or report their levels, without granting them the ability to change the levels or the
+// outputs, as a *Scope would.
+type RegistryReader interface {
+	// Scopes describes the registered scopes, sorted by name.
+	Scopes() []ScopeDescription
+	// Scope describes the scope of the given name, if it's registered.
+	Scope(name string) (ScopeDescription, bool)
+	// OutputLevel returns the output level of the scope of the given name, if it's registered.
+	OutputLevel(name string) (Level, bool)
+}
+
+// registryReader reads the scopes of a registry.
+type registryReader struct {
+	r *Registry
+}
+
+// ReadOnlyRegistry returns a RegistryReader over the scopes registered in the process.
+func ReadOnlyRegistry() RegistryReader {
+	return defaultRegistry.ReadOnly()
+}
+
+func (rr registryReader) Scopes() []ScopeDescription {
+	all := rr.r.Scopes()
+
+	descriptions := make([]ScopeDescription, 0, len(all))
+	for _, s := range all {
+		descriptions = append(descriptions, describeScope(s))
+	}
+
+	sort.Slice(descriptions, func(i, j int) bool { return descriptions[i].Name < descriptions[j].Name })
+	return descriptions
+}
+
+func (rr registryReader) Scope(name string) (ScopeDescription, bool) {
+	s := rr.r.lookup(name)
+	if s == nil {
+		return ScopeDescription{}, false
+	}
+
+	return describeScope(s), true
+}
+
+func (rr registryReader) OutputLevel(name string) (Level, bool) {
+	s := rr.r.lookup(name)
+	if s == nil {
+		return NoneLevel, false
+	}
+
+	return s.GetOutputLevel(), true
+}
+
+// lookup returns the scope of the given name, if it's registered. Unlike FindScope, it
+// neither counts misses nor registers the scope.
+func (r *Registry) lookup(name string) *Scope {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.scopes[name]
+}
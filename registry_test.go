@@ -0,0 +1,167 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestReadOnlyRegistry(t *testing.T) {
+	s := RegisterScope("TestReadOnlyRegistry", "read only", 0)
+	s.SetOutputLevel(WarnLevel)
+	defer s.SetOutputLevel(InfoLevel)
+
+	r := ReadOnlyRegistry()
+
+	all := r.Scopes()
+	if len(all) != len(Scopes()) {
+		t.Errorf("Got %d scopes, expected %d", len(all), len(Scopes()))
+	}
+	if !sort.SliceIsSorted(all, func(i, j int) bool { return all[i].Name < all[j].Name }) {
+		t.Errorf("Got %v, expected the scopes sorted by name", all)
+	}
+
+	d, ok := r.Scope("TestReadOnlyRegistry")
+	if !ok || d.Description != "read only" || d.OutputLevel != WarnLevel {
+		t.Errorf("Got %v, %v, expected the description of the scope", d, ok)
+	}
+
+	if l, ok := r.OutputLevel("TestReadOnlyRegistry"); !ok || l != WarnLevel {
+		t.Errorf("Got %v, %v, expected %v", l, ok, WarnLevel)
+	}
+
+	misses := ScopeMisses()["TestReadOnlyRegistryMissing"]
+	if _, ok := r.Scope("TestReadOnlyRegistryMissing"); ok {
+		t.Error("Got true, expected false for a scope which isn't registered")
+	}
+	if _, ok := r.OutputLevel("TestReadOnlyRegistryMissing"); ok {
+		t.Error("Got true, expected false for a scope which isn't registered")
+	}
+	if got := ScopeMisses()["TestReadOnlyRegistryMissing"]; got != misses {
+		t.Errorf("Got %d misses, expected the lookups not to be counted", got)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	global := RegisterScope("TestRegistry", "", 0)
+
+	r := NewRegistry()
+	buf := &bufferSyncer{}
+	r.SetOutput(buf)
+	r.SetOutputLevel(WarnLevel)
+
+	s := r.RegisterScope("TestRegistry", "isolated", 0)
+	if s == global {
+		t.Fatal("Got the scope of the process, expected an isolated one")
+	}
+	if Scopes()["TestRegistry"] != global {
+		t.Error("Got the isolated scope in the registry of the process, expected it to be left out")
+	}
+	if r.FindScope("TestRegistry") != s || r.RegisterScope("TestRegistry", "", 0) != s {
+		t.Error("Got another scope, expected the one registered")
+	}
+	if r.RegisterScope("invalid.name", "", 0) != nil {
+		t.Error("Got a scope, expected nil for an invalid name")
+	}
+
+	child := s.WithName("child")
+	if r.FindScope("TestRegistry.child") != child || FindScope("TestRegistry.child") != nil {
+		t.Error("Got the child in the registry of the process, expected it in the isolated one")
+	}
+
+	// Configure leaves isolated registries alone
+	o := DefaultOptions()
+	o.SetOutputLevel("TestRegistry", DebugLevel)
+	if err := Configure(o); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = Configure(DefaultOptions()) }()
+
+	s.Info("filtered")
+	s.Warn("isolated warning")
+	child.Error("isolated error")
+	_ = r.Sync()
+
+	got := buf.String()
+	if strings.Contains(got, "filtered") || !strings.Contains(got, "isolated warning") || !strings.Contains(got, "isolated error") {
+		t.Errorf("Got '%v', expected the warning and the error of the isolated scopes", got)
+	}
+
+	if l := global.GetOutputLevel(); l != DebugLevel {
+		t.Errorf("Got %v, expected %v for the scope of the process", l, DebugLevel)
+	}
+
+	all := r.ReadOnly().Scopes()
+	if len(all) != 2 || all[0].Name != "TestRegistry" || all[1].Name != "TestRegistry.child" {
+		t.Errorf("Got %v, expected the scopes of the isolated registry", all)
+	}
+
+	if DefaultRegistry().FindScope("TestRegistry") != global {
+		t.Error("Got another scope, expected the default registry to hold the scope of the process")
+	}
+}
+
+func TestRegistryIsolatedGlobals(t *testing.T) {
+	global := RegisterScope("TestRegistryIsolatedGlobals", "", 0)
+
+	r := NewRegistry()
+	buf := &bufferSyncer{}
+	r.SetOutput(buf)
+	isolated := r.RegisterScope("TestRegistryIsolatedGlobals", "", 0)
+
+	var changes []LevelChange
+	cancel := WatchLevels(func(c LevelChange) {
+		if c.Scope == "TestRegistryIsolatedGlobals" {
+			changes = append(changes, c)
+		}
+	})
+	defer cancel()
+
+	o := DefaultOptions()
+	o.AuditLevelChanges = true
+	o.StormRate = 1
+	if err := Configure(o); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = Configure(DefaultOptions()) }()
+
+	isolated.SetOutputLevel(WarnLevel)
+	global.SetOutputLevel(WarnLevel)
+	defer global.SetOutputLevel(InfoLevel)
+
+	if len(changes) != 2 || changes[0].Registry != r || changes[1].Registry != DefaultRegistry() {
+		t.Errorf("Got %+v, expected the changes to carry the registry of their scope", changes)
+	}
+
+	if got := buf.String(); !strings.Contains(got, "output level changed") {
+		t.Errorf("Got '%v', expected the change of the isolated scope to be audited to its registry", got)
+	}
+
+	global.Warn("global")
+	isolated.Warn("isolated")
+
+	governed := 0
+	governors.Range(func(k, _ interface{}) bool {
+		if k.(scopeKey).name == "TestRegistryIsolatedGlobals" {
+			governed++
+		}
+		return true
+	})
+	if governed != 2 {
+		t.Errorf("Got %d storm governors, expected one per registry", governed)
+	}
+}
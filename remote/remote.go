@@ -0,0 +1,251 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package remote lets a control plane manage the levels of the logging scopes of a fleet
+// of processes, so that debug output can be turned on without redeploying.
+//
+// The client long-polls an HTTP endpoint for level configurations, applies them and
+// reports the levels in effect back to the endpoint:
+//
+//	GET  <endpoint>?node=<node>&version=<version>
+//
+// answers once the configuration differs from the given version, with a 200 and a
+// Config, or with a 304 when the poll times out without any change.
+//
+//	POST <endpoint>
+//
+// receives a Report of the levels in effect once a configuration has been applied.
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/tetratelabs/log"
+)
+
+const (
+	defaultPollTimeout   = 5 * time.Minute
+	defaultRetryInterval = 10 * time.Second
+)
+
+var scope = log.RegisterScope("remote", "Remote management of the logging levels.", 0)
+
+// Config is a level configuration pushed by the control plane.
+type Config struct {
+	// Version identifies the configuration.
+	Version string `json:"version"`
+	// Levels maps scope names to their output level. The "all" scope applies to every scope.
+	Levels map[string]log.Level `json:"levels"`
+	// Author identifies who pushed the configuration, as recorded by the level change audit trail.
+	Author string `json:"author,omitempty"`
+}
+
+// Report describes the levels in effect once a configuration has been applied.
+type Report struct {
+	// Node identifies the process.
+	Node string `json:"node"`
+	// Version is the version of the applied configuration.
+	Version string `json:"version"`
+	// Levels maps the name of every scope to its output level.
+	Levels map[string]log.Level `json:"levels"`
+	// Errors lists the parts of the configuration which could not be applied.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Options controls the behavior of a Client.
+type Options struct {
+	// Node identifies the process to the control plane.
+	Node string
+
+	// HTTPClient is used to reach the control plane. Its timeout must exceed PollTimeout.
+	HTTPClient *http.Client
+
+	// PollTimeout is how long a poll may be held by the control plane.
+	PollTimeout time.Duration
+
+	// RetryInterval is how long to wait before polling again after a failure.
+	RetryInterval time.Duration
+}
+
+// DefaultOptions returns a new set of options, initialized to the defaults
+func DefaultOptions() *Options {
+	return &Options{
+		HTTPClient:    &http.Client{},
+		PollTimeout:   defaultPollTimeout,
+		RetryInterval: defaultRetryInterval,
+	}
+}
+
+// Client applies the level configurations pushed by a control plane.
+type Client struct {
+	endpoint string
+	options  Options
+	version  string
+}
+
+// NewClient returns a client for the given control plane endpoint.
+func NewClient(endpoint string, options *Options) *Client {
+	if options == nil {
+		options = DefaultOptions()
+	}
+
+	c := &Client{
+		endpoint: endpoint,
+		options:  *options,
+	}
+
+	if c.options.HTTPClient == nil {
+		c.options.HTTPClient = &http.Client{}
+	}
+
+	return c
+}
+
+// Run polls the control plane and applies its configurations until the context is done.
+func (c *Client) Run(ctx context.Context) error {
+	for ctx.Err() == nil {
+		if err := c.Poll(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			scope.Warnf("unable to get the logging levels from %s: %v", c.endpoint, err)
+
+			select {
+			case <-time.After(c.options.RetryInterval):
+			case <-ctx.Done():
+			}
+		}
+	}
+
+	return ctx.Err()
+}
+
+// Poll waits for the next configuration, applies it and reports the result. It returns
+// without applying anything if the poll times out without any change.
+func (c *Client) Poll(ctx context.Context) error {
+	pollCtx, cancel := context.WithTimeout(ctx, c.options.PollTimeout)
+	defer cancel()
+
+	q := url.Values{}
+	q.Set("node", c.options.Node)
+	q.Set("version", c.version)
+
+	req, err := http.NewRequestWithContext(pollCtx, http.MethodGet, c.endpoint+"?"+q.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.options.HTTPClient.Do(req)
+	if err != nil {
+		if ctx.Err() == nil && pollCtx.Err() == context.DeadlineExceeded {
+			// the poll timed out without any change
+			return nil
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var config Config
+	if err := json.NewDecoder(resp.Body).Decode(&config); err != nil {
+		return fmt.Errorf("invalid configuration: %v", err)
+	}
+
+	report := Apply(&config)
+	report.Node = c.options.Node
+	c.version = config.Version
+
+	return c.report(ctx, report)
+}
+
+// Apply applies a level configuration and returns the resulting levels.
+func Apply(config *Config) *Report {
+	report := &Report{Version: config.Version}
+
+	// the override goes first, so that the levels of specific scopes prevail
+	if l, ok := config.Levels[log.OverrideScopeName]; ok {
+		for _, s := range log.Scopes() {
+			s.SetOutputLevelFrom(l, log.LevelSourceRemote, config.Author)
+		}
+	}
+
+	names := make([]string, 0, len(config.Levels))
+	for name := range config.Levels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// looked up without FindScope, which would count or register the unknown scopes
+	registered := log.Scopes()
+	for _, name := range names {
+		if name == log.OverrideScopeName {
+			continue
+		}
+
+		if s := registered[name]; s != nil {
+			s.SetOutputLevelFrom(config.Levels[name], log.LevelSourceRemote, config.Author)
+		} else {
+			report.Errors = append(report.Errors, fmt.Sprintf("unknown scope '%s'", name))
+		}
+	}
+
+	report.Levels = make(map[string]log.Level)
+	for name, s := range log.Scopes() {
+		report.Levels[name] = s.GetOutputLevel()
+	}
+
+	scope.Infof("applied logging levels version %q", config.Version)
+
+	return report
+}
+
+func (c *Client) report(ctx context.Context, report *Report) error {
+	b, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.options.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unable to report the applied levels: %s", resp.Status)
+	}
+
+	return nil
+}
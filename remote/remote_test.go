@@ -0,0 +1,155 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tetratelabs/log"
+)
+
+func TestClient(t *testing.T) {
+	a := log.RegisterScope("TestClientA", "", 0)
+	b := log.RegisterScope("TestClientB", "", 0)
+
+	var mu sync.Mutex
+	var polls []string
+	var reports []Report
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Method == http.MethodPost {
+			var report Report
+			_ = json.NewDecoder(r.Body).Decode(&report)
+			reports = append(reports, report)
+			return
+		}
+
+		polls = append(polls, r.URL.Query().Get("node")+"@"+r.URL.Query().Get("version"))
+		if r.URL.Query().Get("version") == "v1" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"version": "v1", "levels": {"all": "warn", "TestClientA": "debug", "nope": "info"}}`))
+	}))
+	defer srv.Close()
+
+	options := DefaultOptions()
+	options.Node = "node-1"
+	c := NewClient(srv.URL, options)
+
+	for i := 0; i < 2; i++ {
+		if err := c.Poll(context.Background()); err != nil {
+			t.Fatalf("Got error '%v', expected success", err)
+		}
+	}
+
+	if a.GetOutputLevel() != log.DebugLevel || b.GetOutputLevel() != log.WarnLevel {
+		t.Errorf("Got (%v, %v), expected (debug, warn)", a.GetOutputLevel(), b.GetOutputLevel())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(polls) != 2 || polls[0] != "node-1@" || polls[1] != "node-1@v1" {
+		t.Errorf("Got %v, expected a poll for the initial version and one for v1", polls)
+	}
+
+	if len(reports) != 1 {
+		t.Fatalf("Got %d reports, expected 1", len(reports))
+	}
+
+	r := reports[0]
+	if r.Node != "node-1" || r.Version != "v1" || r.Levels["TestClientA"] != log.DebugLevel || r.Levels["TestClientB"] != log.WarnLevel {
+		t.Errorf("Got %+v, expected the applied levels", r)
+	}
+
+	if len(r.Errors) != 1 || r.Errors[0] != "unknown scope 'nope'" {
+		t.Errorf("Got %v, expected an error for the unknown scope", r.Errors)
+	}
+
+	for _, s := range log.Scopes() {
+		s.SetOutputLevel(log.InfoLevel)
+	}
+}
+
+func TestRun(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	options := DefaultOptions()
+	options.RetryInterval = time.Millisecond
+	c := NewClient(srv.URL, options)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := c.Run(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Got '%v', expected the context to end the loop", err)
+	}
+}
+
+func TestPollTimeout(t *testing.T) {
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer srv.Close()
+	defer close(done)
+
+	options := DefaultOptions()
+	options.PollTimeout = 20 * time.Millisecond
+	c := NewClient(srv.URL, options)
+
+	if err := c.Poll(context.Background()); err != nil {
+		t.Errorf("Got error '%v', expected a timed out poll to succeed", err)
+	}
+}
+
+func TestApplyUnknownScope(t *testing.T) {
+	o := log.DefaultOptions()
+	o.AutoRegisterScopes = true
+	if err := log.Configure(o); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = log.Configure(log.DefaultOptions()) }()
+
+	report := Apply(&Config{Version: "v1", Levels: map[string]log.Level{"TestApplyUnknown": log.DebugLevel}})
+	if len(report.Errors) != 1 {
+		t.Errorf("Got %v, expected the unknown scope to be reported", report.Errors)
+	}
+
+	if _, ok := log.Scopes()["TestApplyUnknown"]; ok {
+		t.Error("Got the unknown scope registered, expected it to be left alone")
+	}
+
+	if n := log.ScopeMisses()["TestApplyUnknown"]; n != 0 {
+		t.Errorf("Got %d misses, expected the lookup not to be counted", n)
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ReopenableFile is a log file which can be closed and reopened at the same path, as
+// needed once logrotate has renamed it. Output paths that designate files are opened as
+// reopenable files.
+//
+// Each entry is appended with a single write, which keeps the entries of processes sharing
+// the file from interleaving on local file systems. Setups where this isn't enough, such as
+// network file systems, can additionally lock the file around each write.
+type ReopenableFile struct {
+	path string
+
+	mu   sync.Mutex
+	f    *os.File
+	lock bool
+}
+
+// NewReopenableFile opens the file at the given path for appending, creating it if needed.
+func NewReopenableFile(path string) (*ReopenableFile, error) {
+	rf := &ReopenableFile{path: path}
+	if err := rf.Reopen(); err != nil {
+		return nil, err
+	}
+
+	return rf, nil
+}
+
+// SetLocking controls whether an exclusive advisory lock (flock) is held on the file while
+// appending each entry, for files shared with other processes which lock it as well. File
+// locking is not supported on all platforms, where this has no effect.
+func (rf *ReopenableFile) SetLocking(enabled bool) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	rf.lock = enabled
+}
+
+// Write appends an entry to the file.
+func (rf *ReopenableFile) Write(p []byte) (int, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if !rf.lock {
+		return rf.f.Write(p)
+	}
+
+	if err := lockFile(rf.f); err != nil {
+		return 0, err
+	}
+	defer unlockFile(rf.f)
+
+	return rf.f.Write(p)
+}
+
+// Sync commits the content of the file to stable storage.
+func (rf *ReopenableFile) Sync() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	return rf.f.Sync()
+}
+
+// Close closes the file.
+func (rf *ReopenableFile) Close() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	return rf.f.Close()
+}
+
+// Reopen closes the file and opens the file found at the same path, creating it if needed.
+// If the path can't be opened, the current file is kept.
+func (rf *ReopenableFile) Reopen() error {
+	f, err := os.OpenFile(rf.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+
+	rf.mu.Lock()
+	old := rf.f
+	rf.f = f
+	rf.mu.Unlock()
+
+	if old != nil {
+		return old.Close()
+	}
+
+	return nil
+}
+
+// Reopen reopens the files the log is written to. Call it once logrotate has renamed them,
+// or set Options.ReopenOnSIGHUP to have it called whenever the process receives SIGHUP.
+func Reopen() error {
+	out, _ := currentOutputs.Load().(*outputs)
+	if out == nil {
+		return nil
+	}
+
+	var err error
+	for _, f := range out.files {
+		if e := f.Reopen(); e != nil && err == nil {
+			err = e
+		}
+	}
+
+	return err
+}
+
+// openOutputs opens the given output paths, as timed files for the paths that designate
+// files and hold time directives, as reopenable files for the other paths that designate
+// files and through the registered zap sinks for the others.
+func openOutputs(paths []string, lock bool, timed *TimedFileOptions) (zapcore.WriteSyncer, []*ReopenableFile, error) {
+	var files []*ReopenableFile
+	var timedFiles []*TimedFile
+	var sinks []zapcore.WriteSyncer
+	var others []string
+
+	closeAll := func() {
+		closeFiles(files)
+		for _, f := range timedFiles {
+			_ = f.Close()
+		}
+	}
+
+	for _, p := range paths {
+		path, ok := filePath(p)
+		if !ok {
+			others = append(others, p)
+			continue
+		}
+
+		if isTimePattern(path) {
+			f, err := NewTimedFile(path, timed)
+			if err != nil {
+				closeAll()
+				return nil, nil, err
+			}
+
+			timedFiles = append(timedFiles, f)
+			sinks = append(sinks, f)
+			continue
+		}
+
+		f, err := NewReopenableFile(path)
+		if err != nil {
+			closeAll()
+			return nil, nil, err
+		}
+
+		f.SetLocking(lock)
+		files = append(files, f)
+		sinks = append(sinks, f)
+	}
+
+	if len(others) > 0 {
+		ws, _, err := zap.Open(others...)
+		if err != nil {
+			closeAll()
+			return nil, nil, err
+		}
+
+		sinks = append(sinks, ws)
+	}
+
+	if len(sinks) == 1 {
+		return sinks[0], files, nil
+	}
+
+	return zapcore.NewMultiWriteSyncer(sinks...), files, nil
+}
+
+// filePath returns the path of the file designated by an output path, if it designates one.
+func filePath(p string) (string, bool) {
+	if p == "stdout" || p == "stderr" {
+		return "", false
+	}
+
+	// the directives of time patterns aren't valid URL escapes
+	if isTimePattern(p) {
+		if path := strings.TrimPrefix(p, "file://"); !strings.Contains(path, "://") {
+			return path, path != ""
+		}
+	}
+
+	u, err := url.Parse(p)
+	if err != nil || (u.Scheme != "" && u.Scheme != "file") {
+		// let zap deal with other schemes, and with what it can't parse
+		return "", false
+	}
+
+	if u.Scheme == "file" {
+		return u.Path, u.Path != ""
+	}
+
+	return p, true
+}
+
+func closeFiles(files []*ReopenableFile) {
+	for _, f := range files {
+		_ = f.Close()
+	}
+}
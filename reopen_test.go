@@ -0,0 +1,173 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestReopen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "log_reopen")
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "out.log")
+	rotated := path + ".1"
+
+	o := DefaultOptions()
+	o.OutputPaths = []string{path, "file://" + filepath.Join(dir, "other.log")}
+	if err := Configure(o); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = Configure(DefaultOptions()) }()
+
+	Info("before")
+	_ = os.Rename(path, rotated)
+	Info("still in the rotated file")
+
+	if err := Reopen(); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	Info("after")
+	_ = Sync()
+
+	old, _ := ioutil.ReadFile(rotated)
+	if !strings.Contains(string(old), "before") || !strings.Contains(string(old), "still in the rotated file") {
+		t.Errorf("Got '%s', expected the entries written before reopening", old)
+	}
+
+	current, _ := ioutil.ReadFile(path)
+	if !strings.HasSuffix(string(current), "\tafter\n") || strings.Contains(string(current), "before") {
+		t.Errorf("Got '%s', expected only the entry written after reopening", current)
+	}
+
+	other, _ := ioutil.ReadFile(filepath.Join(dir, "other.log"))
+	if strings.Count(string(other), "\n") != 3 {
+		t.Errorf("Got '%s', expected all the entries in the file target", other)
+	}
+}
+
+func TestReopenOnSIGHUP(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("SIGHUP is not supported on windows")
+	}
+
+	dir, err := ioutil.TempDir("", "log_reopen")
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "out.log")
+
+	o := DefaultOptions()
+	o.OutputPaths = []string{path}
+	o.ReopenOnSIGHUP = true
+	if err := Configure(o); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = Configure(DefaultOptions()) }()
+
+	_ = os.Rename(path, path+".1")
+
+	p, _ := os.FindProcess(os.Getpid())
+	_ = p.Signal(syscall.SIGHUP)
+
+	for i := 0; i < 100; i++ {
+		if _, err := os.Stat(path); err == nil {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Error("Expecting the file to be reopened upon SIGHUP")
+}
+
+func TestFilePath(t *testing.T) {
+	cases := []struct {
+		path   string
+		result string
+		ok     bool
+	}{
+		{"stdout", "", false},
+		{"stderr", "", false},
+		{"/var/log/app.log", "/var/log/app.log", true},
+		{"app.log", "app.log", true},
+		{"file:///var/log/app.log", "/var/log/app.log", true},
+		{"tcp://collector:5170", "", false},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if got, ok := filePath(c.path); got != c.result || ok != c.ok {
+				t.Errorf("Got (%v, %v), expected (%v, %v)", got, ok, c.result, c.ok)
+			}
+		})
+	}
+}
+
+func TestReopenableFileSharedAppends(t *testing.T) {
+	dir, err := ioutil.TempDir("", "log_shared")
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "shared.log")
+	line := strings.Repeat("x", 1000) + "\n"
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		rf, err := NewReopenableFile(path)
+		if err != nil {
+			t.Fatalf("Got error '%v', expected success", err)
+		}
+		rf.SetLocking(i%2 == 0)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer rf.Close()
+
+			for j := 0; j < 100; j++ {
+				_, _ = rf.Write([]byte(line))
+			}
+		}()
+	}
+	wg.Wait()
+
+	content, _ := ioutil.ReadFile(path)
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	if len(lines) != 400 {
+		t.Fatalf("Got %d lines, expected 400", len(lines))
+	}
+
+	for i, l := range lines {
+		if l+"\n" != line {
+			t.Fatalf("Got an interleaved line %d: %q", i, l)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package requestid correlates all the log entries produced while serving a request.
+//
+// The Handler middleware assigns an ID to every request, or honors the one supplied by
+// the client, and places it in the request context. Scopes derived from that context
+// add the ID to every entry:
+//
+//	handler = requestid.Handler(handler)
+//
+//	func (h *myHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+//		scope.WithContext(r.Context()).Info("serving")
+//	}
+//
+// The middleware also parses the W3C traceparent and B3 trace headers, adding the trace
+// and span IDs to the entries, which correlates logs and traces even when no tracing SDK
+// is running.
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/tetratelabs/log"
+)
+
+const (
+	// Header is the HTTP header carrying the request ID, in requests and responses.
+	Header = "X-Request-Id"
+
+	// Key is the field under which the request ID is logged.
+	Key = "request_id"
+
+	// maxLength bounds the size of the IDs accepted from clients.
+	maxLength = 128
+)
+
+type contextKey struct{}
+
+// New generates a random request ID.
+func New() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// NewContext returns a copy of the context carrying the given request ID, both for
+// FromContext and as a log field.
+func NewContext(ctx context.Context, id string) context.Context {
+	ctx = context.WithValue(ctx, contextKey{}, id)
+	return log.ContextWithFields(ctx, zap.String(Key, id))
+}
+
+// FromContext returns the request ID carried by the context, or an empty string.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Handler returns a middleware assigning an ID to every request served by the given
+// handler. The ID supplied by the client in the X-Request-Id header is used when it is
+// valid, a new one is generated otherwise. The ID is returned in the response headers.
+//
+// The trace found by TraceFromHeaders, if any, is added to the request context with
+// NewTraceContext.
+func Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(Header)
+		if !valid(id) {
+			id = New()
+		}
+
+		ctx := NewContext(r.Context(), id)
+		if t, ok := TraceFromHeaders(r.Header); ok {
+			ctx = NewTraceContext(ctx, t)
+		}
+
+		w.Header().Set(Header, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// valid reports whether an ID received from a client is safe to propagate and log.
+func valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,77 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package requestid
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/tetratelabs/log"
+)
+
+func TestHandler(t *testing.T) {
+	cases := []struct {
+		incoming string
+		honored  bool
+	}{
+		{"", false},
+		{"abc-123", true},
+		{"with space", false},
+		{strings.Repeat("x", maxLength+1), false},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var got string
+			var fields []zapcore.Field
+			handler := Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = FromContext(r.Context())
+				fields = log.FieldsFromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest("GET", "/", nil)
+			if c.incoming != "" {
+				req.Header.Set(Header, c.incoming)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if c.honored && got != c.incoming {
+				t.Errorf("Got '%v', expected '%v'", got, c.incoming)
+			} else if !c.honored && (got == c.incoming || len(got) != 32) {
+				t.Errorf("Got '%v', expected a generated ID", got)
+			}
+
+			if h := rec.Header().Get(Header); h != got {
+				t.Errorf("Got response header '%v', expected '%v'", h, got)
+			}
+
+			if len(fields) != 1 || fields[0].Key != Key || fields[0].String != got {
+				t.Errorf("Got fields %v, expected a single %v field", fields, Key)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	if a, b := New(), New(); a == b {
+		t.Errorf("Got the same ID '%v' twice, expected unique IDs", a)
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package requestid
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/tetratelabs/log"
+)
+
+// The headers propagating the trace of a request, parsed by the Handler middleware.
+const (
+	// TraceParentHeader is the W3C Trace Context header.
+	TraceParentHeader = "Traceparent"
+	// B3Header is the single B3 propagation header.
+	B3Header = "B3"
+	// B3TraceIDHeader, B3SpanIDHeader and B3SampledHeader are the multiple B3 propagation headers.
+	B3TraceIDHeader = "X-B3-Traceid"
+	B3SpanIDHeader  = "X-B3-Spanid"
+	B3SampledHeader = "X-B3-Sampled"
+	// B3FlagsHeader carries the B3 debug flag, which implies sampling.
+	B3FlagsHeader = "X-B3-Flags"
+)
+
+// The fields under which the identifiers of the trace of a request are logged.
+const (
+	TraceIDKey = log.TraceIDKey
+	SpanIDKey  = log.SpanIDKey
+)
+
+// Trace identifies the trace, and the span within it, a request belongs to.
+type Trace struct {
+	// TraceID is the trace identifier, in lowercase hexadecimal.
+	TraceID string
+	// SpanID is the identifier of the span of the caller, in lowercase hexadecimal.
+	SpanID string
+	// Sampled is the sampling decision of the caller, meaningful when SampledKnown is true.
+	Sampled bool
+	// SampledKnown is false when the caller deferred the sampling decision.
+	SampledKnown bool
+}
+
+type traceContextKey struct{}
+
+// TraceFromHeaders parses the W3C traceparent header or, failing that, the single or
+// multiple B3 headers. It returns false when none of them holds a valid trace, which
+// requires no tracing SDK to be running.
+func TraceFromHeaders(h http.Header) (Trace, bool) {
+	if v := h.Get(TraceParentHeader); v != "" {
+		if t, ok := parseTraceParent(v); ok {
+			return t, true
+		}
+	}
+
+	if v := h.Get(B3Header); v != "" {
+		if t, ok := parseB3(v); ok {
+			return t, true
+		}
+	}
+
+	t := Trace{
+		TraceID: h.Get(B3TraceIDHeader),
+		SpanID:  h.Get(B3SpanIDHeader),
+	}
+	if !validB3TraceID(t.TraceID) || !validHex(t.SpanID, 16) {
+		return Trace{}, false
+	}
+
+	if h.Get(B3FlagsHeader) == "1" {
+		t.Sampled, t.SampledKnown = true, true
+	} else {
+		switch h.Get(B3SampledHeader) {
+		case "1", "true":
+			t.Sampled, t.SampledKnown = true, true
+		case "0", "false":
+			t.SampledKnown = true
+		}
+	}
+
+	return t, true
+}
+
+// NewTraceContext returns a copy of the context carrying the given trace, both for
+// TraceFromContext and as the trace_id and span_id log fields. A known sampling decision
+// is recorded with log.ContextWithTraceSampled, and the span with log.ContextWithSpan.
+func NewTraceContext(ctx context.Context, t Trace) context.Context {
+	ctx = context.WithValue(ctx, traceContextKey{}, t)
+	ctx = log.ContextWithSpan(ctx, log.Span{TraceID: t.TraceID, SpanID: t.SpanID})
+	if t.SampledKnown {
+		ctx = log.ContextWithTraceSampled(ctx, t.Sampled)
+	}
+	return log.ContextWithFields(ctx, zap.String(TraceIDKey, t.TraceID), zap.String(SpanIDKey, t.SpanID))
+}
+
+// TraceFromContext returns the trace carried by the context, if any.
+func TraceFromContext(ctx context.Context) (Trace, bool) {
+	t, ok := ctx.Value(traceContextKey{}).(Trace)
+	return t, ok
+}
+
+// parseTraceParent parses a header of the form version-traceid-parentid-flags.
+func parseTraceParent(v string) (Trace, bool) {
+	parts := strings.Split(strings.TrimSpace(v), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || !isHex(parts[0]) || parts[0] == "ff" {
+		return Trace{}, false
+	}
+
+	// version 00 has exactly four parts, later versions may append more
+	if parts[0] == "00" && len(parts) != 4 {
+		return Trace{}, false
+	}
+
+	if !validHex(parts[1], 32) || !validHex(parts[2], 16) || len(parts[3]) != 2 || !isHex(parts[3]) {
+		return Trace{}, false
+	}
+
+	return Trace{
+		TraceID:      parts[1],
+		SpanID:       parts[2],
+		Sampled:      parts[3][1]&1 == 1, // the sampled flag is the lowest bit
+		SampledKnown: true,
+	}, true
+}
+
+// parseB3 parses a header of the form traceid-spanid[-sampled[-parentspanid]]. Headers
+// holding only a sampling decision carry no trace and are rejected.
+func parseB3(v string) (Trace, bool) {
+	parts := strings.Split(strings.TrimSpace(v), "-")
+	if len(parts) < 2 || len(parts) > 4 || !validB3TraceID(parts[0]) || !validHex(parts[1], 16) {
+		return Trace{}, false
+	}
+
+	t := Trace{TraceID: parts[0], SpanID: parts[1]}
+	if len(parts) > 2 {
+		switch parts[2] {
+		case "1", "d":
+			t.Sampled, t.SampledKnown = true, true
+		case "0":
+			t.SampledKnown = true
+		default:
+			return Trace{}, false
+		}
+	}
+
+	return t, true
+}
+
+// validB3TraceID reports whether id is a 64 or 128 bit B3 trace ID.
+func validB3TraceID(id string) bool {
+	return validHex(id, 16) || validHex(id, 32)
+}
+
+// validHex reports whether s is a non-zero identifier of n lowercase hexadecimal digits.
+func validHex(s string, n int) bool {
+	return len(s) == n && isHex(s) && strings.Trim(s, "0") != ""
+}
+
+func isHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,123 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package requestid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/tetratelabs/log"
+)
+
+const (
+	traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	spanID  = "00f067aa0ba902b7"
+)
+
+func TestTraceFromHeaders(t *testing.T) {
+	cases := []struct {
+		headers  map[string]string
+		ok       bool
+		expected Trace
+	}{
+		{map[string]string{}, false, Trace{}},
+
+		// W3C trace context
+		{map[string]string{"traceparent": "00-" + traceID + "-" + spanID + "-01"}, true, Trace{traceID, spanID, true, true}},
+		{map[string]string{"traceparent": "00-" + traceID + "-" + spanID + "-00"}, true, Trace{traceID, spanID, false, true}},
+		{map[string]string{"traceparent": "01-" + traceID + "-" + spanID + "-01-future"}, true, Trace{traceID, spanID, true, true}},
+		{map[string]string{"traceparent": "00-" + traceID + "-" + spanID + "-01-extra"}, false, Trace{}},
+		{map[string]string{"traceparent": "ff-" + traceID + "-" + spanID + "-01"}, false, Trace{}},
+		{map[string]string{"traceparent": "00-00000000000000000000000000000000-" + spanID + "-01"}, false, Trace{}},
+		{map[string]string{"traceparent": "00-4BF92F3577B34DA6A3CE929D0E0E4736-" + spanID + "-01"}, false, Trace{}},
+
+		// single B3 header
+		{map[string]string{"b3": traceID + "-" + spanID}, true, Trace{traceID, spanID, false, false}},
+		{map[string]string{"b3": traceID + "-" + spanID + "-1-05e3ac9a4f6e3b90"}, true, Trace{traceID, spanID, true, true}},
+		{map[string]string{"b3": spanID + "-" + spanID + "-d"}, true, Trace{spanID, spanID, true, true}},
+		{map[string]string{"b3": traceID + "-" + spanID + "-0"}, true, Trace{traceID, spanID, false, true}},
+		{map[string]string{"b3": "0"}, false, Trace{}},
+
+		// multiple B3 headers
+		{map[string]string{"x-b3-traceid": traceID, "x-b3-spanid": spanID, "x-b3-sampled": "1"}, true, Trace{traceID, spanID, true, true}},
+		{map[string]string{"x-b3-traceid": traceID, "x-b3-spanid": spanID, "x-b3-flags": "1"}, true, Trace{traceID, spanID, true, true}},
+		{map[string]string{"x-b3-traceid": traceID, "x-b3-spanid": spanID}, true, Trace{traceID, spanID, false, false}},
+		{map[string]string{"x-b3-traceid": traceID}, false, Trace{}},
+
+		// traceparent takes precedence
+		{map[string]string{"traceparent": "00-" + traceID + "-" + spanID + "-01", "b3": spanID + "-" + spanID + "-0"}, true, Trace{traceID, spanID, true, true}},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			h := http.Header{}
+			for k, v := range c.headers {
+				h.Set(k, v)
+			}
+
+			got, ok := TraceFromHeaders(h)
+			if ok != c.ok || got != c.expected {
+				t.Errorf("Got %v, %v, expected %v, %v", got, ok, c.expected, c.ok)
+			}
+		})
+	}
+}
+
+func TestHandlerTrace(t *testing.T) {
+	var ctx context.Context
+	handler := Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx = r.Context()
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(TraceParentHeader, "00-"+traceID+"-"+spanID+"-00")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got, ok := TraceFromContext(ctx); !ok || got.TraceID != traceID || got.SpanID != spanID {
+		t.Errorf("Got %v, expected the trace of the headers", got)
+	}
+
+	fields := map[string]string{}
+	for _, f := range log.FieldsFromContext(ctx) {
+		if f.Type == zapcore.StringType {
+			fields[f.Key] = f.String
+		}
+	}
+	if fields[TraceIDKey] != traceID || fields[SpanIDKey] != spanID || fields[Key] == "" {
+		t.Errorf("Got fields %v, expected the request, trace and span IDs", fields)
+	}
+
+	var entries int
+	s := log.NewWithEmit("TestHandlerTrace", "", 0, func(zapcore.Entry, []zapcore.Field) error {
+		entries++
+		return nil
+	})
+	o := log.DefaultOptions()
+	o.TraceSampledOnly = true
+	if err := log.Configure(o); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = log.Configure(log.DefaultOptions()) }()
+
+	s.WithContext(ctx).Info("unsampled")
+	if entries != 0 {
+		t.Errorf("Got %d entries, expected the unsampled trace to suppress info entries", entries)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// ResourceKey is the key of the object holding the attributes of Options.Resource in the
+// JSON entries.
+const ResourceKey = "resource"
+
+// The environment variables of the OpenTelemetry SDKs describing the resource.
+const (
+	otelResourceAttributesEnvVar = "OTEL_RESOURCE_ATTRIBUTES"
+	otelServiceNameEnvVar        = "OTEL_SERVICE_NAME"
+)
+
+// ResourceFromEnv returns the attributes of the resource described by the environment
+// variables OpenTelemetry SDKs read, OTEL_RESOURCE_ATTRIBUTES, a comma-separated list of
+// key=value pairs, and OTEL_SERVICE_NAME, which takes precedence for service.name. This
+// lets processes share the resource of their traces without depending on an SDK:
+//
+//	options.Resource = log.ResourceFromEnv()
+func ResourceFromEnv() map[string]string {
+	attrs := make(map[string]string)
+
+	for _, kv := range strings.Split(os.Getenv(otelResourceAttributesEnvVar), ",") {
+		i := strings.IndexByte(kv, '=')
+		if i <= 0 {
+			continue
+		}
+
+		key := strings.TrimSpace(kv[:i])
+		value := strings.TrimSpace(kv[i+1:])
+		if unescaped, err := url.PathUnescape(value); err == nil {
+			value = unescaped
+		}
+		attrs[key] = value
+	}
+
+	if name := os.Getenv(otelServiceNameEnvVar); name != "" {
+		attrs["service.name"] = name
+	}
+
+	return attrs
+}
+
+// resourceAttributes encodes the attributes of a resource as an object, sorted by key.
+type resourceAttributes map[string]string
+
+func (r resourceAttributes) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	keys := make([]string, 0, len(r))
+	for k := range r {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		enc.AddString(k, r[k])
+	}
+
+	return nil
+}
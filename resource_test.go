@@ -0,0 +1,72 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestResourceFromEnv(t *testing.T) {
+	_ = os.Setenv(otelResourceAttributesEnvVar, "service.name=ignored, service.version=1.2,k8s.pod.name=api%2D0,invalid")
+	_ = os.Setenv(otelServiceNameEnvVar, "api")
+	defer func() {
+		_ = os.Unsetenv(otelResourceAttributesEnvVar)
+		_ = os.Unsetenv(otelServiceNameEnvVar)
+	}()
+
+	expected := map[string]string{
+		"service.name":    "api",
+		"service.version": "1.2",
+		"k8s.pod.name":    "api-0",
+	}
+	if got := ResourceFromEnv(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Got %v, expected %v", got, expected)
+	}
+}
+
+func TestResource(t *testing.T) {
+	lines, err := captureStdout(func() {
+		o := DefaultOptions()
+		o.JSONEncoding = true
+		o.Resource = map[string]string{"service.name": "api", "service.version": "1.2"}
+		if err := Configure(o); err != nil {
+			t.Errorf("Got error '%v', expected success", err)
+		}
+
+		Info("first")
+		Info("second")
+
+		_ = Sync()
+	})
+	_ = Configure(DefaultOptions())
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	for i, line := range lines[:2] {
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &m); err != nil {
+			t.Fatalf("Got error '%v' decoding line %d '%s', expected success", err, i, line)
+		}
+
+		expected := map[string]interface{}{"service.name": "api", "service.version": "1.2"}
+		if !reflect.DeepEqual(m[ResourceKey], expected) {
+			t.Errorf("Got %v, expected %v", m[ResourceKey], expected)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// RouteFunc selects the destination of an entry, from its fields or from the context of
+// the scope, as given to WithContext, which is nil if the scope has none or the entry was
+// not logged through WithContext. It returns nil to leave the entry to the usual outputs.
+type RouteFunc func(ctx context.Context, e zapcore.Entry, fields []zapcore.Field) zapcore.WriteSyncer
+
+// holds the RouteFunc set by SetRouter
+var router atomic.Value
+
+// the destinations flushed by Sync: those given to SetRouter, and those opened by RouteByField
+var routedOutputs struct {
+	sync.Mutex
+	outputs []zapcore.WriteSyncer
+}
+
+func init() {
+	router.Store(RouteFunc(nil))
+}
+
+// SetRouter sets a function choosing the destination of every entry, which takes precedence
+// over the destination set for its scope with SetOutput and the shared outputs. This lets
+// multi-tenant control planes send the entries of each tenant to their own file or stream,
+// see RouteByField. The entries are encoded in the format of their scope. Use nil to remove
+// the router.
+//
+// The destinations the router can return are given along with it, to be flushed by Sync,
+// the destinations opened by RouteByField being added as they're opened. They're never
+// closed by this package.
+func SetRouter(fn RouteFunc, outputs ...zapcore.WriteSyncer) {
+	routedOutputs.Lock()
+	routedOutputs.outputs = append([]zapcore.WriteSyncer(nil), outputs...)
+	routedOutputs.Unlock()
+
+	router.Store(fn)
+}
+
+// addRoutedOutput adds a destination to those flushed by Sync.
+func addRoutedOutput(ws zapcore.WriteSyncer) {
+	routedOutputs.Lock()
+	routedOutputs.outputs = append(routedOutputs.outputs, ws)
+	routedOutputs.Unlock()
+}
+
+// routing returns whether a router is set.
+func routing() bool {
+	return router.Load().(RouteFunc) != nil
+}
+
+// route returns the destination chosen by the router for an entry, if any.
+func route(ctx context.Context, e zapcore.Entry, fields []zapcore.Field) zapcore.WriteSyncer {
+	fn := router.Load().(RouteFunc)
+	if fn == nil {
+		return nil
+	}
+
+	return fn(ctx, e, fields)
+}
+
+// syncRouted flushes the destinations of the router.
+func syncRouted() error {
+	routedOutputs.Lock()
+	outputs := routedOutputs.outputs
+	routedOutputs.Unlock()
+
+	var err error
+	for _, ws := range outputs {
+		if e := ws.Sync(); e != nil && err == nil {
+			err = e
+		}
+	}
+
+	return err
+}
+
+// RouteByField returns a RouteFunc sending the entries carrying a string field with the
+// given key, such as tenant, to the destination returned by open for the value of the
+// field. The destination is opened when the value is first seen, and kept for the entries
+// that follow. Entries without the field go to the usual outputs, as do the entries whose
+// destination can't be opened, the failure being reported to the error output once.
+//
+//	log.SetRouter(log.RouteByField("tenant", func(tenant string) (zapcore.WriteSyncer, error) {
+//		return log.NewReopenableFile(filepath.Join(dir, url.PathEscape(tenant)+".log"))
+//	}))
+//
+// The values of the field come from the callers: open must not trust them with paths.
+func RouteByField(key string, open func(value string) (zapcore.WriteSyncer, error)) RouteFunc {
+	var mu sync.Mutex
+	opened := make(map[string]zapcore.WriteSyncer)
+
+	return func(_ context.Context, _ zapcore.Entry, fields []zapcore.Field) zapcore.WriteSyncer {
+		for i := len(fields) - 1; i >= 0; i-- {
+			if fields[i].Key != key || fields[i].Type != zapcore.StringType {
+				continue
+			}
+
+			value := fields[i].String
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			ws, ok := opened[value]
+			if !ok {
+				var err error
+				if ws, err = open(value); err != nil {
+					ws = nil
+					if es := errorSink.Load().(zapcore.WriteSyncer); es != nil {
+						_, _ = fmt.Fprintf(es, "%v log routing error: can't open the output for %s '%s': %v\n", time.Now(), key, value, err)
+						_ = es.Sync()
+					}
+				} else {
+					addRoutedOutput(ws)
+				}
+				opened[value] = ws
+			}
+
+			return ws
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestRouteByField(t *testing.T) {
+	s := RegisterScope("TestRouteByField", "", 0)
+
+	tenants := map[string]*bufferSyncer{}
+	var opened int
+	lines, err := captureStdout(func() {
+		_ = Configure(DefaultOptions())
+
+		SetRouter(RouteByField("tenant", func(tenant string) (zapcore.WriteSyncer, error) {
+			opened++
+			if tenant == "broken" {
+				return nil, errors.New("can't open")
+			}
+			tenants[tenant] = &bufferSyncer{}
+			return tenants[tenant], nil
+		}))
+		defer SetRouter(nil)
+
+		s.Info("first of a", zap.String("tenant", "a"))
+		s.WithContext(ContextWithFields(context.Background(), zap.String("tenant", "b"))).Info("first of b")
+		s.Info("second of a", zap.String("tenant", "a"))
+		s.Info("no tenant")
+		s.Info("broken", zap.String("tenant", "broken"))
+		s.Info("still broken", zap.String("tenant", "broken"))
+		_ = Sync()
+	})
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	if opened != 3 {
+		t.Errorf("Got %d outputs opened, expected 3", opened)
+	}
+
+	for tenant, expected := range map[string][]string{"a": {"first of a", "second of a"}, "b": {"first of b"}} {
+		got := strings.Split(strings.TrimSpace(tenants[tenant].String()), "\n")
+		if len(got) != len(expected) {
+			t.Fatalf("Got %v for tenant %s, expected %v", got, tenant, expected)
+		}
+		for i := range got {
+			if !strings.Contains(got[i], expected[i]) {
+				t.Errorf("Got '%v' for tenant %s, expected '%v'", got[i], tenant, expected[i])
+			}
+		}
+	}
+
+	if len(lines) != 4 || !strings.Contains(lines[0], "no tenant") || !strings.Contains(lines[1], "broken") {
+		t.Errorf("Got %v, expected the entries without tenant output to stdout", lines)
+	}
+}
+
+func TestRouteByContext(t *testing.T) {
+	s := RegisterScope("TestRouteByContext", "", 0)
+	s.SetFormat(JSONFormat)
+	defer s.SetFormat(DefaultFormat)
+
+	buf := &bufferSyncer{}
+	SetRouter(func(ctx context.Context, _ zapcore.Entry, _ []zapcore.Field) zapcore.WriteSyncer {
+		if ctx != nil && ctx.Value(tenantKey{}) == "a" {
+			return buf
+		}
+		return nil
+	})
+	defer SetRouter(nil)
+
+	_, _ = captureStdout(func() {
+		s.WithContext(context.WithValue(context.Background(), tenantKey{}, "a")).Info("routed")
+		s.Info("not routed")
+	})
+
+	if got := buf.String(); !strings.Contains(got, `"msg":"routed"`) || strings.Contains(got, "not routed") {
+		t.Errorf("Got '%v', expected the entry logged with the context of the tenant", got)
+	}
+}
+
+type syncCountingSyncer struct {
+	bufferSyncer
+	syncs int
+}
+
+func (s *syncCountingSyncer) Sync() error {
+	s.syncs++
+	return nil
+}
+
+func TestRouteToMultiWriteSyncer(t *testing.T) {
+	s := RegisterScope("TestRouteToMultiWriteSyncer", "", 0)
+
+	a, b := &syncCountingSyncer{}, &syncCountingSyncer{}
+	multi := zapcore.NewMultiWriteSyncer(a, b)
+	SetRouter(func(context.Context, zapcore.Entry, []zapcore.Field) zapcore.WriteSyncer {
+		// a comparable type holding a value which can't be a map key
+		return struct{ zapcore.WriteSyncer }{multi}
+	}, multi)
+	defer SetRouter(nil)
+
+	s.Info("first")
+	s.Info("second")
+	_ = Sync()
+
+	for _, ws := range []*syncCountingSyncer{a, b} {
+		if got := strings.Count(ws.String(), "\n"); got != 2 {
+			t.Errorf("Got %d lines, expected 2", got)
+		}
+		if ws.syncs != 1 {
+			t.Errorf("Got %d syncs, expected 1", ws.syncs)
+		}
+	}
+}
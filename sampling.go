@@ -0,0 +1,158 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SampledKey is the key of the field added to the entries kept by sampling.
+const SampledKey = "sampled"
+
+// sampleRates holds the fraction of the entries kept for each level, indexed by level.
+type sampleRates [DebugLevel + 1]float64
+
+var allKept = sampleRates{1, 1, 1, 1, 1}
+
+// serializes the updates of the sample rates of all scopes
+var samplingLock sync.Mutex
+
+// SetSampleRate sets the fraction of the entries of the given level which are emitted,
+// between 0 and 1, the default being 1. The other entries are dropped, and accounted
+// for by DroppedCounts. Entries kept when the rate is below 1 carry a sampled field.
+// Fatal entries are never sampled, as they precede the termination of the process.
+func (s *Scope) SetSampleRate(l Level, rate float64) {
+	if l <= NoneLevel || l > DebugLevel {
+		return
+	}
+
+	if rate < 0 {
+		rate = 0
+	} else if rate > 1 {
+		rate = 1
+	}
+
+	samplingLock.Lock()
+	defer samplingLock.Unlock()
+
+	rates := s.sampling.Load().(sampleRates)
+	rates[l] = rate
+	s.sampling.Store(rates)
+}
+
+// GetSampleRate returns the fraction of the entries of the given level which are emitted.
+func (s *Scope) GetSampleRate(l Level) float64 {
+	if l <= NoneLevel || l > DebugLevel {
+		return 1
+	}
+
+	return s.sampling.Load().(sampleRates)[l]
+}
+
+// sample decides whether an entry of the given level is kept, and returns the field
+// recording the decision when the entry is subject to sampling.
+func (s *Scope) sample(level zapcore.Level) (bool, *zapcore.Field) {
+	if level > zapcore.ErrorLevel {
+		return true, nil
+	}
+
+	var l Level
+	switch {
+	case level == zapcore.ErrorLevel:
+		l = ErrorLevel
+	case level == zapcore.WarnLevel:
+		l = WarnLevel
+	case level == zapcore.InfoLevel:
+		l = InfoLevel
+	default:
+		l = DebugLevel
+	}
+
+	rate := s.sampling.Load().(sampleRates)[l]
+	if rate >= 1 {
+		return true, nil
+	}
+
+	if rate <= 0 || rand.Float64() >= rate {
+		recordDropped(s.name, 1)
+		return false, nil
+	}
+
+	f := zap.Bool(SampledKey, true)
+	return true, &f
+}
+
+// convertScopedSampleRate parses a <scope>:<level>:<rate> setting, the scope being optional.
+func convertScopedSampleRate(ssr string) (string, Level, float64, error) {
+	pieces := strings.Split(ssr, ":")
+	if len(pieces) == 2 {
+		pieces = append([]string{DefaultScopeName}, pieces...)
+	} else if len(pieces) != 3 {
+		return "", NoneLevel, 0, fmt.Errorf("invalid sample rate format '%s'", ssr)
+	}
+
+	level, err := ParseLevel(pieces[1])
+	if err != nil || level == NoneLevel {
+		return "", NoneLevel, 0, fmt.Errorf("invalid sample rate level '%s'", ssr)
+	}
+
+	rate, err := strconv.ParseFloat(pieces[2], 64)
+	if err != nil || rate < 0 || rate > 1 {
+		return "", NoneLevel, 0, fmt.Errorf("invalid sample rate '%s', must be between 0 and 1", ssr)
+	}
+
+	return pieces[0], level, rate, nil
+}
+
+// processSampleRates applies a comma-separated list of sample rates to the scopes. Like
+// for levels, the name of a scope can be replaced by that of a group, or by the global override.
+func processSampleRates(allScopes map[string]*Scope, arg string) error {
+	for _, ssr := range strings.Split(arg, ",") {
+		if ssr == "" {
+			continue
+		}
+
+		name, l, rate, err := convertScopedSampleRate(ssr)
+		if err != nil {
+			return err
+		}
+
+		if scope, ok := allScopes[name]; ok {
+			scope.SetSampleRate(l, rate)
+		} else if name == OverrideScopeName {
+			for _, scope := range allScopes {
+				scope.SetSampleRate(l, rate)
+			}
+		} else if members := GroupScopes(name); members != nil {
+			for _, n := range members {
+				if scope, ok := allScopes[n]; ok {
+					scope.SetSampleRate(l, rate)
+				}
+			}
+		} else {
+			_, _ = fmt.Fprintf(os.Stderr, "unknown scope '%s' specified\n", name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"strconv"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestSampleRate(t *testing.T) {
+	var kept, sampled int
+	s := NewWithEmit("TestSampleRate", "", 0, func(e zapcore.Entry, fields []zapcore.Field) error {
+		kept++
+		for _, f := range fields {
+			if f.Key == SampledKey {
+				sampled++
+			}
+		}
+		return nil
+	})
+	s.SetOutputLevel(DebugLevel)
+	defer s.SetOutputLevel(InfoLevel)
+
+	s.SetSampleRate(DebugLevel, 0)
+	s.SetSampleRate(InfoLevel, 0.5)
+	s.SetSampleRate(WarnLevel, 2)
+	defer s.SetSampleRate(DebugLevel, 1)
+	defer s.SetSampleRate(InfoLevel, 1)
+
+	if s.GetSampleRate(WarnLevel) != 1 || s.GetSampleRate(NoneLevel) != 1 {
+		t.Errorf("Got %v and %v, expected rates clamped to 1", s.GetSampleRate(WarnLevel), s.GetSampleRate(NoneLevel))
+	}
+
+	before := DroppedCounts()["TestSampleRate"]
+
+	for i := 0; i < 100; i++ {
+		s.Debug("debug")
+	}
+
+	if kept != 0 {
+		t.Errorf("Got %d entries, expected none", kept)
+	}
+
+	if got := DroppedCounts()["TestSampleRate"] - before; got != 100 {
+		t.Errorf("Got %d dropped entries, expected 100", got)
+	}
+
+	for i := 0; i < 1000; i++ {
+		s.Info("info")
+	}
+
+	if kept < 350 || kept > 650 || sampled != kept {
+		t.Errorf("Got %d entries, %d with the sampled field, expected about 500 of them", kept, sampled)
+	}
+
+	kept, sampled = 0, 0
+	s.Warn("warn")
+	s.Error("error")
+
+	if kept != 2 || sampled != 0 {
+		t.Errorf("Got %d entries, %d with the sampled field, expected 2 without it", kept, sampled)
+	}
+}
+
+func TestSampleRateFatal(t *testing.T) {
+	var levels []zapcore.Level
+	s := NewWithEmit("TestSampleRateFatal", "", 0, func(e zapcore.Entry, _ []zapcore.Field) error {
+		levels = append(levels, e.Level)
+		return nil
+	})
+
+	s.SetSampleRate(ErrorLevel, 0)
+	defer s.SetSampleRate(ErrorLevel, 1)
+
+	s.Error("dropped")
+	s.emit(zapcore.FatalLevel, false, "kept", nil)
+
+	if len(levels) != 1 || levels[0] != zapcore.FatalLevel {
+		t.Errorf("Got %v, expected only the fatal entry", levels)
+	}
+}
+
+func TestConvertScopedSampleRate(t *testing.T) {
+	cases := []struct {
+		input string
+		scope string
+		level Level
+		rate  float64
+		err   bool
+	}{
+		{"debug:0.01", DefaultScopeName, DebugLevel, 0.01, false},
+		{"foo:info:0.5", "foo", InfoLevel, 0.5, false},
+		{"foo:info:1.5", "", NoneLevel, 0, true},
+		{"foo:none:0.5", "", NoneLevel, 0, true},
+		{"foo:info:half", "", NoneLevel, 0, true},
+		{"0.5", "", NoneLevel, 0, true},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			s, l, r, err := convertScopedSampleRate(c.input)
+			if (err != nil) != c.err {
+				t.Fatalf("Got error '%v', expected an error: %v", err, c.err)
+			}
+
+			if s != c.scope || l != c.level || r != c.rate {
+				t.Errorf("Got (%s, %v, %v), expected (%s, %v, %v)", s, l, r, c.scope, c.level, c.rate)
+			}
+		})
+	}
+}
+
+func TestConfigureSampleRates(t *testing.T) {
+	s := RegisterScope("TestConfigureSampleRates", "", 0)
+	defer s.SetSampleRate(DebugLevel, 1)
+	defer s.SetSampleRate(InfoLevel, 1)
+
+	o := DefaultOptions()
+	o.SampleRates = "TestConfigureSampleRates:debug:0.01,TestConfigureSampleRates:info:0.1"
+	if err := Configure(o); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	if s.GetSampleRate(DebugLevel) != 0.01 || s.GetSampleRate(InfoLevel) != 0.1 || s.GetSampleRate(ErrorLevel) != 1 {
+		t.Errorf("Got %v, expected the configured rates", s.sampling.Load())
+	}
+
+	o.SampleRates = "TestConfigureSampleRates:debug:2"
+	if err := Configure(o); err == nil {
+		t.Error("Got success, expected an error for an invalid rate")
+	}
+
+	_ = Configure(DefaultOptions())
+}
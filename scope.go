@@ -17,6 +17,7 @@
 package log // nolint: golint
 
 import (
+	"context"
 	"fmt"
 	"runtime"
 	"strings"
@@ -32,19 +33,50 @@ import (
 // the level of logging output produced.
 type Scope struct {
 	// immutable, set at creation
+	registry    *Registry
 	name        string
 	nameToEmit  string
 	description string
 	callerSkip  int
 
-	// set by the Configure method and adjustable dynamically
-	outputLevel     atomic.Value
-	stackTraceLevel atomic.Value
-	logCallers      atomic.Value
+	// set by the Configure method and adjustable dynamically, shared with derived scopes
+	outputLevel        *atomic.Value
+	stackTraceLevel    *atomic.Value
+	logCallers         *atomic.Value
+	emitFn             *atomic.Value
+	format             *atomic.Value
+	output             *atomic.Value
+	metric             *atomic.Value
+	keyPolicy          *atomic.Value
+	missingValuePolicy *atomic.Value
+	sampling           *atomic.Value
+	durationMetrics    *atomic.Value
+	scopeFields        *atomic.Value
+	keyNormalizer      *atomic.Value
+
+	// updated by emit, shared with derived scopes
+	stats *scopeStats
+
+	// set when deriving a scope, decides whether an enabled entry is emitted
+	gate func() bool
+	// set when deriving a scope, added to every entry
+	fields []zapcore.Field
+	// set when deriving a scope from a context whose trace isn't sampled
+	traceUnsampled bool
+	// set when deriving a scope from a cancelable context, if its state is reported
+	ctx context.Context
+	// state of the Once, FirstN and Every gates, shared with derived scopes
+	suppressions *sync.Map
+	// set when deriving a scope whose entries are written whatever its output level
+	unleveled bool
+	// set when deriving a scope whose entries are chained, see Options.AuditChain
+	chain *hashChain
+	// set when deriving a scope whose entries are never sampled, such as escalation alerts
+	unsampled bool
 }
 
-var scopes = make(map[string]*Scope)
-var lock = sync.Mutex{}
+// EmitFunc writes a fully-formed log entry to its final destination.
+type EmitFunc func(zapcore.Entry, []zapcore.Field) error
 
 // set by the Configure method
 var writeFn atomic.Value
@@ -59,74 +91,205 @@ func RegisterScope(name string, description string, callerSkip int) *Scope {
 		return nil
 	}
 
-	lock.Lock()
-	defer lock.Unlock()
+	return defaultRegistry.register(name, description, callerSkip, nil)
+}
 
-	s, ok := scopes[name]
-	if !ok {
-		s = &Scope{
-			name:        name,
-			description: description,
-			callerSkip:  callerSkip,
-		}
-		s.SetOutputLevel(InfoLevel)
-		s.SetStackTraceLevel(NoneLevel)
-		s.SetLogCallers(false)
+// newScope returns a scope with the default settings, belonging to the given registry.
+func newScope(r *Registry, name string, description string, callerSkip int) *Scope {
+	s := &Scope{
+		registry:           r,
+		name:               name,
+		description:        description,
+		callerSkip:         callerSkip,
+		outputLevel:        &atomic.Value{},
+		stackTraceLevel:    &atomic.Value{},
+		logCallers:         &atomic.Value{},
+		emitFn:             &atomic.Value{},
+		format:             &atomic.Value{},
+		output:             &atomic.Value{},
+		metric:             &atomic.Value{},
+		keyPolicy:          &atomic.Value{},
+		missingValuePolicy: &atomic.Value{},
+		sampling:           &atomic.Value{},
+		durationMetrics:    &atomic.Value{},
+		scopeFields:        &atomic.Value{},
+		keyNormalizer:      &atomic.Value{},
+		suppressions:       &sync.Map{},
+		stats:              &scopeStats{},
+	}
+	s.emitFn.Store(EmitFunc(nil))
+	s.SetFormat(DefaultFormat)
+	s.SetOutput(nil)
+	s.SetMetric(nil, RecordWhenEmitted)
+	s.SetKeyPolicy(StringifyKeys)
+	s.SetMissingValuePolicy(PadMissing)
+	s.sampling.Store(allKept)
+	s.durationMetrics.Store(map[string]Metric(nil))
+	s.scopeFields.Store([]zapcore.Field(nil))
+	s.keyNormalizer.Store(KeyNormalizer(nil))
+	s.outputLevel.Store(InfoLevel)
+	s.SetStackTraceLevel(NoneLevel)
+	s.SetLogCallers(false)
+
+	if name != DefaultScopeName {
+		s.nameToEmit = name
+	}
+
+	return s
+}
 
-		if name != DefaultScopeName {
-			s.nameToEmit = name
+// WithName returns the child scope named after the scope and the given name, as in
+// "parent.name", registering it on first use. A new child inherits the levels, format,
+// output and fields of the scope at the time, and is controlled independently afterwards,
+// its levels being set through its full name, e.g. --log-output-level parent.name:debug.
+// The levels configured for a child before it's created are applied when it is.
+//
+// The name cannot include colons, commas, or periods, nil is returned otherwise.
+func (s *Scope) WithName(name string) *Scope {
+	if name == "" || strings.ContainsAny(name, ":,.") {
+		return nil
+	}
+
+	return s.registry.register(s.name+"."+name, s.description, s.callerSkip, func(child *Scope) {
+		child.emitFn.Store(s.emitFn.Load())
+		child.SetFormat(s.GetFormat())
+		child.SetOutput(s.GetOutput())
+		child.outputLevel.Store(s.GetOutputLevel())
+		child.SetStackTraceLevel(s.GetStackTraceLevel())
+		child.SetLogCallers(s.GetLogCallers())
+		child.scopeFields.Store(s.scopeFields.Load())
+
+		if c, _ := pendingChildLevels.Load().(*childLevels); c != nil && !s.registry.isolated {
+			if l, ok := c.output[child.name]; ok {
+				child.outputLevel.Store(l)
+			}
+			if l, ok := c.stackTrace[child.name]; ok {
+				child.SetStackTraceLevel(l)
+			}
 		}
+	})
+}
 
-		scopes[name] = s
+// NewWithEmit registers a logging scope like RegisterScope, but whose entries are handed
+// to the given function instead of being written to the configured outputs. The scope
+// otherwise behaves like any other: its levels can be controlled through the command-line
+// flags and it is reported by Scopes.
+//
+// If the scope was already registered, its entries are redirected to the given function.
+func NewWithEmit(name string, description string, callerSkip int, fn EmitFunc) *Scope {
+	s := RegisterScope(name, description, callerSkip)
+	if s != nil {
+		s.SetEmitFunc(fn)
 	}
 
 	return s
 }
 
-// FindScope returns a previously registered scope, or nil if the named scope wasn't previously registered
+// SetEmitFunc hands the entries of the scope to the given function instead of writing them
+// to the configured outputs, like NewWithEmit does. Use nil to write them to the outputs
+// again.
+func (s *Scope) SetEmitFunc(fn EmitFunc) {
+	s.emitFn.Store(fn)
+}
+
+// GetEmitFunc returns the function the entries of the scope are handed to, or nil if they
+// are written to the configured outputs.
+func (s *Scope) GetEmitFunc() EmitFunc {
+	return s.emitFn.Load().(EmitFunc)
+}
+
+// AutoRegisteredDescription is the description of the scopes registered by FindScope when
+// Options.AutoRegisterScopes is set.
+const AutoRegisteredDescription = "registered on first lookup"
+
+// set by the Configure method, 1 when FindScope registers the scopes it doesn't find
+var autoRegisterScopes int32
+
+// FindScope returns a previously registered scope, or nil if the named scope wasn't previously registered.
+// When Options.AutoRegisterScopes is set, unknown scopes are registered instead, with
+// AutoRegisteredDescription, unless their name is invalid. Either way, the lookups of
+// unknown scopes are counted, see ScopeMisses.
 func FindScope(scope string) *Scope {
-	lock.Lock()
-	defer lock.Unlock()
+	return defaultRegistry.FindScope(scope)
+}
 
-	s := scopes[scope]
-	return s
+// GetOrRegisterScope returns the named scope, registering it first if needed, like
+// RegisterScope. Use it where a scope may be looked up before the package owning it
+// registered it, instead of FindScope.
+func GetOrRegisterScope(name string, description string) *Scope {
+	return RegisterScope(name, description, 0)
+}
+
+// ScopeMisses returns the names FindScope was asked for while they weren't registered,
+// with the number of such lookups, which reveals typos and lookups made too early.
+func ScopeMisses() map[string]uint64 {
+	return defaultRegistry.ScopeMisses()
 }
 
 // Scopes returns a snapshot of the currently defined set of scopes
 func Scopes() map[string]*Scope {
-	lock.Lock()
-	defer lock.Unlock()
+	return defaultRegistry.Scopes()
+}
 
-	s := make(map[string]*Scope, len(scopes))
-	for k, v := range scopes {
-		s[k] = v
+// Fatal outputs a message at fatal level, writes a crash report if they are enabled and
+// terminates the process. Fatal messages are output whenever error level is enabled.
+func (s *Scope) Fatal(msg string, fields ...zapcore.Field) {
+	if s.enabled(ErrorLevel) {
+		s.emit(zapcore.FatalLevel, s.GetStackTraceLevel() >= ErrorLevel, msg, fields)
 	}
+	crash(s, msg)
+}
 
-	return s
+// Fatala uses fmt.Sprint to construct and log a message at fatal level, then terminates the process.
+func (s *Scope) Fatala(args ...interface{}) {
+	msg := fmt.Sprint(args...)
+	if s.enabled(ErrorLevel) {
+		s.emit(zapcore.FatalLevel, s.GetStackTraceLevel() >= ErrorLevel, msg, nil)
+	}
+	crash(s, msg)
+}
+
+// Fatalf uses fmt.Sprintf to construct and log a message at fatal level, then terminates the process.
+func (s *Scope) Fatalf(template string, args ...interface{}) {
+	msg, fields := s.formatf(template, args, true)
+	if s.enabled(ErrorLevel) {
+		s.emit(zapcore.FatalLevel, s.GetStackTraceLevel() >= ErrorLevel, msg, fields)
+	}
+	crash(s, msg)
 }
 
 // Error outputs a message at error level.
 func (s *Scope) Error(msg string, fields ...zapcore.Field) {
-	if s.GetOutputLevel() >= ErrorLevel {
+	if s.enabled(ErrorLevel) {
+		errType := fieldsErrorType(fields)
 		s.emit(zapcore.ErrorLevel, s.GetStackTraceLevel() >= ErrorLevel, msg, fields)
+		s.countError(msg, errType)
 	}
 }
 
 // Errora uses fmt.Sprint to construct and log a message at error level.
 func (s *Scope) Errora(args ...interface{}) {
-	if s.GetOutputLevel() >= ErrorLevel {
-		s.emit(zapcore.ErrorLevel, s.GetStackTraceLevel() >= ErrorLevel, fmt.Sprint(args...), nil)
+	if s.enabled(ErrorLevel) {
+		msg := fmt.Sprint(args...)
+		s.emit(zapcore.ErrorLevel, s.GetStackTraceLevel() >= ErrorLevel, msg, nil)
+		s.countError(msg, argsErrorType(args))
 	}
 }
 
 // Errorf uses fmt.Sprintf to construct and log a message at error level.
 func (s *Scope) Errorf(template string, args ...interface{}) {
-	if s.GetOutputLevel() >= ErrorLevel {
-		msg := template
-		if len(args) > 0 {
-			msg = fmt.Sprintf(template, args...)
-		}
-		s.emit(zapcore.ErrorLevel, s.GetStackTraceLevel() >= ErrorLevel, msg, nil)
+	if s.enabled(ErrorLevel) {
+		msg, fields := s.formatf(template, args, s.ErrorEnabled())
+		s.emit(zapcore.ErrorLevel, s.GetStackTraceLevel() >= ErrorLevel, msg, fields)
+		s.countError(template, argsErrorType(args))
+	}
+}
+
+// Errorw outputs a message at error level, with fields built from alternating keys and values.
+func (s *Scope) Errorw(msg string, keysAndValues ...interface{}) {
+	if s.enabled(ErrorLevel) {
+		s.emit(zapcore.ErrorLevel, s.GetStackTraceLevel() >= ErrorLevel, msg, s.keyValueFields(msg, keysAndValues, s.ErrorEnabled()))
+		s.countError(msg, argsErrorType(keysAndValues))
 	}
 }
 
@@ -137,26 +300,30 @@ func (s *Scope) ErrorEnabled() bool {
 
 // Warn outputs a message at warn level.
 func (s *Scope) Warn(msg string, fields ...zapcore.Field) {
-	if s.GetOutputLevel() >= WarnLevel {
+	if s.enabled(WarnLevel) {
 		s.emit(zapcore.WarnLevel, s.GetStackTraceLevel() >= ErrorLevel, msg, fields)
 	}
 }
 
 // Warna uses fmt.Sprint to construct and log a message at warn level.
 func (s *Scope) Warna(args ...interface{}) {
-	if s.GetOutputLevel() >= WarnLevel {
+	if s.enabled(WarnLevel) {
 		s.emit(zapcore.WarnLevel, s.GetStackTraceLevel() >= ErrorLevel, fmt.Sprint(args...), nil)
 	}
 }
 
 // Warnf uses fmt.Sprintf to construct and log a message at warn level.
 func (s *Scope) Warnf(template string, args ...interface{}) {
-	if s.GetOutputLevel() >= WarnLevel {
-		msg := template
-		if len(args) > 0 {
-			msg = fmt.Sprintf(template, args...)
-		}
-		s.emit(zapcore.WarnLevel, s.GetStackTraceLevel() >= ErrorLevel, msg, nil)
+	if s.enabled(WarnLevel) {
+		msg, fields := s.formatf(template, args, s.WarnEnabled())
+		s.emit(zapcore.WarnLevel, s.GetStackTraceLevel() >= ErrorLevel, msg, fields)
+	}
+}
+
+// Warnw outputs a message at warn level, with fields built from alternating keys and values.
+func (s *Scope) Warnw(msg string, keysAndValues ...interface{}) {
+	if s.enabled(WarnLevel) {
+		s.emit(zapcore.WarnLevel, s.GetStackTraceLevel() >= ErrorLevel, msg, s.keyValueFields(msg, keysAndValues, s.WarnEnabled()))
 	}
 }
 
@@ -167,26 +334,30 @@ func (s *Scope) WarnEnabled() bool {
 
 // Info outputs a message at info level.
 func (s *Scope) Info(msg string, fields ...zapcore.Field) {
-	if s.GetOutputLevel() >= InfoLevel {
+	if s.enabled(InfoLevel) {
 		s.emit(zapcore.InfoLevel, s.GetStackTraceLevel() >= ErrorLevel, msg, fields)
 	}
 }
 
 // Infoa uses fmt.Sprint to construct and log a message at info level.
 func (s *Scope) Infoa(args ...interface{}) {
-	if s.GetOutputLevel() >= InfoLevel {
+	if s.enabled(InfoLevel) {
 		s.emit(zapcore.InfoLevel, s.GetStackTraceLevel() >= ErrorLevel, fmt.Sprint(args...), nil)
 	}
 }
 
 // Infof uses fmt.Sprintf to construct and log a message at info level.
 func (s *Scope) Infof(template string, args ...interface{}) {
-	if s.GetOutputLevel() >= InfoLevel {
-		msg := template
-		if len(args) > 0 {
-			msg = fmt.Sprintf(template, args...)
-		}
-		s.emit(zapcore.InfoLevel, s.GetStackTraceLevel() >= ErrorLevel, msg, nil)
+	if s.enabled(InfoLevel) {
+		msg, fields := s.formatf(template, args, s.InfoEnabled())
+		s.emit(zapcore.InfoLevel, s.GetStackTraceLevel() >= ErrorLevel, msg, fields)
+	}
+}
+
+// Infow outputs a message at info level, with fields built from alternating keys and values.
+func (s *Scope) Infow(msg string, keysAndValues ...interface{}) {
+	if s.enabled(InfoLevel) {
+		s.emit(zapcore.InfoLevel, s.GetStackTraceLevel() >= ErrorLevel, msg, s.keyValueFields(msg, keysAndValues, s.InfoEnabled()))
 	}
 }
 
@@ -197,26 +368,30 @@ func (s *Scope) InfoEnabled() bool {
 
 // Debug outputs a message at debug level.
 func (s *Scope) Debug(msg string, fields ...zapcore.Field) {
-	if s.GetOutputLevel() >= DebugLevel {
+	if s.enabled(DebugLevel) {
 		s.emit(zapcore.DebugLevel, s.GetStackTraceLevel() >= ErrorLevel, msg, fields)
 	}
 }
 
 // Debuga uses fmt.Sprint to construct and log a message at debug level.
 func (s *Scope) Debuga(args ...interface{}) {
-	if s.GetOutputLevel() >= DebugLevel {
+	if s.enabled(DebugLevel) {
 		s.emit(zapcore.DebugLevel, s.GetStackTraceLevel() >= ErrorLevel, fmt.Sprint(args...), nil)
 	}
 }
 
 // Debugf uses fmt.Sprintf to construct and log a message at debug level.
 func (s *Scope) Debugf(template string, args ...interface{}) {
-	if s.GetOutputLevel() >= DebugLevel {
-		msg := template
-		if len(args) > 0 {
-			msg = fmt.Sprintf(template, args...)
-		}
-		s.emit(zapcore.DebugLevel, s.GetStackTraceLevel() >= ErrorLevel, msg, nil)
+	if s.enabled(DebugLevel) {
+		msg, fields := s.formatf(template, args, s.DebugEnabled())
+		s.emit(zapcore.DebugLevel, s.GetStackTraceLevel() >= ErrorLevel, msg, fields)
+	}
+}
+
+// Debugw outputs a message at debug level, with fields built from alternating keys and values.
+func (s *Scope) Debugw(msg string, keysAndValues ...interface{}) {
+	if s.enabled(DebugLevel) {
+		s.emit(zapcore.DebugLevel, s.GetStackTraceLevel() >= ErrorLevel, msg, s.keyValueFields(msg, keysAndValues, s.DebugEnabled()))
 	}
 }
 
@@ -238,10 +413,48 @@ func (s *Scope) Description() string {
 const callerSkipOffset = 2
 
 func (s *Scope) emit(level zapcore.Level, dumpStack bool, msg string, fields []zapcore.Field) {
+	if atomic.LoadInt32(&disabled) != 0 {
+		return
+	}
+
+	m := s.metric.Load().(metricHolder)
+	if m.metric != nil && m.policy == RecordAlways {
+		recordEntry(m.metric, level)
+	}
+
+	if !s.unleveled && levelToZap[s.GetOutputLevel()] > level && atomic.LoadInt32(&crashDumpSuppressed) != 0 {
+		s.recordSuppressed(level, msg, fields)
+		return
+	}
+
+	if s.gate != nil && !s.gate() {
+		return
+	}
+
+	if s.traceUnsampled && level < zapcore.WarnLevel && atomic.LoadInt32(&traceSampledOnly) != 0 {
+		return
+	}
+
+	if !s.throttle(level) {
+		return
+	}
+
+	var sampled *zapcore.Field
+	if !s.unsampled {
+		var keep bool
+		if keep, sampled = s.sample(level); !keep {
+			return
+		}
+	}
+
+	if m.metric != nil && m.policy == RecordWhenEmitted {
+		recordEntry(m.metric, level)
+	}
+
 	e := zapcore.Entry{
 		Message:    msg,
 		Level:      level,
-		Time:       time.Now(),
+		Time:       now(),
 		LoggerName: s.nameToEmit,
 	}
 
@@ -253,8 +466,63 @@ func (s *Scope) emit(level zapcore.Level, dumpStack bool, msg string, fields []z
 		e.Stack = zap.Stack("").String
 	}
 
-	if w := writeFn.Load().(func(zapcore.Entry, []zapcore.Field) error); w != nil {
-		if err := w(e, fields); err != nil {
+	if len(s.fields) > 0 {
+		fields = append(s.fields[:len(s.fields):len(s.fields)], fields...)
+	}
+	fields = withStaticFields(s.withScopeFields(fields))
+	s.recordDurations(fields)
+
+	if s.ctx != nil {
+		if xf := s.extractContextFields(s.ctx, msg); len(xf) > 0 {
+			fields = append(fields[:len(fields):len(fields)], xf...)
+		}
+	}
+
+	if s.ctx != nil && level >= zapcore.ErrorLevel && atomic.LoadInt32(&contextErrorFields) != 0 {
+		if cf := contextFields(s.ctx, time.Now()); len(cf) > 0 {
+			fields = append(fields[:len(fields):len(fields)], cf...)
+		}
+	}
+
+	if s.description != "" && atomic.LoadInt32(&scopeDescriptions) != 0 {
+		fields = append(fields[:len(fields):len(fields)], zap.String(ScopeDescriptionKey, s.description))
+	}
+
+	if atomic.LoadInt32(&logGoroutineID) != 0 {
+		if id := goroutineID(); id != 0 {
+			fields = append(fields[:len(fields):len(fields)], zap.Uint64(GoroutineKey, id))
+		}
+	}
+
+	if sampled != nil {
+		fields = append(fields[:len(fields):len(fields)], *sampled)
+	}
+
+	if n := s.keyNormalizer.Load().(KeyNormalizer); n != nil {
+		fields = normalizeKeys(fields, n, msg)
+	}
+
+	fields = processFields(fields)
+	if atomic.LoadInt32(&messageTemplates) != 0 {
+		// once processed, so that redacted values aren't revealed by the message
+		e.Message = renderTemplate(e.Message, fields)
+	}
+	recordForCrash(e, fields)
+	runHooks(e, fields)
+
+	w := s.emitFn.Load().(EmitFunc)
+	if w == nil {
+		if s.GetFormat() != DefaultFormat || s.GetOutput() != nil || routing() || s.chain != nil {
+			w = s.write
+		} else {
+			w = writeFn.Load().(func(zapcore.Entry, []zapcore.Field) error)
+		}
+	}
+
+	if w != nil {
+		err := w(e, fields)
+		s.stats.record(level, now(), err)
+		if err != nil {
 			if es := errorSink.Load().(zapcore.WriteSyncer); es != nil {
 				_, _ = fmt.Fprintf(es, "%v log write error: %v\n", time.Now(), err)
 				_ = es.Sync()
@@ -263,9 +531,41 @@ func (s *Scope) emit(level zapcore.Level, dumpStack bool, msg string, fields []z
 	}
 }
 
-// SetOutputLevel adjusts the output level associated with the scope.
+// WithCallerSkip returns a derived scope reporting, when callers are logged, the caller the
+// given number of frames higher than the scope does. Packages wrapping the scope, e.g. with
+// their own logging functions, use it so that the locations of their callers are reported
+// rather than their own. The derived scope shares the name, levels and settings of the scope.
+func (s *Scope) WithCallerSkip(n int) *Scope {
+	out := s.copy()
+	out.callerSkip += n
+	return out
+}
+
+// copy returns a derived scope sharing the same name, levels and settings.
+func (s *Scope) copy() *Scope {
+	out := *s
+	return &out
+}
+
+// SetOutputLevel adjusts the output level associated with the scope. Functions registered
+// with WatchLevels are notified of the change.
 func (s *Scope) SetOutputLevel(l Level) {
+	s.SetOutputLevelFrom(l, LevelSourceAPI, "")
+}
+
+// SetOutputLevelFrom adjusts the output level associated with the scope like SetOutputLevel,
+// on behalf of the given source and identity, as reported by the level change audit trail.
+// Components letting operators change the levels, such as admin endpoints, use it to record
+// who changed them.
+func (s *Scope) SetOutputLevelFrom(l Level, source string, identity string) {
+	old, set := s.outputLevel.Load().(Level)
 	s.outputLevel.Store(l)
+
+	if set && old != l {
+		c := LevelChange{Scope: s.name, Registry: s.registry, Old: old, New: l, Source: source, Identity: identity}
+		auditLevelChange(s, c)
+		notifyLevelChange(c)
+	}
 }
 
 // GetOutputLevel returns the output level associated with the scope.
@@ -17,11 +17,15 @@
 package log
 
 import (
+	"encoding/json"
 	"errors"
 	"regexp"
+	"runtime"
 	"strconv"
+	"strings"
 	"testing"
 
+	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -39,21 +43,26 @@ func TestBasicScopes(t *testing.T) {
 		{func() { s.Debugf("Hello") }, timePattern + "\tdebug\ttestScope\tHello", false, false, NoneLevel},
 		{func() { s.Debugf("%s", "Hello") }, timePattern + "\tdebug\ttestScope\tHello", false, false, NoneLevel},
 		{func() { s.Debuga("Hello") }, timePattern + "\tdebug\ttestScope\tHello", false, false, NoneLevel},
+		{func() { s.Debugw("Hello", "k", 1) }, timePattern + "\tdebug\ttestScope\tHello\t{\"k\": 1}", false, false, NoneLevel},
 
 		{func() { s.Info("Hello") }, timePattern + "\tinfo\ttestScope\tHello", false, false, NoneLevel},
 		{func() { s.Infof("Hello") }, timePattern + "\tinfo\ttestScope\tHello", false, false, NoneLevel},
 		{func() { s.Infof("%s", "Hello") }, timePattern + "\tinfo\ttestScope\tHello", false, false, NoneLevel},
 		{func() { s.Infoa("Hello") }, timePattern + "\tinfo\ttestScope\tHello", false, false, NoneLevel},
+		{func() { s.Infow("Hello", "k", 1) }, timePattern + "\tinfo\ttestScope\tHello\t{\"k\": 1}", false, false, NoneLevel},
+		{func() { s.Infof("%s", "Hello", KV, "k", 1) }, timePattern + "\tinfo\ttestScope\tHello\t{\"k\": 1}", false, false, NoneLevel},
 
 		{func() { s.Warn("Hello") }, timePattern + "\twarn\ttestScope\tHello", false, false, NoneLevel},
 		{func() { s.Warnf("Hello") }, timePattern + "\twarn\ttestScope\tHello", false, false, NoneLevel},
 		{func() { s.Warnf("%s", "Hello") }, timePattern + "\twarn\ttestScope\tHello", false, false, NoneLevel},
 		{func() { s.Warna("Hello") }, timePattern + "\twarn\ttestScope\tHello", false, false, NoneLevel},
+		{func() { s.Warnw("Hello", "k", 1) }, timePattern + "\twarn\ttestScope\tHello\t{\"k\": 1}", false, false, NoneLevel},
 
 		{func() { s.Error("Hello") }, timePattern + "\terror\ttestScope\tHello", false, false, NoneLevel},
 		{func() { s.Errorf("Hello") }, timePattern + "\terror\ttestScope\tHello", false, false, NoneLevel},
 		{func() { s.Errorf("%s", "Hello") }, timePattern + "\terror\ttestScope\tHello", false, false, NoneLevel},
 		{func() { s.Errora("Hello") }, timePattern + "\terror\ttestScope\tHello", false, false, NoneLevel},
+		{func() { s.Errorw("Hello", "k", 1) }, timePattern + "\terror\ttestScope\tHello\t{\"k\": 1}", false, false, NoneLevel},
 
 		{func() { s.Debug("Hello") }, timePattern + "\tdebug\ttestScope\tlog/scope_test.go:.*\tHello", false, true, NoneLevel},
 
@@ -180,6 +189,36 @@ func TestFind(t *testing.T) {
 	}
 }
 
+func TestFindAutoRegister(t *testing.T) {
+	if z := FindScope("TestFindAutoRegister"); z != nil {
+		t.Error("Found scope, but expected it wouldn't exist")
+	}
+
+	o := DefaultOptions()
+	o.AutoRegisterScopes = true
+	if err := Configure(o); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = Configure(DefaultOptions()) }()
+
+	z := FindScope("TestFindAutoRegister")
+	if z == nil || z.Description() != AutoRegisteredDescription || FindScope("TestFindAutoRegister") != z {
+		t.Errorf("Got %v, expected the scope to be registered", z)
+	}
+
+	if FindScope("Test.FindAutoRegister") != nil {
+		t.Error("Got a scope, expected invalid names not to be registered")
+	}
+
+	if got := ScopeMisses()["TestFindAutoRegister"]; got != 2 {
+		t.Errorf("Got %d, expected 2 misses", got)
+	}
+
+	if s := GetOrRegisterScope("TestFindAutoRegister", "other"); s != z {
+		t.Error("Got another scope, expected the registered one")
+	}
+}
+
 func TestBadNames(t *testing.T) {
 	if s := RegisterScope("a:b", "", 0); s != nil {
 		t.Error("Expecting to get nil")
@@ -208,3 +247,195 @@ func TestBadWriter(t *testing.T) {
 	// inspect it, but it's just not worth it
 	defaultScope.Error("TestBadWriter")
 }
+
+func TestNewWithEmit(t *testing.T) {
+	var entries []zapcore.Entry
+	var fields [][]zapcore.Field
+
+	s := NewWithEmit("TestNewWithEmit", "desc", 0, func(e zapcore.Entry, f []zapcore.Field) error {
+		entries = append(entries, e)
+		fields = append(fields, f)
+		return nil
+	})
+
+	if FindScope("TestNewWithEmit") != s {
+		t.Error("Expecting the scope to be registered")
+	}
+
+	s.SetOutputLevel(InfoLevel)
+	s.Debug("Hidden")
+	s.Info("Hello", zap.String("k", "v"))
+
+	if len(entries) != 1 {
+		t.Fatalf("Got %d entries, expected 1", len(entries))
+	}
+
+	if entries[0].Message != "Hello" || entries[0].LoggerName != "TestNewWithEmit" || entries[0].Level != zapcore.InfoLevel {
+		t.Errorf("Got %+v, expected an info entry with message Hello", entries[0])
+	}
+
+	if len(fields[0]) != 1 || fields[0][0].Key != "k" {
+		t.Errorf("Got %v, expected a single k field", fields[0])
+	}
+
+	if s2 := NewWithEmit("a.b", "", 0, nil); s2 != nil {
+		t.Error("Expecting to get nil")
+	}
+}
+
+func TestWithName(t *testing.T) {
+	var names []string
+	s := NewWithEmit("TestWithName", "parent", 0, func(e zapcore.Entry, _ []zapcore.Field) error {
+		names = append(names, e.LoggerName)
+		return nil
+	})
+	s.SetOutputLevel(DebugLevel)
+
+	child := s.WithName("child")
+	if child == nil || FindScope("TestWithName.child") != child || s.WithName("child") != child {
+		t.Fatalf("Got %v, expected the child scope to be registered once", child)
+	}
+
+	if child.GetOutputLevel() != DebugLevel || child.Description() != "parent" {
+		t.Errorf("Got %v, expected the level of the parent", child.GetOutputLevel())
+	}
+
+	child.Debug("hello")
+	child.WithName("grandchild").Debug("hello")
+	if len(names) != 2 || names[0] != "TestWithName.child" || names[1] != "TestWithName.child.grandchild" {
+		t.Errorf("Got %v, expected the entries of the children on the emit function of the parent", names)
+	}
+
+	// the child is controlled independently
+	child.SetOutputLevel(ErrorLevel)
+	if s.GetOutputLevel() != DebugLevel {
+		t.Errorf("Got %v, expected %v", s.GetOutputLevel(), DebugLevel)
+	}
+
+	o := DefaultOptions()
+	o.SetOutputLevel("TestWithName.child", WarnLevel)
+	_ = Configure(o)
+	defer func() { _ = Configure(DefaultOptions()) }()
+
+	if child.GetOutputLevel() != WarnLevel {
+		t.Errorf("Got %v, expected %v", child.GetOutputLevel(), WarnLevel)
+	}
+
+	for _, name := range []string{"", "a.b", "a:b", "a,b"} {
+		if s.WithName(name) != nil {
+			t.Errorf("Got a scope for '%s', expected nil", name)
+		}
+	}
+}
+
+func TestWithNameAfterConfigure(t *testing.T) {
+	s := RegisterScope("TestWithNameAfterConfigure", "", 0)
+
+	o := DefaultOptions()
+	o.SetOutputLevel("TestWithNameAfterConfigure.child", DebugLevel)
+	o.SetStackTraceLevel("TestWithNameAfterConfigure.child.grandchild", WarnLevel)
+	_ = Configure(o)
+	defer func() { _ = Configure(DefaultOptions()) }()
+
+	cases := []struct {
+		scope      *Scope
+		output     Level
+		stackTrace Level
+	}{
+		{s.WithName("child"), DebugLevel, NoneLevel},
+		{s.WithName("child").WithName("grandchild"), DebugLevel, WarnLevel},
+		{s.WithName("other"), InfoLevel, NoneLevel},
+		{NewRegistry().RegisterScope("TestWithNameAfterConfigure", "", 0).WithName("child"), InfoLevel, NoneLevel},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if c.scope.GetOutputLevel() != c.output {
+				t.Errorf("Got %v, expected %v", c.scope.GetOutputLevel(), c.output)
+			}
+			if c.scope.GetStackTraceLevel() != c.stackTrace {
+				t.Errorf("Got %v, expected %v", c.scope.GetStackTraceLevel(), c.stackTrace)
+			}
+		})
+	}
+}
+
+// wrappedInfo stands for the logging function of a package wrapping scopes.
+func wrappedInfo(s *Scope, msg string) {
+	s.WithCallerSkip(1).Info(msg)
+}
+
+func TestWithCallerSkip(t *testing.T) {
+	var callers []zapcore.EntryCaller
+	s := NewWithEmit("TestWithCallerSkip", "", 0, func(e zapcore.Entry, _ []zapcore.Field) error {
+		callers = append(callers, e.Caller)
+		return nil
+	})
+	s.SetLogCallers(true)
+
+	_, _, line, _ := runtime.Caller(0)
+	wrappedInfo(s, "wrapped")
+
+	if len(callers) != 1 || callers[0].Line != line+1 || !strings.HasSuffix(callers[0].File, "scope_test.go") {
+		t.Errorf("Got %v, expected scope_test.go:%d", callers, line+1)
+	}
+}
+
+func TestEntryPointsShareOptions(t *testing.T) {
+	s := RegisterScope("TestEntryPointsShareOptions", "", 0)
+
+	cases := []struct {
+		log   func(msg string)
+		scope string
+	}{
+		{func(msg string) { Info(msg) }, ""},
+		{func(msg string) { Infow(msg, "k", "v") }, ""},
+		{func(msg string) { s.Info(msg) }, "TestEntryPointsShareOptions"},
+		{func(msg string) { s.Infof("%s", msg) }, "TestEntryPointsShareOptions"},
+		{func(msg string) { s.Infow(msg, "k", "v") }, "TestEntryPointsShareOptions"},
+		{func(msg string) { s.Infoa(msg) }, "TestEntryPointsShareOptions"},
+		{func(msg string) { s.NewEntry(InfoLevel).Str("k", "v").Msg(msg) }, "TestEntryPointsShareOptions"},
+		{func(msg string) { s.Freeze().Info(msg) }, "TestEntryPointsShareOptions"},
+		{func(msg string) { s.WithName("child").Info(msg) }, "TestEntryPointsShareOptions.child"},
+		{func(msg string) { GetOrRegisterScope("TestEntryPointsShareOptions", "").Info(msg) }, "TestEntryPointsShareOptions"},
+	}
+
+	// every entry point writes through the same scopes, so the options of Configure apply to all
+	lines, err := captureStdout(func() {
+		o := DefaultOptions()
+		o.JSONEncoding = true
+		o.ScopeKey = "component"
+		if err := Configure(o); err != nil {
+			t.Fatalf("Got error '%v', expected success", err)
+		}
+
+		for i, c := range cases {
+			c.log(strconv.Itoa(i))
+		}
+		_ = Sync()
+	})
+	_ = Configure(DefaultOptions())
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	if len(lines) != len(cases)+1 {
+		t.Fatalf("Got %q, expected %d lines", lines, len(cases))
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var entry map[string]interface{}
+			if err := json.Unmarshal([]byte(lines[i]), &entry); err != nil {
+				t.Fatalf("Got error '%v' for '%s', expected a JSON entry", err, lines[i])
+			}
+
+			if entry["msg"] != strconv.Itoa(i) {
+				t.Errorf("Got %v, expected %d", entry["msg"], i)
+			}
+			if scope, _ := entry["component"].(string); scope != c.scope {
+				t.Errorf("Got %v, expected %v", scope, c.scope)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sentry forwards error and fatal log entries to Sentry, so that error tracking
+// doesn't require a second call at every error site.
+//
+// The package does not depend on the Sentry SDK. Applications adapt the client they
+// already use to the Client interface and register it:
+//
+//	remove := sentry.Register(myClient, sentry.DefaultOptions())
+//	defer remove()
+//
+// Each entry becomes an event carrying the message, the first error of the entry as the
+// exception, the other fields as extra data, the stack trace when the scope records one,
+// and the scope and level as tags.
+package sentry
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/tetratelabs/log"
+)
+
+// Event is an error tracking event, mirroring the Sentry event attributes filled from log entries.
+type Event struct {
+	Timestamp time.Time
+	// Level is one of debug, info, warning, error and fatal.
+	Level   string
+	Logger  string
+	Message string
+	// Exception is nil when the entry holds no error.
+	Exception *Exception
+	// Stacktrace is the stack trace of the entry, as formatted by the log package, if any.
+	Stacktrace string
+	// Caller is the location of the logging call, if the scope logs callers.
+	Caller string
+	Tags   map[string]string
+	Extra  map[string]interface{}
+}
+
+// Exception describes the error held by an entry.
+type Exception struct {
+	// Type is the Go type of the error.
+	Type string
+	// Value is the error message.
+	Value string
+}
+
+// Client sends events to Sentry.
+type Client interface {
+	// CaptureEvent sends an event. The client may deliver it asynchronously.
+	CaptureEvent(e *Event) error
+
+	// Flush waits until the pending events have been delivered, or the timeout elapsed.
+	Flush(timeout time.Duration) bool
+}
+
+// Options control which entries are forwarded.
+type Options struct {
+	// Level is the lowest level of the forwarded entries. Fatal entries are always forwarded.
+	Level log.Level
+	// SampleRate is the fraction of the entries below fatal level which are forwarded,
+	// between 0 and 1. Fatal entries are always forwarded.
+	SampleRate float64
+	// FlushTimeout bounds how long the delivery of a fatal entry is waited for, before
+	// the process terminates.
+	FlushTimeout time.Duration
+	// Tags are added to every event.
+	Tags map[string]string
+}
+
+// DefaultOptions returns options forwarding every error and fatal entry.
+func DefaultOptions() Options {
+	return Options{
+		Level:        log.ErrorLevel,
+		SampleRate:   1,
+		FlushTimeout: 2 * time.Second,
+	}
+}
+
+var scope = log.RegisterScope("sentry", "Sentry error forwarding.", 0)
+
+// Register forwards the entries of all scopes to the client, according to the options.
+// The returned function stops the forwarding.
+func Register(c Client, o Options) (remove func()) {
+	f := &forwarder{client: c, options: o, rand: rand.New(rand.NewSource(time.Now().UnixNano()))}
+	return log.AddHook(o.Level, f.forward)
+}
+
+type forwarder struct {
+	client  Client
+	options Options
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+func (f *forwarder) forward(e zapcore.Entry, fields []zapcore.Field) {
+	// failures to send events are logged, don't forward them again
+	if e.LoggerName == scope.Name() {
+		return
+	}
+
+	fatal := e.Level >= zapcore.FatalLevel
+	if !fatal && !f.sampled() {
+		return
+	}
+
+	if err := f.client.CaptureEvent(NewEvent(e, fields, f.options.Tags)); err != nil {
+		scope.Warnf("unable to send event to Sentry: %v", err)
+		return
+	}
+
+	// the process terminates once a fatal entry is logged
+	if fatal {
+		f.client.Flush(f.options.FlushTimeout)
+	}
+}
+
+func (f *forwarder) sampled() bool {
+	if f.options.SampleRate >= 1 {
+		return true
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.rand.Float64() < f.options.SampleRate
+}
+
+// NewEvent builds the event describing a log entry.
+func NewEvent(e zapcore.Entry, fields []zapcore.Field, tags map[string]string) *Event {
+	logger := e.LoggerName
+	if logger == "" {
+		logger = log.DefaultScopeName
+	}
+
+	level := sentryLevel(e.Level)
+
+	ev := &Event{
+		Timestamp:  e.Time,
+		Level:      level,
+		Logger:     logger,
+		Message:    e.Message,
+		Stacktrace: e.Stack,
+		Tags:       map[string]string{"scope": logger, "level": level},
+		Extra:      make(map[string]interface{}),
+	}
+
+	for k, v := range tags {
+		ev.Tags[k] = v
+	}
+
+	if e.Caller.Defined {
+		ev.Caller = e.Caller.TrimmedPath()
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range fields {
+		if field.Type == zapcore.ErrorType && ev.Exception == nil {
+			if err, ok := field.Interface.(error); ok {
+				ev.Exception = &Exception{Type: fmt.Sprintf("%T", err), Value: err.Error()}
+				continue
+			}
+		}
+
+		field.AddTo(enc)
+	}
+
+	for k, v := range enc.Fields {
+		ev.Extra[k] = v
+	}
+
+	return ev
+}
+
+func sentryLevel(l zapcore.Level) string {
+	switch {
+	case l >= zapcore.FatalLevel:
+		return "fatal"
+	case l >= zapcore.ErrorLevel:
+		return "error"
+	case l == zapcore.WarnLevel:
+		return "warning"
+	case l == zapcore.InfoLevel:
+		return "info"
+	}
+
+	return "debug"
+}
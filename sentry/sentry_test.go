@@ -0,0 +1,153 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sentry
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/tetratelabs/log"
+)
+
+type fakeClient struct {
+	events  []*Event
+	flushes int
+	fail    bool
+}
+
+func (c *fakeClient) CaptureEvent(e *Event) error {
+	if c.fail {
+		return errors.New("unreachable")
+	}
+
+	c.events = append(c.events, e)
+	return nil
+}
+
+func (c *fakeClient) Flush(time.Duration) bool {
+	c.flushes++
+	return true
+}
+
+func TestNewEvent(t *testing.T) {
+	now := time.Now()
+	err := &os.PathError{Op: "open", Path: "/x", Err: errors.New("denied")}
+
+	e := NewEvent(zapcore.Entry{
+		Level:      zapcore.ErrorLevel,
+		Time:       now,
+		LoggerName: "",
+		Message:    "boom",
+		Stack:      "stack",
+	}, []zapcore.Field{zap.Error(err), zap.String("k", "v"), zap.Error(errors.New("second"))}, map[string]string{"env": "test"})
+
+	if e.Level != "error" || e.Logger != log.DefaultScopeName || e.Message != "boom" || e.Stacktrace != "stack" || !e.Timestamp.Equal(now) {
+		t.Errorf("Got %+v, expected the attributes of the entry", e)
+	}
+
+	if e.Exception == nil || e.Exception.Value != err.Error() || !strings.HasSuffix(e.Exception.Type, ".PathError") {
+		t.Errorf("Got %+v, expected the first error of the entry", e.Exception)
+	}
+
+	if e.Extra["k"] != "v" || e.Extra["error"] != "second" || len(e.Extra) != 2 {
+		t.Errorf("Got %v, expected the other fields", e.Extra)
+	}
+
+	if e.Tags["scope"] != log.DefaultScopeName || e.Tags["level"] != "error" || e.Tags["env"] != "test" {
+		t.Errorf("Got %v, expected the scope, level and given tags", e.Tags)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	s := log.NewWithEmit("TestRegister", "", 0, func(zapcore.Entry, []zapcore.Field) error { return nil })
+	c := &fakeClient{}
+
+	remove := Register(c, DefaultOptions())
+	s.Warn("warn")
+	s.Error("error", zap.Error(errors.New("e")))
+	remove()
+	s.Error("removed")
+
+	if len(c.events) != 1 || c.events[0].Message != "error" || c.events[0].Tags["scope"] != "TestRegister" {
+		t.Fatalf("Got %v, expected only the error entry", c.events)
+	}
+
+	if c.flushes != 0 {
+		t.Errorf("Got %d flushes, expected 0", c.flushes)
+	}
+}
+
+func TestSampling(t *testing.T) {
+	s := log.NewWithEmit("TestSampling", "", 0, func(zapcore.Entry, []zapcore.Field) error { return nil })
+	c := &fakeClient{}
+
+	o := DefaultOptions()
+	o.SampleRate = 0
+	remove := Register(c, o)
+	defer remove()
+
+	for i := 0; i < 10; i++ {
+		s.Error("sampled out")
+	}
+
+	if len(c.events) != 0 {
+		t.Errorf("Got %d events, expected none", len(c.events))
+	}
+}
+
+func TestFailure(t *testing.T) {
+	s := log.NewWithEmit("TestFailure", "", 0, func(zapcore.Entry, []zapcore.Field) error { return nil })
+	c := &fakeClient{fail: true}
+
+	var warnings []string
+	removeWatch := log.AddHook(log.WarnLevel, func(e zapcore.Entry, _ []zapcore.Field) {
+		if e.LoggerName == scope.Name() {
+			warnings = append(warnings, e.Message)
+		}
+	})
+	defer removeWatch()
+
+	remove := Register(c, DefaultOptions())
+	defer remove()
+
+	s.Error("lost")
+
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "unreachable") {
+		t.Errorf("Got %v, expected a single warning about the failure", warnings)
+	}
+}
+
+func TestFatal(t *testing.T) {
+	c := &fakeClient{}
+	o := DefaultOptions()
+	o.SampleRate = 0
+	f := &forwarder{client: c, options: o}
+
+	f.forward(zapcore.Entry{Level: zapcore.FatalLevel, Message: "fatal"}, nil)
+
+	if len(c.events) != 1 || c.events[0].Level != "fatal" {
+		t.Errorf("Got %v, expected the fatal entry regardless of sampling", c.events)
+	}
+
+	if c.flushes != 1 {
+		t.Errorf("Got %d flushes, expected 1", c.flushes)
+	}
+}
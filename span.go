@@ -0,0 +1,136 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// The keys of the fields added by ForSpan.
+const (
+	// TraceIDKey is the key of the identifier of the trace of a span.
+	TraceIDKey = "trace_id"
+	// SpanIDKey is the key of the identifier of a span.
+	SpanIDKey = "span_id"
+	// SpanNameKey is the key of the name of a span.
+	SpanNameKey = "span_name"
+)
+
+// Span describes the span active in a context.
+type Span struct {
+	// TraceID is the identifier of the trace the span belongs to.
+	TraceID string
+	// SpanID is the identifier of the span.
+	SpanID string
+	// Name is the name of the operation the span covers.
+	Name string
+}
+
+// SpanFunc returns the span active in a context. It returns false when the context holds
+// no span.
+type SpanFunc func(ctx context.Context) (Span, bool)
+
+type spanKey struct{}
+
+// holds the SpanFunc used by ForSpan
+var spanFn atomic.Value
+
+func init() {
+	spanFn.Store(SpanFunc(spanFromContext))
+}
+
+// ContextWithSpan returns a copy of the context recording the span active in it, for
+// applications propagating spans themselves.
+func ContextWithSpan(ctx context.Context, sp Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, sp)
+}
+
+func spanFromContext(ctx context.Context) (Span, bool) {
+	sp, ok := ctx.Value(spanKey{}).(Span)
+	return sp, ok
+}
+
+// SetSpanFunc sets how ForSpan finds the span active in a context, typically from the
+// span of a tracing library. The default reads the span recorded by ContextWithSpan. Use
+// nil to revert to the default.
+func SetSpanFunc(fn SpanFunc) {
+	if fn == nil {
+		fn = spanFromContext
+	}
+
+	spanFn.Store(fn)
+}
+
+// ForSpan returns the named scope, registered first if needed like GetOrRegisterScope,
+// bound to the context like WithContext does, and adding the trace and span identifiers
+// and the name of the span active in the context to every entry. This hands the handlers
+// of a request a correlated scope in one call:
+//
+//	s := log.ForSpan(r.Context(), "server")
+//	s.Info("serving")
+//
+// Identifiers already carried by the fields of the context aren't repeated. The default
+// scope is used when the name isn't valid.
+func ForSpan(ctx context.Context, scope string) *Scope {
+	s := GetOrRegisterScope(scope, "")
+	if s == nil {
+		s = defaultScope
+	}
+
+	if ctx == nil {
+		return s
+	}
+
+	s = s.WithContext(ctx)
+
+	sp, ok := spanFn.Load().(SpanFunc)(ctx)
+	if !ok {
+		return s
+	}
+
+	bound := FieldsFromContext(ctx)
+	var fields []zapcore.Field
+	for _, f := range []zapcore.Field{
+		zap.String(TraceIDKey, sp.TraceID),
+		zap.String(SpanIDKey, sp.SpanID),
+		zap.String(SpanNameKey, sp.Name),
+	} {
+		if f.String != "" && !hasFieldKey(bound, f.Key) {
+			fields = append(fields, f)
+		}
+	}
+
+	if len(fields) == 0 {
+		return s
+	}
+
+	out := s.copy()
+	out.fields = append(s.fields[:len(s.fields):len(s.fields)], fields...)
+	return out
+}
+
+func hasFieldKey(fields []zapcore.Field, key string) bool {
+	for _, f := range fields {
+		if f.Key == key {
+			return true
+		}
+	}
+
+	return false
+}
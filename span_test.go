@@ -0,0 +1,73 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestForSpan(t *testing.T) {
+	var keys []string
+	NewWithEmit("TestForSpan", "", 0, func(_ zapcore.Entry, fields []zapcore.Field) error {
+		keys = nil
+		for _, f := range fields {
+			keys = append(keys, f.Key+"="+f.String)
+		}
+		return nil
+	})
+
+	sp := Span{TraceID: "t1", SpanID: "s1", Name: "GET /"}
+	bg := context.Background()
+
+	cases := []struct {
+		ctx      context.Context
+		expected []string
+	}{
+		{bg, nil},
+		{ContextWithSpan(bg, sp), []string{"trace_id=t1", "span_id=s1", "span_name=GET /"}},
+		{ContextWithSpan(bg, Span{TraceID: "t1", SpanID: "s1"}), []string{"trace_id=t1", "span_id=s1"}},
+		{
+			ContextWithSpan(ContextWithFields(bg, zap.String(TraceIDKey, "t1"), zap.String(SpanIDKey, "s1")), sp),
+			[]string{"trace_id=t1", "span_id=s1", "span_name=GET /"},
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			ForSpan(c.ctx, "TestForSpan").Info("msg")
+			if !reflect.DeepEqual(keys, c.expected) {
+				t.Errorf("Got %v, expected %v", keys, c.expected)
+			}
+		})
+	}
+
+	if s := ForSpan(bg, "Test.ForSpan"); s != defaultScope {
+		t.Errorf("Got %v, expected the default scope for an invalid name", s.Name())
+	}
+
+	SetSpanFunc(func(context.Context) (Span, bool) { return Span{Name: "custom"}, true })
+	defer SetSpanFunc(nil)
+
+	ForSpan(bg, "TestForSpan").Info("msg")
+	if expected := []string{"span_name=custom"}; !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Got %v, expected %v", keys, expected)
+	}
+}
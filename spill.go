@@ -0,0 +1,211 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// recordHeaderSize is the size of the length prefixing each record of a spill file.
+const recordHeaderSize = 4
+
+// spillFile is a bounded on-disk queue of entries, which outlives the process. Entries are
+// appended to a file as length-prefixed records, and the position of the oldest entry not
+// delivered yet is kept in a companion file, so that entries are replayed in order after a
+// restart. When the queue is full, the oldest entries are dropped.
+type spillFile struct {
+	path     string
+	maxBytes int64
+
+	f      *os.File
+	offset int64
+	size   int64
+}
+
+// spillFileName returns the name of the spill file of a destination.
+func spillFileName(kind string, destination string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(destination))
+	return fmt.Sprintf("%s-%016x.spill", kind, h.Sum64())
+}
+
+// openSpillFile opens the spill file at the given path, creating it if needed, and resumes
+// from the oldest entry not delivered yet.
+func openSpillFile(path string, maxBytes int) (*spillFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	sf := &spillFile{path: path, maxBytes: int64(maxBytes), f: f, size: info.Size()}
+
+	if b, err := ioutil.ReadFile(sf.offsetPath()); err == nil && len(b) == 8 {
+		sf.offset = int64(binary.BigEndian.Uint64(b))
+	}
+	if sf.offset > sf.size {
+		sf.offset = sf.size
+	}
+
+	return sf, nil
+}
+
+// empty returns whether all the entries were delivered.
+func (sf *spillFile) empty() bool {
+	return sf.offset >= sf.size
+}
+
+// push appends an entry, dropping the oldest entries to make room for it if needed. It
+// returns the number of entries dropped.
+func (sf *spillFile) push(p []byte) (int, error) {
+	need := int64(len(p) + recordHeaderSize)
+	if need > sf.maxBytes {
+		return 1, nil
+	}
+
+	dropped := 0
+	for sf.size-sf.offset+need > sf.maxBytes {
+		if err := sf.pop(); err != nil {
+			return dropped, err
+		}
+		dropped++
+	}
+
+	if err := sf.compact(); err != nil {
+		return dropped, err
+	}
+
+	record := make([]byte, recordHeaderSize+len(p))
+	binary.BigEndian.PutUint32(record, uint32(len(p)))
+	copy(record[recordHeaderSize:], p)
+
+	if _, err := sf.f.WriteAt(record, sf.size); err != nil {
+		return dropped, err
+	}
+	sf.size += int64(len(record))
+
+	return dropped, nil
+}
+
+// peek returns the oldest entry not delivered yet.
+func (sf *spillFile) peek() ([]byte, error) {
+	if sf.empty() {
+		return nil, io.EOF
+	}
+
+	var header [recordHeaderSize]byte
+	if _, err := sf.f.ReadAt(header[:], sf.offset); err != nil {
+		return nil, sf.corrupted(err)
+	}
+
+	n := int64(binary.BigEndian.Uint32(header[:]))
+	if sf.offset+recordHeaderSize+n > sf.size {
+		return nil, sf.corrupted(errors.New("truncated record"))
+	}
+
+	p := make([]byte, n)
+	if _, err := sf.f.ReadAt(p, sf.offset+recordHeaderSize); err != nil {
+		return nil, sf.corrupted(err)
+	}
+
+	return p, nil
+}
+
+// pop forgets the oldest entry, once delivered or dropped.
+func (sf *spillFile) pop() error {
+	var header [recordHeaderSize]byte
+	if _, err := sf.f.ReadAt(header[:], sf.offset); err != nil {
+		return sf.corrupted(err)
+	}
+
+	sf.offset += recordHeaderSize + int64(binary.BigEndian.Uint32(header[:]))
+	if sf.offset >= sf.size {
+		return sf.reset()
+	}
+
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(sf.offset))
+	return ioutil.WriteFile(sf.offsetPath(), b[:], 0644)
+}
+
+// compact rewrites the file without the delivered entries once they take more room than the
+// queue is allowed, so that the file doesn't grow forever.
+func (sf *spillFile) compact() error {
+	if sf.offset <= sf.maxBytes {
+		return nil
+	}
+
+	rest := make([]byte, sf.size-sf.offset)
+	if _, err := sf.f.ReadAt(rest, sf.offset); err != nil {
+		return sf.corrupted(err)
+	}
+
+	if _, err := sf.f.WriteAt(rest, 0); err != nil {
+		return err
+	}
+	if err := sf.f.Truncate(int64(len(rest))); err != nil {
+		return err
+	}
+
+	sf.size = int64(len(rest))
+	sf.offset = 0
+	return os.Remove(sf.offsetPath())
+}
+
+// reset empties the queue.
+func (sf *spillFile) reset() error {
+	sf.offset = 0
+	sf.size = 0
+
+	if err := sf.f.Truncate(0); err != nil {
+		return err
+	}
+
+	if err := os.Remove(sf.offsetPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// corrupted drops the content of a file which can't be read back, and returns the error.
+func (sf *spillFile) corrupted(err error) error {
+	_ = sf.reset()
+	return fmt.Errorf("spill file %s is corrupted, discarding it: %v", sf.path, err)
+}
+
+func (sf *spillFile) close() error {
+	return sf.f.Close()
+}
+
+func (sf *spillFile) offsetPath() string {
+	return sf.path + ".offset"
+}
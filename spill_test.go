@@ -0,0 +1,121 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+)
+
+func drain(t *testing.T, sf *spillFile) []string {
+	var entries []string
+	for {
+		p, err := sf.peek()
+		if err == io.EOF {
+			return entries
+		}
+		if err != nil {
+			t.Fatalf("Got error '%v', expected success", err)
+		}
+
+		entries = append(entries, string(p))
+		if err := sf.pop(); err != nil {
+			t.Fatalf("Got error '%v', expected success", err)
+		}
+	}
+}
+
+func TestSpillFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue", spillFileName("test", "tcp://collector:5170"))
+
+	sf, err := openSpillFile(path, 100)
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	for _, e := range []string{"a", "b", "c"} {
+		if _, err := sf.push([]byte(e)); err != nil {
+			t.Fatalf("Got error '%v', expected success", err)
+		}
+	}
+
+	// deliver one entry, then resume after a restart
+	if p, _ := sf.peek(); string(p) != "a" {
+		t.Errorf("Got %s, expected a", p)
+	}
+	_ = sf.pop()
+	_ = sf.close()
+
+	if sf, err = openSpillFile(path, 100); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = sf.close() }()
+
+	if got := drain(t, sf); len(got) != 2 || got[0] != "b" || got[1] != "c" {
+		t.Errorf("Got %v, expected [b c]", got)
+	}
+
+	if !sf.empty() || sf.size != 0 {
+		t.Errorf("Got %d bytes, expected an empty file once drained", sf.size)
+	}
+}
+
+func TestSpillFileBounds(t *testing.T) {
+	sf, err := openSpillFile(filepath.Join(t.TempDir(), "bounded.spill"), 30)
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = sf.close() }()
+
+	dropped := 0
+	for _, e := range []string{"0123456789", "abcdefghij", "ABCDEFGHIJ"} {
+		n, err := sf.push([]byte(e))
+		if err != nil {
+			t.Fatalf("Got error '%v', expected success", err)
+		}
+		dropped += n
+	}
+
+	if n, _ := sf.push(make([]byte, 40)); n != 1 {
+		t.Errorf("Got %d, expected an entry larger than the file to be dropped", n)
+	}
+
+	if got := drain(t, sf); dropped != 1 || len(got) != 2 || got[0] != "abcdefghij" {
+		t.Errorf("Got %v with %d dropped, expected the oldest entry to be dropped", got, dropped)
+	}
+}
+
+func TestSpillFileCompaction(t *testing.T) {
+	sf, err := openSpillFile(filepath.Join(t.TempDir(), "compacted.spill"), 30)
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = sf.close() }()
+
+	for i := 0; i < 20; i++ {
+		if _, err := sf.push([]byte("0123456789")); err != nil {
+			t.Fatalf("Got error '%v', expected success", err)
+		}
+	}
+
+	if sf.size > 2*30 {
+		t.Errorf("Got a %d bytes file, expected it to be compacted", sf.size)
+	}
+
+	if got := drain(t, sf); len(got) != 2 {
+		t.Errorf("Got %v, expected the two newest entries", got)
+	}
+}
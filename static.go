@@ -0,0 +1,95 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// holds the []zapcore.Field added to every entry
+var staticFields atomic.Value
+
+// serializes the updates of the static fields
+var staticFieldsLock sync.Mutex
+
+// AddStaticFields adds fields to every entry logged by the process from now on, whatever
+// the scope, including the entries captured from the zap and standard library loggers.
+// They come first, before the fields of the scope and of the entry.
+func AddStaticFields(fields ...zapcore.Field) {
+	staticFieldsLock.Lock()
+	defer staticFieldsLock.Unlock()
+
+	existing, _ := staticFields.Load().([]zapcore.Field)
+	all := make([]zapcore.Field, 0, len(existing)+len(fields))
+	all = append(all, existing...)
+	all = append(all, fields...)
+
+	staticFields.Store(all)
+}
+
+// ResetStaticFields removes the fields added by AddStaticFields.
+func ResetStaticFields() {
+	staticFieldsLock.Lock()
+	defer staticFieldsLock.Unlock()
+
+	staticFields.Store([]zapcore.Field(nil))
+}
+
+// withStaticFields returns the fields of an entry preceded by the static fields.
+func withStaticFields(fields []zapcore.Field) []zapcore.Field {
+	static, _ := staticFields.Load().([]zapcore.Field)
+	if len(static) == 0 {
+		return fields
+	}
+
+	return append(static[:len(static):len(static)], fields...)
+}
+
+// AddFields adds fields to every entry logged through the scope from now on, including
+// through the scopes derived from it, such as by WithContext, and through the scope
+// returned by FindScope later on, so that the tags of a subsystem are set once. They come
+// after the static fields and before the fields of derived scopes and of the entry.
+func (s *Scope) AddFields(fields ...zapcore.Field) {
+	staticFieldsLock.Lock()
+	defer staticFieldsLock.Unlock()
+
+	existing := s.scopeFields.Load().([]zapcore.Field)
+	all := make([]zapcore.Field, 0, len(existing)+len(fields))
+	all = append(all, existing...)
+	all = append(all, fields...)
+
+	s.scopeFields.Store(all)
+}
+
+// ResetFields removes the fields added to the scope by AddFields.
+func (s *Scope) ResetFields() {
+	staticFieldsLock.Lock()
+	defer staticFieldsLock.Unlock()
+
+	s.scopeFields.Store([]zapcore.Field(nil))
+}
+
+// withScopeFields returns the fields of an entry preceded by the fields of the scope.
+func (s *Scope) withScopeFields(fields []zapcore.Field) []zapcore.Field {
+	added := s.scopeFields.Load().([]zapcore.Field)
+	if len(added) == 0 {
+		return fields
+	}
+
+	return append(added[:len(added):len(added)], fields...)
+}
@@ -0,0 +1,110 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestStaticFields(t *testing.T) {
+	var keys []string
+	s := NewWithEmit("TestStaticFields", "", 0, func(_ zapcore.Entry, fields []zapcore.Field) error {
+		keys = keys[:0]
+		for _, f := range fields {
+			keys = append(keys, f.Key)
+		}
+		return nil
+	})
+	defer ResetStaticFields()
+
+	AddStaticFields(zap.String("region", "eu"))
+	AddStaticFields(zap.String("zone", "a"))
+	s.WithContext(ContextWithFields(context.Background(), zap.String("ctx", "c"))).Info("hello", zap.String("k", "v"))
+
+	if got := strings.Join(keys, ","); got != "region,zone,ctx,k" {
+		t.Errorf("Got %s, expected region,zone,ctx,k", got)
+	}
+
+	ResetStaticFields()
+	s.Info("hello")
+
+	if len(keys) != 0 {
+		t.Errorf("Got %v, expected no field", keys)
+	}
+}
+
+func TestStaticFieldsCaptured(t *testing.T) {
+	defer ResetStaticFields()
+
+	lines, err := captureStdout(func() {
+		_ = Configure(DefaultOptions())
+		AddStaticFields(zap.String("region", "eu"))
+
+		zap.L().Info("captured")
+		_ = Sync()
+	})
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	if !strings.Contains(lines[0], "captured") || !strings.Contains(lines[0], `"region": "eu"`) {
+		t.Errorf("Got %v, expected the static field", lines[0])
+	}
+}
+
+func TestScopeFields(t *testing.T) {
+	var keys []string
+	s := NewWithEmit("TestScopeFields", "", 0, func(_ zapcore.Entry, fields []zapcore.Field) error {
+		keys = keys[:0]
+		for _, f := range fields {
+			keys = append(keys, f.Key)
+		}
+		return nil
+	})
+	defer ResetStaticFields()
+
+	derived := s.WithContext(ContextWithFields(context.Background(), zap.String("ctx", "c")))
+
+	AddStaticFields(zap.String("region", "eu"))
+	FindScope("TestScopeFields").AddFields(zap.String("subsystem", "cache"))
+
+	cases := []struct {
+		scope    *Scope
+		expected string
+	}{
+		{s, "region,subsystem,k"},
+		{FindScope("TestScopeFields"), "region,subsystem,k"},
+		{derived, "region,subsystem,ctx,k"},
+		{s.WithName("child"), "region,subsystem,k"},
+	}
+
+	for i, c := range cases {
+		c.scope.Info("hello", zap.String("k", "v"))
+		if got := strings.Join(keys, ","); got != c.expected {
+			t.Errorf("%d: Got %s, expected %s", i, got, c.expected)
+		}
+	}
+
+	s.ResetFields()
+	s.Info("hello")
+	if got := strings.Join(keys, ","); got != "region" {
+		t.Errorf("Got %s, expected region", got)
+	}
+}
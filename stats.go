@@ -0,0 +1,126 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// bytes written to the outputs set by Configure since the process started
+var bytesWritten uint64
+
+// LevelCounts holds a number of entries per level.
+type LevelCounts struct {
+	Debug uint64 `json:"debug"`
+	Info  uint64 `json:"info"`
+	Warn  uint64 `json:"warn"`
+	Error uint64 `json:"error"`
+	Fatal uint64 `json:"fatal"`
+}
+
+// ScopeStats reports on the entries of a scope since the process started, which lets health
+// checks detect a wedged or failing log pipeline.
+type ScopeStats struct {
+	// Entries is the number of entries handed to the output of the scope, per level.
+	Entries LevelCounts `json:"entries"`
+	// Dropped is the number of entries dropped by sampling or throttling, as reported by DroppedCounts.
+	Dropped uint64 `json:"dropped"`
+	// LastWrite is when an entry was last written successfully.
+	LastWrite time.Time `json:"last_write"`
+	// LastError is the last error returned by the output of the scope, if any.
+	LastError string `json:"last_error,omitempty"`
+	// LastErrorTime is when LastError happened.
+	LastErrorTime time.Time `json:"last_error_time"`
+}
+
+// scopeStats is updated by emit, and shared with derived scopes.
+type scopeStats struct {
+	entries   [zapcore.FatalLevel - zapcore.DebugLevel + 1]uint64
+	lastWrite int64
+
+	mu            sync.Mutex
+	lastError     error
+	lastErrorTime time.Time
+}
+
+func (st *scopeStats) record(level zapcore.Level, now time.Time, err error) {
+	if level >= zapcore.DebugLevel && level <= zapcore.FatalLevel {
+		atomic.AddUint64(&st.entries[level-zapcore.DebugLevel], 1)
+	}
+
+	if err == nil {
+		atomic.StoreInt64(&st.lastWrite, now.UnixNano())
+		return
+	}
+
+	st.mu.Lock()
+	st.lastError = err
+	st.lastErrorTime = now
+	st.mu.Unlock()
+}
+
+// Stats returns the statistics of the scope, which are shared with the scopes derived from it.
+func (s *Scope) Stats() ScopeStats {
+	st := s.stats
+	count := func(l zapcore.Level) uint64 { return atomic.LoadUint64(&st.entries[l-zapcore.DebugLevel]) }
+
+	out := ScopeStats{
+		Entries: LevelCounts{
+			Debug: count(zapcore.DebugLevel),
+			Info:  count(zapcore.InfoLevel),
+			Warn:  count(zapcore.WarnLevel),
+			Error: count(zapcore.ErrorLevel),
+			Fatal: count(zapcore.FatalLevel),
+		},
+	}
+
+	if c, ok := dropped.Load(s.name); ok {
+		out.Dropped = atomic.LoadUint64(c.(*uint64))
+	}
+
+	if t := atomic.LoadInt64(&st.lastWrite); t != 0 {
+		out.LastWrite = time.Unix(0, t)
+	}
+
+	st.mu.Lock()
+	if st.lastError != nil {
+		out.LastError = st.lastError.Error()
+		out.LastErrorTime = st.lastErrorTime
+	}
+	st.mu.Unlock()
+
+	return out
+}
+
+// BytesWritten returns the number of bytes written to the outputs set by Configure, and to
+// the outputs of scopes set with SetOutput or chosen by the router, since the process started.
+func BytesWritten() uint64 {
+	return atomic.LoadUint64(&bytesWritten)
+}
+
+// countingSink counts the bytes written to the outputs.
+type countingSink struct {
+	zapcore.WriteSyncer
+}
+
+func (c countingSink) Write(p []byte) (int, error) {
+	n, err := c.WriteSyncer.Write(p)
+	atomic.AddUint64(&bytesWritten, uint64(n))
+	return n, err
+}
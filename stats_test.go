@@ -0,0 +1,93 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestScopeStats(t *testing.T) {
+	fail := false
+	s := NewWithEmit("TestScopeStats", "", 0, func(zapcore.Entry, []zapcore.Field) error {
+		if fail {
+			return errors.New("disk full")
+		}
+		return nil
+	})
+	s.SetOutputLevel(DebugLevel)
+	defer s.SetOutputLevel(InfoLevel)
+
+	s.Debug("a")
+	s.WithContext(ContextWithFields(context.Background(), zap.String("k", "v"))).Info("b")
+	s.Info("c")
+	s.Error("d")
+
+	st := s.Stats()
+	expected := LevelCounts{Debug: 1, Info: 2, Error: 1}
+	if st.Entries != expected {
+		t.Errorf("Got %v, expected %v", st.Entries, expected)
+	}
+	if st.LastWrite.IsZero() || st.LastError != "" {
+		t.Errorf("Got %v, expected a write without error", st)
+	}
+
+	fail = true
+	s.Warn("e")
+
+	st = s.Stats()
+	if st.Entries.Warn != 1 || st.LastError != "disk full" || st.LastErrorTime.Before(st.LastWrite) {
+		t.Errorf("Got %v, expected the write error", st)
+	}
+}
+
+func TestBytesWritten(t *testing.T) {
+	before := BytesWritten()
+	_, _ = captureStdout(func() {
+		if err := Configure(DefaultOptions()); err != nil {
+			t.Fatalf("Got error '%v', expected success", err)
+		}
+		Info("counted")
+		_ = Sync()
+	})
+
+	if after := BytesWritten(); after <= before {
+		t.Errorf("Got %v, expected more than %v", after, before)
+	}
+}
+
+func TestBytesWrittenScopeOutputs(t *testing.T) {
+	s := RegisterScope("TestBytesWrittenScopeOutputs", "", 0)
+	own, routed := &bufferSyncer{}, &bufferSyncer{}
+	s.SetOutput(own)
+	defer s.SetOutput(nil)
+
+	before := BytesWritten()
+	s.Info("own")
+	s.Freeze().Info("frozen")
+
+	SetRouter(func(context.Context, zapcore.Entry, []zapcore.Field) zapcore.WriteSyncer { return routed }, routed)
+	defer SetRouter(nil)
+	s.Info("routed")
+
+	written := uint64(own.Len() + routed.Len())
+	if after := BytesWritten(); written == 0 || after-before != written {
+		t.Errorf("Got %v, expected %v", after-before, written)
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// set by the Configure method, the number of entries per second above which a scope
+// is considered in a log storm, 0 when storms aren't handled
+var stormRate int64
+
+// governors holds a *stormGovernor per scopeKey
+var governors sync.Map
+
+// scopeKey identifies a scope across the registries, as the scopes derived from a scope
+// share its name and registry.
+type scopeKey struct {
+	registry *Registry
+	name     string
+}
+
+// stormGovernor tracks the rate of the entries of a scope, second by second.
+type stormGovernor struct {
+	sync.Mutex
+	second  int64
+	count   int64
+	engaged bool
+	dropped uint64
+}
+
+type stormTransition int
+
+const (
+	stormSteady stormTransition = iota
+	stormEngaged
+	stormDisengaged
+)
+
+// allow accounts for an entry, and returns whether it can be emitted along with any change
+// of state of the governor. Once more than limit entries are logged within a second, debug
+// and info entries are dropped until a second sees no more than limit entries.
+func (g *stormGovernor) allow(level zapcore.Level, now time.Time, limit int64) (bool, stormTransition, uint64) {
+	g.Lock()
+	defer g.Unlock()
+
+	transition := stormSteady
+	var dropped uint64
+
+	if sec := now.Unix(); sec != g.second {
+		calm := g.count <= limit || sec > g.second+1
+		g.second = sec
+		g.count = 0
+
+		if g.engaged && calm {
+			g.engaged = false
+			transition, dropped = stormDisengaged, g.dropped
+			g.dropped = 0
+		}
+	}
+
+	g.count++
+	if !g.engaged && g.count > limit {
+		g.engaged = true
+		transition = stormEngaged
+	}
+
+	if g.engaged && level < zapcore.WarnLevel {
+		g.dropped++
+		return false, transition, dropped
+	}
+
+	return true, transition, dropped
+}
+
+// setStormRate changes the storm rate, and forgets the rates observed so far.
+func setStormRate(rate int) {
+	atomic.StoreInt64(&stormRate, int64(rate))
+	governors.Range(func(key, _ interface{}) bool {
+		governors.Delete(key)
+		return true
+	})
+}
+
+// throttle returns whether an entry of the scope can be emitted, given the storm rate.
+func (s *Scope) throttle(level zapcore.Level) bool {
+	limit := atomic.LoadInt64(&stormRate)
+	if limit <= 0 {
+		return true
+	}
+
+	key := scopeKey{s.registry, s.name}
+	g, ok := governors.Load(key)
+	if !ok {
+		g, _ = governors.LoadOrStore(key, &stormGovernor{})
+	}
+
+	allowed, transition, dropped := g.(*stormGovernor).allow(level, now(), limit)
+
+	// the notices are attributed to the call which caused the transition
+	switch transition {
+	case stormEngaged:
+		s.WithCallerSkip(2).emit(zapcore.WarnLevel, false, "log storm detected, suppressing debug and info entries",
+			[]zapcore.Field{zap.Int64("rate_limit", limit)})
+	case stormDisengaged:
+		s.WithCallerSkip(2).emit(zapcore.WarnLevel, false, "log storm over, resuming debug and info entries",
+			[]zapcore.Field{zap.Uint64("suppressed", dropped)})
+	}
+
+	if !allowed {
+		recordDropped(s.name, 1)
+	}
+
+	return allowed
+}
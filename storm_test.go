@@ -0,0 +1,98 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestStormGovernor(t *testing.T) {
+	base := time.Unix(1000, 0)
+	cases := []struct {
+		at         time.Duration
+		level      zapcore.Level
+		allowed    bool
+		transition stormTransition
+		dropped    uint64
+	}{
+		{0, zapcore.InfoLevel, true, stormSteady, 0},
+		{100 * time.Millisecond, zapcore.InfoLevel, true, stormSteady, 0},
+		{200 * time.Millisecond, zapcore.InfoLevel, false, stormEngaged, 0},
+		{300 * time.Millisecond, zapcore.DebugLevel, false, stormSteady, 0},
+		{400 * time.Millisecond, zapcore.WarnLevel, true, stormSteady, 0},
+		// still storming in the next second
+		{time.Second, zapcore.InfoLevel, false, stormSteady, 0},
+		{time.Second + 100*time.Millisecond, zapcore.InfoLevel, false, stormSteady, 0},
+		{time.Second + 200*time.Millisecond, zapcore.ErrorLevel, true, stormSteady, 0},
+		// the previous second exceeded the rate
+		{2 * time.Second, zapcore.InfoLevel, false, stormSteady, 0},
+		// the previous second was calm
+		{3 * time.Second, zapcore.InfoLevel, true, stormDisengaged, 5},
+		{3*time.Second + 100*time.Millisecond, zapcore.InfoLevel, true, stormSteady, 0},
+	}
+
+	g := &stormGovernor{}
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			allowed, transition, dropped := g.allow(c.level, base.Add(c.at), 2)
+			if allowed != c.allowed {
+				t.Errorf("Got %v, expected %v", allowed, c.allowed)
+			}
+			if transition != c.transition {
+				t.Errorf("Got %v, expected transition %v", transition, c.transition)
+			}
+			if dropped != c.dropped {
+				t.Errorf("Got %v, expected %v dropped", dropped, c.dropped)
+			}
+		})
+	}
+}
+
+func TestStormNotice(t *testing.T) {
+	var messages []string
+	s := NewWithEmit("TestStormNotice", "", 0, func(e zapcore.Entry, _ []zapcore.Field) error {
+		messages = append(messages, e.Message)
+		return nil
+	})
+
+	o := DefaultOptions()
+	o.StormRate = 1000
+	if err := Configure(o); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = Configure(DefaultOptions()) }()
+
+	for i := 0; i < 2000; i++ {
+		s.Info("loop")
+	}
+
+	notices := 0
+	for _, m := range messages {
+		if m == "log storm detected, suppressing debug and info entries" {
+			notices++
+		}
+	}
+	if notices != 1 {
+		t.Errorf("Got %d notices, expected 1", notices)
+	}
+	// the loop may straddle two seconds
+	if len(messages) > 2002 || len(messages) < 1001 {
+		t.Errorf("Got %d entries, expected the loop to be throttled", len(messages))
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// suppressionKey identifies the state of an Every gate: one per call site and interval.
+type suppressionKey struct {
+	pc       uintptr
+	interval time.Duration
+}
+
+// Once returns a derived scope that only emits the first entry ever logged through it
+// under the given key. Entries suppressed by their level don't count.
+//
+//	s.Once("deprecated-flag").Warn("the --foo flag is deprecated")
+func (s *Scope) Once(key string) *Scope {
+	return s.FirstN(key, 1)
+}
+
+// FirstN returns a derived scope that only emits the first n entries logged through it
+// under the given key. Entries suppressed by their level don't count.
+func (s *Scope) FirstN(key string, n int) *Scope {
+	c, ok := s.suppressions.Load(key)
+	if !ok {
+		c, _ = s.suppressions.LoadOrStore(key, new(int64))
+	}
+	count := c.(*int64)
+
+	return s.withGate(func() bool {
+		return atomic.AddInt64(count, 1) <= int64(n)
+	})
+}
+
+// Every returns a derived scope that emits at most one entry per interval. The interval
+// is tracked separately for each call site.
+//
+//	s.Every(time.Minute).Warn("connection pool exhausted")
+func (s *Scope) Every(interval time.Duration) *Scope {
+	pc, _, _, _ := runtime.Caller(1)
+	key := suppressionKey{pc: pc, interval: interval}
+
+	l, ok := s.suppressions.Load(key)
+	if !ok {
+		l, _ = s.suppressions.LoadOrStore(key, &everyGate{})
+	}
+	g := l.(*everyGate)
+
+	return s.withGate(func() bool {
+		return g.allow(interval)
+	})
+}
+
+// withGate returns a derived scope that only emits entries when both the existing gate,
+// if any, and the new gate allow it.
+func (s *Scope) withGate(gate func() bool) *Scope {
+	out := s.copy()
+	if prev := s.gate; prev != nil {
+		out.gate = func() bool { return prev() && gate() }
+	} else {
+		out.gate = gate
+	}
+
+	return out
+}
+
+type everyGate struct {
+	sync.Mutex
+	next time.Time
+}
+
+func (g *everyGate) allow(interval time.Duration) bool {
+	t := now()
+
+	g.Lock()
+	defer g.Unlock()
+
+	if t.Before(g.next) {
+		return false
+	}
+
+	g.next = t.Add(interval)
+	return true
+}
@@ -0,0 +1,94 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSuppression(t *testing.T) {
+	cases := []struct {
+		name string
+		f    func(s *Scope)
+		want int
+	}{
+		{"once", func(s *Scope) {
+			for i := 0; i < 3; i++ {
+				s.Once("a").Info("Hello")
+			}
+			s.Once("b").Info("Hello")
+		}, 2},
+		{"firstN", func(s *Scope) {
+			for i := 0; i < 5; i++ {
+				s.FirstN("a", 3).Info("Hello")
+			}
+		}, 3},
+		{"every", func(s *Scope) {
+			for i := 0; i < 3; i++ {
+				s.Every(time.Hour).Info("Hello")
+			}
+			s.Every(time.Hour).Info("Hello from another call site")
+		}, 2},
+		{"level", func(s *Scope) {
+			// entries suppressed by level don't consume the budget
+			s.Once("a").Debug("Hello")
+			s.Once("a").Info("Hello")
+		}, 1},
+		{"combined", func(s *Scope) {
+			for i := 0; i < 3; i++ {
+				s.FirstN("a", 2).Once("b").Info("Hello")
+			}
+		}, 1},
+	}
+
+	for i, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s := RegisterScope("TestSuppression"+strconv.Itoa(i), "", 0)
+
+			lines, err := captureStdout(func() {
+				_ = Configure(DefaultOptions())
+				c.f(s)
+				_ = Sync()
+			})
+			if err != nil {
+				t.Fatalf("Got error '%v', expected success", err)
+			}
+
+			// the output ends with a newline, hence the extra empty line
+			if got := len(lines) - 1; got != c.want {
+				t.Errorf("Got %d entries, expected %d", got, c.want)
+			}
+		})
+	}
+}
+
+func TestEveryElapsed(t *testing.T) {
+	g := &everyGate{}
+
+	if !g.allow(time.Millisecond) {
+		t.Error("Got false, expected the first entry to be allowed")
+	}
+
+	if g.allow(time.Millisecond) {
+		t.Error("Got true, expected the second entry to be suppressed")
+	}
+
+	time.Sleep(2 * time.Millisecond)
+	if !g.allow(time.Millisecond) {
+		t.Error("Got false, expected an entry to be allowed after the interval")
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// set by the Configure method, 1 when messages are rendered as templates
+var messageTemplates int32
+
+// renderTemplate replaces the {key} placeholders of a message with the values of the fields
+// of the same keys, the last one winning. Placeholders without a field are left as they are.
+func renderTemplate(msg string, fields []zapcore.Field) string {
+	if len(fields) == 0 || strings.IndexByte(msg, '{') < 0 {
+		return msg
+	}
+
+	var values *zapcore.MapObjectEncoder
+	var b strings.Builder
+
+	i := 0
+	for {
+		open := strings.IndexByte(msg[i:], '{')
+		if open < 0 {
+			break
+		}
+		open += i
+
+		end := strings.IndexByte(msg[open+1:], '}')
+		if end < 0 {
+			break
+		}
+		end += open + 1
+
+		if values == nil {
+			values = zapcore.NewMapObjectEncoder()
+			for _, f := range fields {
+				f.AddTo(values)
+			}
+		}
+
+		v, ok := values.Fields[msg[open+1:end]]
+		if !ok {
+			// not a placeholder, keep the brace and look for the next one
+			b.WriteString(msg[i : open+1])
+			i = open + 1
+			continue
+		}
+
+		b.WriteString(msg[i:open])
+		_, _ = fmt.Fprint(&b, v)
+		i = end + 1
+	}
+	b.WriteString(msg[i:])
+
+	return b.String()
+}
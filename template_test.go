@@ -0,0 +1,76 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"strconv"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	fields := []zapcore.Field{zap.String("user", "alice"), zap.Int("n", 3), zap.Bool("ok", true)}
+	cases := []struct {
+		msg      string
+		expected string
+	}{
+		{"user {user} logged in", "user alice logged in"},
+		{"{user}: {n} attempts, ok={ok}", "alice: 3 attempts, ok=true"},
+		{"{unknown} {user}", "{unknown} alice"},
+		{"{{user}}", "{alice}"},
+		{"unterminated {user", "unterminated {user"},
+		{"no placeholders", "no placeholders"},
+		{"{}", "{}"},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if got := renderTemplate(c.msg, fields); got != c.expected {
+				t.Errorf("Got %v, expected %v", got, c.expected)
+			}
+		})
+	}
+}
+
+func TestMessageTemplates(t *testing.T) {
+	var msgs []string
+	var fields [][]zapcore.Field
+	s := NewWithEmit("TestMessageTemplates", "", 0, func(e zapcore.Entry, f []zapcore.Field) error {
+		msgs = append(msgs, e.Message)
+		fields = append(fields, f)
+		return nil
+	})
+
+	s.Infow("user {user} logged in", "user", "alice")
+
+	o := DefaultOptions()
+	o.MessageTemplates = true
+	if err := Configure(o); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = Configure(DefaultOptions()) }()
+
+	s.Infow("user {user} logged in", "user", "alice")
+
+	expected := []string{"user {user} logged in", "user alice logged in"}
+	if len(msgs) != 2 || msgs[0] != expected[0] || msgs[1] != expected[1] {
+		t.Errorf("Got %v, expected %v", msgs, expected)
+	}
+	if len(fields) != 2 || len(fields[1]) != 1 || fields[1][0].Key != "user" {
+		t.Errorf("Got %v, expected the user field to be kept", fields)
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// defaultTextTemplate is the template of TemplateFormat unless Options.TextTemplate is set.
+const defaultTextTemplate = "{time}\t{level}\t{scope}\t{msg}\t{fields}"
+
+// templateElement is what a part of a compiled template outputs.
+type templateElement int
+
+const (
+	literalElement templateElement = iota
+	timeElement
+	levelElement
+	upperLevelElement
+	scopeElement
+	msgElement
+	callerElement
+	fieldsElement
+	fieldElement
+)
+
+var templateElements = map[string]templateElement{
+	"time":   timeElement,
+	"level":  levelElement,
+	"LEVEL":  upperLevelElement,
+	"scope":  scopeElement,
+	"msg":    msgElement,
+	"caller": callerElement,
+	"fields": fieldsElement,
+}
+
+type templatePart struct {
+	element templateElement
+	// the text of a literal, or the key of a field
+	text string
+}
+
+// compileTextTemplate splits a template into the parts output for every entry. The
+// placeholders are enclosed in braces, and literal braces are doubled.
+func compileTextTemplate(tmpl string) ([]templatePart, error) {
+	var parts []templatePart
+	var literal strings.Builder
+
+	flush := func() {
+		if literal.Len() > 0 {
+			parts = append(parts, templatePart{element: literalElement, text: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(tmpl); i++ {
+		switch c := tmpl[i]; {
+		case c == '{' && strings.HasPrefix(tmpl[i:], "{{"), c == '}' && strings.HasPrefix(tmpl[i:], "}}"):
+			literal.WriteByte(c)
+			i++
+
+		case c == '{':
+			end := strings.IndexByte(tmpl[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated placeholder at offset %d", i)
+			}
+
+			name := tmpl[i+1 : i+end]
+			if name == "" || strings.ContainsAny(name, "{ ") {
+				return nil, fmt.Errorf("invalid placeholder '%s' at offset %d", tmpl[i:i+end+1], i)
+			}
+
+			flush()
+			if e, ok := templateElements[name]; ok {
+				parts = append(parts, templatePart{element: e})
+			} else {
+				parts = append(parts, templatePart{element: fieldElement, text: name})
+			}
+			i += end
+
+		case c == '}':
+			return nil, fmt.Errorf("unexpected '}' at offset %d, use '}}' for a literal brace", i)
+
+		default:
+			literal.WriteByte(c)
+		}
+	}
+	flush()
+
+	if len(parts) == 0 {
+		return nil, errors.New("empty template")
+	}
+
+	return parts, nil
+}
+
+// templateEncoder outputs each entry on a line laid out by a template, such as
+// "{time} [{LEVEL}] {scope} {msg} {fields}", which lets the output match a legacy format.
+// Besides the elements of the entry, placeholders can name a field, whose value is then
+// output in place and left out of {fields}. The other fields are output as key=value pairs.
+type templateEncoder struct {
+	*zapcore.MapObjectEncoder
+
+	parts []templatePart
+	// the keys of the fields having their own placeholder
+	placed map[string]struct{}
+}
+
+var templateBuffers = buffer.NewPool()
+
+func newTemplateEncoder(tmpl string) (zapcore.Encoder, error) {
+	parts, err := compileTextTemplate(tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	placed := make(map[string]struct{})
+	for _, p := range parts {
+		if p.element == fieldElement {
+			placed[p.text] = struct{}{}
+		}
+	}
+
+	return &templateEncoder{
+		MapObjectEncoder: zapcore.NewMapObjectEncoder(),
+		parts:            parts,
+		placed:           placed,
+	}, nil
+}
+
+func (enc *templateEncoder) Clone() zapcore.Encoder {
+	clone := &templateEncoder{
+		MapObjectEncoder: zapcore.NewMapObjectEncoder(),
+		parts:            enc.parts,
+		placed:           enc.placed,
+	}
+
+	for k, v := range enc.Fields {
+		clone.Fields[k] = v
+	}
+
+	return clone
+}
+
+func (enc *templateEncoder) EncodeEntry(e zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	// fields added through With come first, in a stable order
+	m := zapcore.NewMapObjectEncoder()
+	for k, v := range enc.Fields {
+		m.Fields[k] = v
+	}
+	keys := sortedKeys(m.Fields)
+
+	for _, f := range fields {
+		fm := zapcore.NewMapObjectEncoder()
+		f.AddTo(fm)
+		for _, k := range sortedKeys(fm.Fields) {
+			if _, ok := m.Fields[k]; !ok {
+				keys = append(keys, k)
+			}
+			m.Fields[k] = fm.Fields[k]
+		}
+	}
+
+	buf := templateBuffers.Get()
+	for _, p := range enc.parts {
+		switch p.element {
+		case literalElement:
+			buf.AppendString(p.text)
+		case timeElement:
+			buf.AppendString(dateString(e.Time))
+		case levelElement:
+			buf.AppendString(e.Level.String())
+		case upperLevelElement:
+			buf.AppendString(e.Level.CapitalString())
+		case scopeElement:
+			buf.AppendString(e.LoggerName)
+		case msgElement:
+			buf.AppendString(e.Message)
+		case callerElement:
+			if e.Caller.Defined {
+				buf.AppendString(e.Caller.TrimmedPath())
+			}
+		case fieldElement:
+			if v, ok := m.Fields[p.text]; ok {
+				buf.AppendString(prettyValue(v))
+			}
+		case fieldsElement:
+			first := true
+			for _, k := range keys {
+				if _, ok := enc.placed[k]; ok {
+					continue
+				}
+
+				if !first {
+					buf.AppendByte(' ')
+				}
+				first = false
+
+				buf.AppendString(k)
+				buf.AppendByte('=')
+				buf.AppendString(quoteTextValue(prettyValue(m.Fields[k])))
+			}
+		}
+	}
+	buf.AppendString(zapcore.DefaultLineEnding)
+
+	if e.Stack != "" {
+		buf.AppendString(e.Stack)
+		buf.AppendString(zapcore.DefaultLineEnding)
+	}
+
+	return buf, nil
+}
+
+// quoteTextValue quotes the values which would be ambiguous in a list of key=value pairs.
+func quoteTextValue(v string) string {
+	if v == "" || strings.ContainsAny(v, " =\"\t\r\n") {
+		return strconv.Quote(v)
+	}
+
+	return v
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
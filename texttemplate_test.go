@@ -0,0 +1,120 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestTemplateEncoder(t *testing.T) {
+	e := zapcore.Entry{
+		Level:      zapcore.WarnLevel,
+		Time:       time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC),
+		LoggerName: "scope",
+		Message:    "Hello",
+		Caller:     zapcore.NewEntryCaller(0, "/src/pkg/file.go", 12, true),
+	}
+	fields := []zapcore.Field{zap.String("k", "v"), zap.Int("n", 1), zap.String("request_id", "r1"), zap.Error(errors.New("with space"))}
+
+	cases := []struct {
+		template string
+		result   string
+	}{
+		{defaultTextTemplate, "2000-01-01T00:00:00.000000Z\twarn\tscope\tHello\tk=v n=1 request_id=r1 error=\"with space\"\n"},
+		{"{time} [{LEVEL}] {scope} {msg} {fields}", "2000-01-01T00:00:00.000000Z [WARN] scope Hello k=v n=1 request_id=r1 error=\"with space\"\n"},
+		{"{{{request_id}}} {caller}: {msg} ({fields})", "{r1} pkg/file.go:12: Hello (k=v n=1 error=\"with space\")\n"},
+		{"{msg} {missing}", "Hello \n"},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			enc, err := newTemplateEncoder(c.template)
+			if err != nil {
+				t.Fatalf("Got error '%v', expected success", err)
+			}
+
+			buf, err := enc.EncodeEntry(e, fields)
+			if err != nil {
+				t.Fatalf("Got error '%v', expected success", err)
+			}
+
+			if got := buf.String(); got != c.result {
+				t.Errorf("Got '%v', expected '%v'", got, c.result)
+			}
+		})
+	}
+}
+
+func TestTemplateEncoderWith(t *testing.T) {
+	enc, _ := newTemplateEncoder("{msg} {tenant} {fields}")
+	zap.String("tenant", "a").AddTo(enc)
+	zap.String("b", "1").AddTo(enc)
+
+	clone := enc.Clone()
+	zap.String("c", "2").AddTo(clone)
+
+	buf, _ := clone.EncodeEntry(zapcore.Entry{Message: "Hello"}, []zapcore.Field{zap.String("d", "3")})
+	if got, expected := buf.String(), "Hello a b=1 c=2 d=3\n"; got != expected {
+		t.Errorf("Got '%v', expected '%v'", got, expected)
+	}
+
+	buf, _ = enc.EncodeEntry(zapcore.Entry{Message: "Hello"}, nil)
+	if got, expected := buf.String(), "Hello a b=1\n"; got != expected {
+		t.Errorf("Got '%v', expected '%v'", got, expected)
+	}
+}
+
+func TestTemplateErrors(t *testing.T) {
+	for i, template := range []string{"", "{msg", "{}", "{a b}", "msg}"} {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if _, err := newTemplateEncoder(template); err == nil {
+				t.Errorf("Got success, expected an error for '%v'", template)
+			}
+
+			o := DefaultOptions()
+			o.TextTemplate = template
+			if template != "" && Configure(o) == nil {
+				t.Errorf("Got success, expected Configure to fail for '%v'", template)
+			}
+		})
+	}
+}
+
+func TestTextTemplate(t *testing.T) {
+	lines, err := captureStdout(func() {
+		o := DefaultOptions()
+		o.TextTemplate = "[{LEVEL}] {msg} {fields}"
+		if err := Configure(o); err != nil {
+			t.Errorf("Got error '%v', expected success", err)
+		}
+
+		Info("Hello", zap.String("k", "v"))
+		_ = Sync()
+	})
+	_ = Configure(DefaultOptions())
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	if expected := "[INFO] Hello k=v"; lines[0] != expected {
+		t.Errorf("Got '%v', expected '%v'", lines[0], expected)
+	}
+}
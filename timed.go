@@ -0,0 +1,78 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DurationKey is the key of the field holding the duration of the operations timed by Start.
+const DurationKey = "duration"
+
+// Start logs the start of an operation at debug level, and returns a function to call
+// with the outcome of the operation once it's done. The function logs the end of the
+// operation with its duration, at debug level when it succeeded, or at error level along
+// with the error when it failed. Both entries carry the given keys and values.
+//
+//	func reconcile(name string) (err error) {
+//		done := s.Start("reconcile", "cluster", name)
+//		defer func() { done(err) }()
+//		...
+//	}
+func (s *Scope) Start(op string, keysAndValues ...interface{}) (done func(err error)) {
+	return s.start(nil, op, keysAndValues)
+}
+
+// StartWithLatency is like Start, and additionally records the duration of the operation,
+// in seconds, in the given metric.
+func (s *Scope) StartWithLatency(m Metric, op string, keysAndValues ...interface{}) (done func(err error)) {
+	return s.start(m, op, keysAndValues)
+}
+
+func (s *Scope) start(m Metric, op string, keysAndValues []interface{}) func(error) {
+	var fields []zapcore.Field
+	if s.GetOutputLevel() >= ErrorLevel {
+		fields = s.keyValueFields(op, keysAndValues, true)
+	}
+
+	if s.enabled(DebugLevel) {
+		// report the caller of Start rather than Start itself
+		s.WithCallerSkip(1).emit(zapcore.DebugLevel, s.GetStackTraceLevel() >= DebugLevel, op+" started", fields)
+	}
+
+	begin := now()
+
+	return func(err error) {
+		d := now().Sub(begin)
+		if m != nil {
+			m.Record(d.Seconds())
+		}
+
+		if err != nil {
+			if s.enabled(ErrorLevel) {
+				s.emit(zapcore.ErrorLevel, s.GetStackTraceLevel() >= ErrorLevel, op+" failed",
+					append(fields[:len(fields):len(fields)], zap.Duration(DurationKey, d), zap.Error(err)))
+				s.countError(op+" failed", argsErrorType([]interface{}{err}))
+			}
+			return
+		}
+
+		if s.enabled(DebugLevel) {
+			s.emit(zapcore.DebugLevel, s.GetStackTraceLevel() >= DebugLevel, op+" done",
+				append(fields[:len(fields):len(fields)], zap.Duration(DurationKey, d)))
+		}
+	}
+}
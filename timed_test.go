@@ -0,0 +1,93 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+type latencyMetric struct {
+	values []float64
+}
+
+func (m *latencyMetric) Record(v float64) {
+	m.values = append(m.values, v)
+}
+
+func TestStart(t *testing.T) {
+	type entry struct {
+		level  zapcore.Level
+		msg    string
+		caller string
+		keys   string
+	}
+
+	var entries []entry
+	s := NewWithEmit("TestStart", "", 0, func(e zapcore.Entry, fields []zapcore.Field) error {
+		var keys []string
+		for _, f := range fields {
+			keys = append(keys, f.Key)
+		}
+		entries = append(entries, entry{e.Level, e.Message, e.Caller.TrimmedPath(), strings.Join(keys, ",")})
+		return nil
+	})
+	s.SetOutputLevel(DebugLevel)
+	s.SetLogCallers(true)
+	defer s.SetOutputLevel(InfoLevel)
+	defer s.SetLogCallers(false)
+
+	m := &latencyMetric{}
+	done := s.StartWithLatency(m, "reconcile", "cluster", "c1")
+	done(nil)
+	done = s.Start("reconcile", "cluster", "c2")
+	done(errors.New("boom"))
+
+	expected := []entry{
+		{zapcore.DebugLevel, "reconcile started", "", "cluster"},
+		{zapcore.DebugLevel, "reconcile done", "", "cluster,duration"},
+		{zapcore.DebugLevel, "reconcile started", "", "cluster"},
+		{zapcore.ErrorLevel, "reconcile failed", "", "cluster,duration,error"},
+	}
+
+	if len(entries) != len(expected) {
+		t.Fatalf("Got %v, expected %v", entries, expected)
+	}
+
+	for i := range entries {
+		if !strings.Contains(entries[i].caller, "timed_test.go") {
+			t.Errorf("Got caller %s, expected timed_test.go", entries[i].caller)
+		}
+
+		entries[i].caller = ""
+		if entries[i] != expected[i] {
+			t.Errorf("Got %v, expected %v", entries[i], expected[i])
+		}
+	}
+
+	if len(m.values) != 1 || m.values[0] < 0 {
+		t.Errorf("Got %v, expected a single latency", m.values)
+	}
+
+	entries = nil
+	s.SetOutputLevel(InfoLevel)
+	s.Start("quiet")(nil)
+	if len(entries) != 0 {
+		t.Errorf("Got %v, expected no entry at info level", entries)
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TimedFileOptions controls the retention of the files written by a TimedFile.
+type TimedFileOptions struct {
+	// MaxAge is the age, based on their last modification, beyond which files are removed.
+	// A value of 0 keeps the files whatever their age.
+	MaxAge time.Duration
+
+	// MaxFiles is the number of files kept, the current one included, the oldest being
+	// removed first. A value of 0 keeps all the files.
+	MaxFiles int
+
+	// LocalTime names the files after the local time rather than UTC.
+	LocalTime bool
+
+	// Encrypt encrypts the files of the pattern, in the background, once a newer file is
+	// started. See SetArchiveKeyFunc and NewArchiveReader.
+	Encrypt bool
+}
+
+// TimedFile is a log file whose name is derived from the current time, following a pattern
+// such as app-%Y-%m-%d.log, so that a new file is started whenever the time crosses the
+// boundary of the pattern, e.g. every day. Output paths holding % directives are opened as
+// timed files. This is independent of the size-based rotation of RotateOutputPath.
+//
+// The directives are %Y for the year, %m for the month, %d for the day of the month, %H for
+// the hour, %M for the minute, %j for the day of the year, %G and %V for the ISO year and
+// week, and %% for a percent sign.
+type TimedFile struct {
+	pattern string
+	options TimedFileOptions
+
+	mu        sync.Mutex
+	f         *os.File
+	name      string
+	nextCheck time.Time
+}
+
+// NewTimedFile opens the file for the current time, creating it if needed.
+func NewTimedFile(pattern string, options *TimedFileOptions) (*TimedFile, error) {
+	if err := checkTimePattern(pattern); err != nil {
+		return nil, err
+	}
+
+	tf := &TimedFile{pattern: pattern}
+	if options != nil {
+		tf.options = *options
+	}
+
+	if err := tf.roll(time.Now()); err != nil {
+		return nil, err
+	}
+
+	return tf, nil
+}
+
+// Write appends an entry to the current file, starting a new file first if the time crossed
+// the boundary of the pattern.
+func (tf *TimedFile) Write(p []byte) (int, error) {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	if now := time.Now(); !now.Before(tf.nextCheck) {
+		if err := tf.roll(now); err != nil {
+			return 0, err
+		}
+	}
+
+	return tf.f.Write(p)
+}
+
+// Sync commits the content of the current file to stable storage.
+func (tf *TimedFile) Sync() error {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	return tf.f.Sync()
+}
+
+// Close closes the current file.
+func (tf *TimedFile) Close() error {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	return tf.f.Close()
+}
+
+// Name returns the path of the current file.
+func (tf *TimedFile) Name() string {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	return tf.name
+}
+
+// roll switches to the file of the given time, if it changed, and enforces the retention.
+// Must be called with the lock held, or before the file is shared.
+func (tf *TimedFile) roll(now time.Time) error {
+	if !tf.options.LocalTime {
+		now = now.UTC()
+	}
+
+	// the finest directive is the minute
+	tf.nextCheck = now.Truncate(time.Minute).Add(time.Minute)
+
+	name := expandTimePattern(tf.pattern, now)
+	if name == tf.name && tf.f != nil {
+		return nil
+	}
+
+	if dir := filepath.Dir(name); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+
+	if tf.f != nil {
+		_ = tf.f.Close()
+	}
+	tf.f = f
+	tf.name = name
+
+	tf.cleanup(now)
+	if tf.options.Encrypt {
+		encryptArchivesAsync(func() []string { return tf.archives(name) })
+	}
+	return nil
+}
+
+// archives returns the files of the pattern other than the current one.
+func (tf *TimedFile) archives(current string) []string {
+	matches, _ := filepath.Glob(timePatternGlob(tf.pattern))
+
+	var archives []string
+	for _, m := range matches {
+		if m != current {
+			archives = append(archives, m)
+		}
+	}
+
+	return archives
+}
+
+// cleanup removes the files of the pattern exceeding the retention.
+func (tf *TimedFile) cleanup(now time.Time) {
+	if tf.options.MaxAge <= 0 && tf.options.MaxFiles <= 0 {
+		return
+	}
+
+	matches, err := filepath.Glob(timePatternGlob(tf.pattern))
+	if err != nil {
+		return
+	}
+
+	type file struct {
+		path    string
+		modTime time.Time
+	}
+
+	var files []file
+	for _, m := range matches {
+		if m == tf.name {
+			continue
+		}
+		if info, err := os.Stat(m); err == nil && info.Mode().IsRegular() {
+			files = append(files, file{m, info.ModTime()})
+		}
+	}
+
+	// newest first
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.After(files[j].modTime) })
+
+	for i, f := range files {
+		// the current file counts towards MaxFiles
+		tooMany := tf.options.MaxFiles > 0 && i+1 >= tf.options.MaxFiles
+		tooOld := tf.options.MaxAge > 0 && now.Sub(f.modTime) > tf.options.MaxAge
+		if tooMany || tooOld {
+			_ = os.Remove(f.path)
+		}
+	}
+}
+
+// isTimePattern returns whether a path holds time directives.
+func isTimePattern(path string) bool {
+	return strings.Contains(path, "%")
+}
+
+// checkTimePattern returns an error if the pattern holds unknown directives.
+func checkTimePattern(pattern string) error {
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '%' {
+			continue
+		}
+
+		i++
+		if i == len(pattern) || !strings.ContainsRune("YmdHMjGV%", rune(pattern[i])) {
+			return fmt.Errorf("invalid time pattern '%s', unknown directive at position %d", pattern, i-1)
+		}
+	}
+
+	return nil
+}
+
+// expandTimePattern returns the name of the file of a pattern at the given time.
+func expandTimePattern(pattern string, t time.Time) string {
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '%' || i+1 == len(pattern) {
+			b.WriteByte(pattern[i])
+			continue
+		}
+
+		i++
+		switch pattern[i] {
+		case 'Y':
+			fmt.Fprintf(&b, "%04d", t.Year())
+		case 'm':
+			fmt.Fprintf(&b, "%02d", int(t.Month()))
+		case 'd':
+			fmt.Fprintf(&b, "%02d", t.Day())
+		case 'H':
+			fmt.Fprintf(&b, "%02d", t.Hour())
+		case 'M':
+			fmt.Fprintf(&b, "%02d", t.Minute())
+		case 'j':
+			fmt.Fprintf(&b, "%03d", t.YearDay())
+		case 'G':
+			year, _ := t.ISOWeek()
+			fmt.Fprintf(&b, "%04d", year)
+		case 'V':
+			_, week := t.ISOWeek()
+			fmt.Fprintf(&b, "%02d", week)
+		default:
+			b.WriteByte(pattern[i])
+		}
+	}
+
+	return b.String()
+}
+
+// timePatternGlob returns a glob matching all the files of a pattern.
+func timePatternGlob(pattern string) string {
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case pattern[i] == '%' && i+1 < len(pattern) && pattern[i+1] == '%':
+			b.WriteByte('%')
+			i++
+		case pattern[i] == '%' && i+1 < len(pattern):
+			b.WriteByte('*')
+			i++
+		default:
+			b.WriteByte(pattern[i])
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,110 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExpandTimePattern(t *testing.T) {
+	at := time.Date(2026, 1, 1, 9, 5, 0, 0, time.UTC)
+	cases := []struct {
+		pattern  string
+		expected string
+	}{
+		{"app-%Y-%m-%d.log", "app-2026-01-01.log"},
+		{"app-%Y%m%d-%H%M.log", "app-20260101-0905.log"},
+		{"app-%G-W%V.log", "app-2026-W01.log"},
+		{"app-%j-100%%.log", "app-001-100%.log"},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if got := expandTimePattern(c.pattern, at); got != c.expected {
+				t.Errorf("Got %v, expected %v", got, c.expected)
+			}
+		})
+	}
+
+	if err := checkTimePattern("app-%Q.log"); err == nil {
+		t.Errorf("Got success, expected an unknown directive error")
+	}
+}
+
+func TestTimedFile(t *testing.T) {
+	dir := t.TempDir()
+	pattern := filepath.Join(dir, "app-%Y-%m-%d-%H-%M.log")
+
+	// older files of the pattern, and an unrelated file
+	old := time.Now().Add(-48 * time.Hour)
+	for _, name := range []string{"app-2000-01-01-00-00.log", "app-2000-01-02-00-00.log", "app-2000-01-03-00-00.log", "other.log"} {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte("old\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_ = os.Chtimes(path, old, old)
+		old = old.Add(time.Hour)
+	}
+
+	tf, err := NewTimedFile(pattern, &TimedFileOptions{MaxFiles: 3})
+	if err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	if _, err := tf.Write([]byte("new\n")); err != nil {
+		t.Errorf("Got error '%v', expected success", err)
+	}
+	_ = tf.Close()
+
+	if !strings.HasPrefix(filepath.Base(tf.Name()), "app-"+time.Now().UTC().Format("2006-01-02")) {
+		t.Errorf("Got %v, expected a file named after today", tf.Name())
+	}
+
+	entries, _ := ioutil.ReadDir(dir)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+
+	// the current file and the two newest old ones are kept
+	if len(names) != 4 || names[0] != "app-2000-01-02-00-00.log" || names[1] != "app-2000-01-03-00-00.log" || names[3] != "other.log" {
+		t.Errorf("Got %v, expected the oldest file to be removed", names)
+	}
+}
+
+func TestTimedOutputPath(t *testing.T) {
+	dir := t.TempDir()
+
+	o := DefaultOptions()
+	o.OutputPaths = []string{filepath.Join(dir, "app-%Y-%m-%d.log")}
+	if err := Configure(o); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+	defer func() { _ = Configure(DefaultOptions()) }()
+
+	Info("timed")
+	_ = Sync()
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "app-"+time.Now().UTC().Format("2006-01-02")+".log"))
+	if err != nil || !strings.Contains(string(content), "timed") {
+		t.Errorf("Got '%s' (%v), expected the entry in the file of the day", content, err)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// holds the func() time.Time giving the time of entries
+var timeSource atomic.Value
+
+func init() {
+	timeSource.Store(time.Now)
+}
+
+// SetTimeSource sets the function giving the time of the entries of all the scopes, and
+// the time measured by Start and Progress, by the storm and Every limits, by the error
+// aggregation and escalation windows, and by the stats, for deterministic replays and
+// simulations where time is virtual. Use nil to revert to the wall clock.
+//
+// The wall clock is still used for the time left before the deadlines of contexts, the
+// periodic summaries of dropped and repeated entries, the retries of the network and
+// failover outputs, the outputs rolling over time, and the names of the crash reports.
+func SetTimeSource(fn func() time.Time) {
+	if fn == nil {
+		fn = time.Now
+	}
+
+	timeSource.Store(fn)
+}
+
+// now returns the time from the time source set by SetTimeSource.
+func now() time.Time {
+	return timeSource.Load().(func() time.Time)()
+}
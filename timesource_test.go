@@ -0,0 +1,104 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestSetTimeSource(t *testing.T) {
+	var entries []zapcore.Entry
+	var durations []time.Duration
+	s := NewWithEmit("TestSetTimeSource", "", 0, func(e zapcore.Entry, fields []zapcore.Field) error {
+		entries = append(entries, e)
+		for _, f := range fields {
+			if f.Key == DurationKey {
+				durations = append(durations, time.Duration(f.Integer))
+			}
+		}
+		return nil
+	})
+	s.SetOutputLevel(DebugLevel)
+
+	virtual := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	SetTimeSource(func() time.Time { return virtual })
+	defer SetTimeSource(nil)
+
+	s.Info("first")
+	done := s.Start("step")
+	virtual = virtual.Add(time.Minute)
+	done(nil)
+
+	if len(entries) != 3 {
+		t.Fatalf("Got %d entries, expected 3", len(entries))
+	}
+
+	if expected := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC); !entries[0].Time.Equal(expected) {
+		t.Errorf("Got %v, expected %v", entries[0].Time, expected)
+	}
+
+	if !entries[2].Time.Equal(virtual) {
+		t.Errorf("Got %v, expected %v", entries[2].Time, virtual)
+	}
+
+	if len(durations) != 1 || durations[0] != time.Minute {
+		t.Errorf("Got %v, expected a minute", durations)
+	}
+
+	SetTimeSource(nil)
+	s.Info("wall")
+	if d := time.Since(entries[3].Time); d < 0 || d > time.Minute {
+		t.Errorf("Got %v, expected the wall clock", entries[3].Time)
+	}
+}
+
+func TestSetTimeSourceWindows(t *testing.T) {
+	var messages []string
+	s := NewWithEmit("TestSetTimeSourceWindows", "", 0, func(e zapcore.Entry, _ []zapcore.Field) error {
+		messages = append(messages, e.Message)
+		return nil
+	})
+
+	virtual := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	SetTimeSource(func() time.Time { return virtual })
+	defer SetTimeSource(nil)
+
+	var escalated int
+	remove := AddEscalation(EscalationPolicy{
+		Threshold: 2,
+		Window:    time.Minute,
+		Action:    func(ErrorFingerprint, int) { escalated++ },
+	})
+	defer remove()
+
+	every := s.Every(time.Hour)
+	every.Info("every")
+	s.Error("failed")
+	virtual = virtual.Add(2 * time.Hour)
+	every.Info("every")
+	s.Error("failed")
+
+	expected := []string{"every", "failed", "every", "failed"}
+	if len(messages) != len(expected) {
+		t.Errorf("Got %v, expected %v", messages, expected)
+	}
+
+	if escalated != 0 {
+		t.Errorf("Got %d escalations, expected none for errors further apart than the window", escalated)
+	}
+}
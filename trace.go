@@ -0,0 +1,76 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// TraceSampledFunc reports whether the trace active in a context is sampled. It returns
+// false for ok when the context holds no trace, or its sampling decision is unknown.
+type TraceSampledFunc func(ctx context.Context) (sampled bool, ok bool)
+
+type traceSampledKey struct{}
+
+// set by the Configure method, 1 when only the debug and info entries of sampled traces are emitted
+var traceSampledOnly int32
+
+// holds the TraceSampledFunc used by WithContext
+var traceSampledFn atomic.Value
+
+func init() {
+	traceSampledFn.Store(TraceSampledFunc(traceSampledFromContext))
+}
+
+// ContextWithTraceSampled returns a copy of the context recording the sampling decision
+// of its trace, for applications propagating the decision themselves, for example from
+// the x-b3-sampled header.
+func ContextWithTraceSampled(ctx context.Context, sampled bool) context.Context {
+	return context.WithValue(ctx, traceSampledKey{}, sampled)
+}
+
+func traceSampledFromContext(ctx context.Context) (bool, bool) {
+	sampled, ok := ctx.Value(traceSampledKey{}).(bool)
+	return sampled, ok
+}
+
+// SetTraceSampledFunc sets how the sampling decision of the trace of a context is found,
+// typically from the span context of a tracing library:
+//
+//	log.SetTraceSampledFunc(func(ctx context.Context) (bool, bool) {
+//		sc := trace.SpanContextFromContext(ctx)
+//		return sc.IsSampled(), sc.IsValid()
+//	})
+//
+// The default reads the decision recorded by ContextWithTraceSampled. Use nil to revert
+// to the default.
+func SetTraceSampledFunc(fn TraceSampledFunc) {
+	if fn == nil {
+		fn = traceSampledFromContext
+	}
+
+	traceSampledFn.Store(fn)
+}
+
+// traceUnsampled returns whether the context holds a trace known not to be sampled.
+func traceUnsampled(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+
+	sampled, ok := traceSampledFn.Load().(TraceSampledFunc)(ctx)
+	return ok && !sampled
+}
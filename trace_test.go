@@ -0,0 +1,90 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestTraceSampledOnly(t *testing.T) {
+	var messages []string
+	s := NewWithEmit("TestTraceSampledOnly", "", 0, func(e zapcore.Entry, _ []zapcore.Field) error {
+		messages = append(messages, e.Message)
+		return nil
+	})
+	s.SetOutputLevel(DebugLevel)
+	defer s.SetOutputLevel(InfoLevel)
+	defer func() { _ = Configure(DefaultOptions()) }()
+
+	type ctxKey struct{}
+	custom := func(ctx context.Context) (bool, bool) {
+		sampled, ok := ctx.Value(ctxKey{}).(bool)
+		return sampled, ok
+	}
+
+	cases := []struct {
+		enabled  bool
+		fn       TraceSampledFunc
+		ctx      context.Context
+		expected []string
+	}{
+		{true, nil, context.Background(), []string{"debug", "info", "warn"}},
+		{true, nil, ContextWithTraceSampled(context.Background(), true), []string{"debug", "info", "warn"}},
+		{true, nil, ContextWithTraceSampled(context.Background(), false), []string{"warn"}},
+		{false, nil, ContextWithTraceSampled(context.Background(), false), []string{"debug", "info", "warn"}},
+		{true, custom, context.WithValue(context.Background(), ctxKey{}, false), []string{"warn"}},
+		{true, custom, ContextWithTraceSampled(context.Background(), false), []string{"debug", "info", "warn"}},
+	}
+
+	for i, c := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			o := DefaultOptions()
+			o.TraceSampledOnly = c.enabled
+			if err := Configure(o); err != nil {
+				t.Fatalf("Got error '%v', expected success", err)
+			}
+
+			SetTraceSampledFunc(c.fn)
+			defer SetTraceSampledFunc(nil)
+
+			messages = nil
+			cs := s.WithContext(c.ctx)
+			cs.Debug("debug")
+			cs.Info("info")
+			cs.Warn("warn")
+
+			if len(messages) != len(c.expected) {
+				t.Fatalf("Got %v, expected %v", messages, c.expected)
+			}
+
+			for j := range messages {
+				if messages[j] != c.expected[j] {
+					t.Errorf("Got %v, expected %v", messages, c.expected)
+				}
+			}
+		})
+	}
+
+	// the original scope isn't affected
+	messages = nil
+	s.Debug("debug")
+	if len(messages) != 1 {
+		t.Errorf("Got %v, expected [debug]", messages)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"sync"
+)
+
+// LevelChange describes a change of the output level of a scope.
+type LevelChange struct {
+	// Scope is the name of the scope whose level changed.
+	Scope string
+	// Registry is the registry of the scope, which tells the scopes of isolated registries
+	// apart from those of the process, registered in DefaultRegistry.
+	Registry *Registry
+	// Old is the level before the change.
+	Old Level
+	// New is the level after the change.
+	New Level
+	// Source is what changed the level, such as LevelSourceAPI.
+	Source string
+	// Identity identifies who requested the change through the source, if known.
+	Identity string
+}
+
+// The sources of level changes known to this package.
+const (
+	// LevelSourceAPI is a call to SetOutputLevel.
+	LevelSourceAPI = "api"
+	// LevelSourceConfigure is a call to Configure.
+	LevelSourceConfigure = "configure"
+	// LevelSourceGroup is a call to SetGroupLevel.
+	LevelSourceGroup = "group"
+	// LevelSourceRemote is a configuration pushed by a control plane.
+	LevelSourceRemote = "remote"
+)
+
+var levelWatchers struct {
+	sync.Mutex
+	next     int
+	watchers map[int]func(LevelChange)
+}
+
+// WatchLevels registers a function called whenever the output level of a scope changes,
+// whether through Configure, SetOutputLevel or any other means. This lets components
+// caching whether debug output is enabled, or reporting the levels in effect, stay up
+// to date. The returned function cancels the registration.
+//
+// The function is called synchronously by the goroutine changing the level, so it must
+// return quickly and must not change levels itself.
+func WatchLevels(fn func(LevelChange)) (cancel func()) {
+	levelWatchers.Lock()
+	defer levelWatchers.Unlock()
+
+	if levelWatchers.watchers == nil {
+		levelWatchers.watchers = make(map[int]func(LevelChange))
+	}
+
+	id := levelWatchers.next
+	levelWatchers.next++
+	levelWatchers.watchers[id] = fn
+
+	return func() {
+		levelWatchers.Lock()
+		defer levelWatchers.Unlock()
+
+		delete(levelWatchers.watchers, id)
+	}
+}
+
+func notifyLevelChange(c LevelChange) {
+	levelWatchers.Lock()
+	watchers := make([]func(LevelChange), 0, len(levelWatchers.watchers))
+	for _, fn := range levelWatchers.watchers {
+		watchers = append(watchers, fn)
+	}
+	levelWatchers.Unlock()
+
+	for _, fn := range watchers {
+		fn(c)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"testing"
+)
+
+func TestWatchLevels(t *testing.T) {
+	s := RegisterScope("TestWatchLevels", "", 0)
+
+	var changes []LevelChange
+	cancel := WatchLevels(func(c LevelChange) {
+		if c.Scope == "TestWatchLevels" {
+			changes = append(changes, c)
+		}
+	})
+
+	s.SetOutputLevel(DebugLevel)
+	s.SetOutputLevel(DebugLevel)
+
+	o := DefaultOptions()
+	o.SetOutputLevel("TestWatchLevels", ErrorLevel)
+	_ = Configure(o)
+
+	cancel()
+	s.SetOutputLevel(InfoLevel)
+	_ = Configure(DefaultOptions())
+
+	expected := []LevelChange{
+		{"TestWatchLevels", DefaultRegistry(), InfoLevel, DebugLevel, LevelSourceAPI, ""},
+		{"TestWatchLevels", DefaultRegistry(), DebugLevel, ErrorLevel, LevelSourceConfigure, ""},
+	}
+
+	if len(changes) != len(expected) {
+		t.Fatalf("Got %v, expected %v", changes, expected)
+	}
+
+	for i := range expected {
+		if changes[i] != expected[i] {
+			t.Errorf("Got %v, expected %v", changes[i], expected[i])
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log // nolint: golint
+
+import (
+	"bytes"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// maxLineSize bounds the partial lines buffered by LineWriter.
+const maxLineSize = 64 * 1024
+
+// LineWriter is an io.Writer logging each line written to it as an entry of a scope.
+// It suits components which only report through an io.Writer, such as the output of
+// an exec.Cmd or the ErrorLog of an http.Server. It is safe for concurrent use.
+type LineWriter struct {
+	scope    *Scope
+	level    Level
+	zapLevel zapcore.Level
+
+	mu  sync.Mutex
+	buf []byte
+}
+
+// WriterAt returns a writer logging each line written to it through the scope, at the
+// given level. Lines are split on newlines, which are stripped along with carriage
+// returns, and empty lines are skipped. A partial line is kept until its end is written,
+// or until Flush is called. Lines longer than 64KiB are split.
+//
+//	cmd.Stderr = log.WriterAt(s, log.WarnLevel)
+//	srv.ErrorLog = stdlog.New(log.WriterAt(s, log.ErrorLevel), "", 0)
+func WriterAt(s *Scope, l Level) *LineWriter {
+	return &LineWriter{
+		scope:    s,
+		level:    l,
+		zapLevel: levelToZap[l],
+	}
+}
+
+// Write logs each complete line of p, and buffers the rest.
+func (w *LineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	n := len(p)
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			w.buf = append(w.buf, p...)
+			for len(w.buf) >= maxLineSize {
+				w.emit(w.buf[:maxLineSize])
+				w.buf = append(w.buf[:0], w.buf[maxLineSize:]...)
+			}
+			break
+		}
+
+		if len(w.buf) > 0 {
+			w.buf = append(w.buf, p[:i]...)
+			w.emit(w.buf)
+			w.buf = w.buf[:0]
+		} else {
+			w.emit(p[:i])
+		}
+		p = p[i+1:]
+	}
+
+	return n, nil
+}
+
+// Flush logs the partial line written so far, if any.
+func (w *LineWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.emit(w.buf)
+	w.buf = w.buf[:0]
+}
+
+func (w *LineWriter) emit(line []byte) {
+	line = bytes.TrimRight(line, "\r")
+	if len(line) == 0 || w.level == NoneLevel || !w.scope.enabled(w.level) {
+		return
+	}
+
+	w.scope.emit(w.zapLevel, w.scope.GetStackTraceLevel() >= w.level, string(line), nil)
+}
@@ -0,0 +1,89 @@
+// Copyright (c) Tetrate, Inc 2026 All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWriterAt(t *testing.T) {
+	var lines []string
+	var levels []zapcore.Level
+	s := NewWithEmit("TestWriterAt", "", 0, func(e zapcore.Entry, _ []zapcore.Field) error {
+		lines = append(lines, e.Message)
+		levels = append(levels, e.Level)
+		return nil
+	})
+
+	w := WriterAt(s, WarnLevel)
+	_, _ = fmt.Fprint(w, "first\nsec")
+	_, _ = fmt.Fprint(w, "ond\r\n\nthird")
+
+	if strings.Join(lines, "|") != "first|second" {
+		t.Errorf("Got %v, expected [first second]", lines)
+	}
+
+	w.Flush()
+	if strings.Join(lines, "|") != "first|second|third" {
+		t.Errorf("Got %v, expected [first second third]", lines)
+	}
+
+	for _, l := range levels {
+		if l != zapcore.WarnLevel {
+			t.Errorf("Got %v, expected %v", l, zapcore.WarnLevel)
+		}
+	}
+
+	lines = nil
+	_, _ = fmt.Fprint(w, strings.Repeat("x", maxLineSize+10))
+	w.Flush()
+	if len(lines) != 2 || len(lines[0]) != maxLineSize || len(lines[1]) != 10 {
+		t.Errorf("Got %d lines, expected the long line to be split", len(lines))
+	}
+
+	lines = nil
+	_, _ = fmt.Fprintln(WriterAt(s, DebugLevel), "disabled")
+	if len(lines) != 0 {
+		t.Errorf("Got %v, expected no entry for a disabled level", lines)
+	}
+}
+
+func TestWriterAtCommand(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no shell available")
+	}
+
+	var lines []string
+	s := NewWithEmit("TestWriterAtCommand", "", 0, func(e zapcore.Entry, _ []zapcore.Field) error {
+		lines = append(lines, e.Message)
+		return nil
+	})
+
+	cmd := exec.Command(sh, "-c", "echo one; echo two")
+	cmd.Stdout = WriterAt(s, InfoLevel)
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("Got error '%v', expected success", err)
+	}
+
+	if strings.Join(lines, "|") != "one|two" {
+		t.Errorf("Got %v, expected [one two]", lines)
+	}
+}